@@ -1,14 +1,18 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/fatih/structs"
+	"github.com/netapp/terraform-provider-netapp-cloudmanager/cloudmanager/cloudmanager/restapi"
 )
 
 // createAggregateRequest the users input for creating an Aggregate
@@ -108,7 +112,7 @@ type updateAggregateRequest struct {
 }
 
 // get aggregate by workingEnvironmentId+aggregate name
-func (c *Client) getAggregate(request aggregateRequest, name string, sourceWorkingEnvironmentType string, clientID string) (aggregateResult, error) {
+func (c *Client) getAggregate(ctx context.Context, request aggregateRequest, name string, sourceWorkingEnvironmentType string, clientID string) (aggregateResult, error) {
 	log.Printf("getAggregate %s", name)
 	hostType := "CloudManagerHost"
 
@@ -116,7 +120,7 @@ func (c *Client) getAggregate(request aggregateRequest, name string, sourceWorki
 	if sourceWorkingEnvironmentType == "ON_PREM" {
 		baseURL = fmt.Sprintf("/occm/api/onprem/aggregates?workingEnvironmentId=%s", request.WorkingEnvironmentID)
 	} else {
-		rootURL, cloudProviderName, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID)
+		rootURL, cloudProviderName, err := c.getAPIRoot(ctx, request.WorkingEnvironmentID, clientID)
 
 		if err != nil {
 			log.Print("getAggregate: Cannot get API root.")
@@ -132,17 +136,12 @@ func (c *Client) getAggregate(request aggregateRequest, name string, sourceWorki
 
 	var aggregates []aggregateResult
 
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	_, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Printf("getAggregate request failed. Response %v, err %v", response, err)
 		return aggregateResult{}, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "getAggregate")
-	if responseError != nil {
-		return aggregateResult{}, responseError
-	}
-
 	if err := json.Unmarshal(response, &aggregates); err != nil {
 		log.Print("Failed to unmarshall response from getAggregates")
 		return aggregateResult{}, err
@@ -163,13 +162,13 @@ func (c *Client) getAggregate(request aggregateRequest, name string, sourceWorki
 }
 
 // create aggregate
-func (c *Client) createAggregate(request *createAggregateRequest, clientID string) (aggregateResult, error) {
+func (c *Client) createAggregate(ctx context.Context, request *createAggregateRequest, clientID string) (aggregateResult, error) {
 	log.Printf("createAggregate %v... ", (*request).Name)
 	params := structs.Map(request)
 	hostType := "CloudManagerHost"
 
 	var baseURL string
-	rootURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID)
+	rootURL, _, err := c.getAPIRoot(ctx, request.WorkingEnvironmentID, clientID)
 
 	if err != nil {
 		log.Print("createAggregate: Cannot get API root.")
@@ -181,28 +180,27 @@ func (c *Client) createAggregate(request *createAggregateRequest, clientID strin
 	maxRetries := 24 // max retry 150 sec * 24 = 1hr
 	for {
 		log.Print("Call aggregate creation API... ", (*request).Name)
-		statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+		_, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
 		if err != nil {
-			log.Print("createAggregate request failed", (*request).Name)
-			return aggregateResult{}, err
-		}
-		responseError := apiResponseChecker(statusCode, response, "createAggregate")
-		if responseError != nil {
-			if strings.Contains(responseError.Error(), "code: 409, message: {\"message\":\"Couldn't perform action Create Aggregate, because there are ongoing operations which might interfere with it") {
+			var apiErr *restapi.APIError
+			if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict && strings.Contains(apiErr.Message, "Couldn't perform action Create Aggregate, because there are ongoing operations which might interfere with it") {
 				if retries >= maxRetries {
 					log.Print("Failed: Reached aggregate creation max retries.")
 					break
 				}
 				retries++
 				log.Print("Wait for 150 seconds... ", retries)
-				time.Sleep(150 * time.Second)
+				if err := sleepWithContext(ctx, 150*time.Second); err != nil {
+					return aggregateResult{}, err
+				}
 			} else {
-				return aggregateResult{}, responseError
+				log.Print("createAggregate request failed", (*request).Name)
+				return aggregateResult{}, err
 			}
 		} else {
 			// wait for creation
 			log.Print("Wait for aggregate creation... ", (*request).Name)
-			err = c.waitOnCompletion(onCloudRequestID, "Aggregate", "create", 15, 60, clientID)
+			err = c.waitOnCompletion(ctx, onCloudRequestID, "Aggregate", "create", 60, clientID)
 			log.Print("Finish waiting... ", (*request).Name)
 			if err != nil {
 				return aggregateResult{}, err
@@ -211,14 +209,14 @@ func (c *Client) createAggregate(request *createAggregateRequest, clientID strin
 		}
 	}
 
-	workingEnvDetail, err := c.getWorkingEnvironmentInfo(request.WorkingEnvironmentID, clientID)
+	workingEnvDetail, err := c.getWorkingEnvironmentInfo(ctx, request.WorkingEnvironmentID, clientID)
 	if err != nil {
 		log.Print("Cannot get working environment information.")
 		return aggregateResult{}, err
 	}
 
 	var aggregate aggregateResult
-	aggregate, err = c.getAggregate(aggregateRequest{WorkingEnvironmentID: request.WorkingEnvironmentID}, request.Name, workingEnvDetail.WorkingEnvironmentType, clientID)
+	aggregate, err = c.getAggregate(ctx, aggregateRequest{WorkingEnvironmentID: request.WorkingEnvironmentID}, request.Name, workingEnvDetail.WorkingEnvironmentType, clientID)
 	if err != nil {
 		return aggregateResult{}, err
 	}
@@ -227,12 +225,12 @@ func (c *Client) createAggregate(request *createAggregateRequest, clientID strin
 }
 
 // delete aggregate
-func (c *Client) deleteAggregate(request deleteAggregateRequest, clientID string) error {
+func (c *Client) deleteAggregate(ctx context.Context, request deleteAggregateRequest, clientID string) error {
 	log.Print("On deleteAggregate... ")
 	hostType := "CloudManagerHost"
 
 	var baseURL string
-	rootURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID)
+	rootURL, _, err := c.getAPIRoot(ctx, request.WorkingEnvironmentID, clientID)
 
 	if err != nil {
 		log.Print("deleteAggregate: Cannot get API root.")
@@ -241,19 +239,14 @@ func (c *Client) deleteAggregate(request deleteAggregateRequest, clientID string
 
 	baseURL = fmt.Sprintf("%s/aggregates/%s/%s", rootURL, request.WorkingEnvironmentID, request.Name)
 
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	_, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("deleteAggregate request failed")
 		return err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "deleteAggregate")
-	if responseError != nil {
-		return responseError
-	}
-
 	log.Print("Wait for aggregate deletion.")
-	err = c.waitOnCompletion(onCloudRequestID, "Aggregate", "delete", 10, 60, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "Aggregate", "delete", 60, clientID)
 	if err != nil {
 		return err
 	}
@@ -261,13 +254,13 @@ func (c *Client) deleteAggregate(request deleteAggregateRequest, clientID string
 	return nil
 }
 
-func (c *Client) updateAggregate(request updateAggregateRequest, clientID string) error {
+func (c *Client) updateAggregate(ctx context.Context, request updateAggregateRequest, clientID string) error {
 	log.Print("updateAggregate... ")
 	params := structs.Map(request)
 	hostType := "CloudManagerHost"
 
 	var baseURL string
-	rootURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID)
+	rootURL, _, err := c.getAPIRoot(ctx, request.WorkingEnvironmentID, clientID)
 
 	if err != nil {
 		log.Print("updateAggregate: Cannot get API root.")
@@ -275,19 +268,14 @@ func (c *Client) updateAggregate(request updateAggregateRequest, clientID string
 	}
 	baseURL = fmt.Sprintf("%s/aggregates/%s/%s/disks", rootURL, request.WorkingEnvironmentID, request.Name)
 
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	_, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
 	if err != nil {
 		log.Print("updateAggregate request failed")
 		return err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "updateAggregate")
-	if responseError != nil {
-		return responseError
-	}
-
 	log.Print("Wait for aggregate update.")
-	err = c.waitOnCompletion(onCloudRequestID, "Aggregate", "update", 10, 60, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "Aggregate", "update", 60, clientID)
 	if err != nil {
 		return err
 	}
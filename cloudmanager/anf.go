@@ -1,6 +1,7 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -68,21 +69,16 @@ type cvsInfo struct {
 	SubnetName         string `structs:"subnetName"`
 }
 
-func (c *Client) getAccountByName(name string, clientID string) (string, error) {
+func (c *Client) getAccountByName(ctx context.Context, name string, clientID string) (string, error) {
 	log.Print("getAccount")
 
 	baseURL := "/tenancy/account"
 	hostType := "CloudManagerHost"
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getAccount request failed ", statusCode)
 		return "", err
 	}
-	responseError := apiResponseChecker(statusCode, response, "getAccount")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	var results []accountIDResult
 	if err := json.Unmarshal(response, &results); err != nil {
 		log.Print("Failed to unmarshall response from getAccount ", err)
@@ -104,16 +100,16 @@ func (c *Client) getAccountByName(name string, clientID string) (string, error)
 	return results[0].AccountID, nil
 }
 
-func (c *Client) createANFVolume(vol anfVolumeRequest, info cvsInfo, clientID string) error {
-	baseURL, err := c.getCVSAPIRoot(info.AccountName, vol.WorkingEnvironmentName, clientID)
+func (c *Client) createANFVolume(ctx context.Context, vol anfVolumeRequest, info cvsInfo, clientID string) error {
+	baseURL, err := c.getCVSAPIRoot(ctx, info.AccountName, vol.WorkingEnvironmentName, clientID)
 	if err != nil {
 		return err
 	}
-	subscription, err := c.getSubscription(baseURL, info.SubscriptionName, clientID)
+	subscription, err := c.getSubscription(ctx, baseURL, info.SubscriptionName, clientID)
 	if err != nil {
 		return err
 	}
-	subnet, err := c.getSubnetID(fmt.Sprintf("%s/subscriptions/%s", baseURL, subscription), info.VirtualNetworkName, info.SubnetName, vol.Location, clientID)
+	subnet, err := c.getSubnetID(ctx, fmt.Sprintf("%s/subscriptions/%s", baseURL, subscription), info.VirtualNetworkName, info.SubnetName, vol.Location, clientID)
 	if err != nil {
 		return err
 	}
@@ -121,29 +117,24 @@ func (c *Client) createANFVolume(vol anfVolumeRequest, info cvsInfo, clientID st
 	hostType := "CVSHost"
 	param := structs.Map(vol)
 	param["subnetId"] = subnet
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, param, c.Token, hostType, clientID)
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "POST", baseURL, param, true, hostType, clientID)
 	if err != nil {
 		log.Print("createANFVolume request failed ", statusCode)
 		return err
 	}
-	responseError := apiResponseChecker(statusCode, response, "createANFVolume")
-	if responseError != nil {
-		return responseError
-	}
-
 	return nil
 }
 
-func (c *Client) getANFVolume(vol anfVolumeRequest, info cvsInfo, clientID string) (anfVolumeResponse, error) {
-	baseURL, err := c.getCVSAPIRoot(info.AccountName, vol.WorkingEnvironmentName, clientID)
+func (c *Client) getANFVolume(ctx context.Context, vol anfVolumeRequest, info cvsInfo, clientID string) (anfVolumeResponse, error) {
+	baseURL, err := c.getCVSAPIRoot(ctx, info.AccountName, vol.WorkingEnvironmentName, clientID)
 	if err != nil {
 		return anfVolumeResponse{}, err
 	}
-	subscription, err := c.getSubscription(baseURL, info.SubscriptionName, clientID)
+	subscription, err := c.getSubscription(ctx, baseURL, info.SubscriptionName, clientID)
 	if err != nil {
 		return anfVolumeResponse{}, err
 	}
-	subnet, err := c.getSubnetID(fmt.Sprintf("%s/subscriptions/%s", baseURL, subscription), info.VirtualNetworkName, info.SubnetName, vol.Location, clientID)
+	subnet, err := c.getSubnetID(ctx, fmt.Sprintf("%s/subscriptions/%s", baseURL, subscription), info.VirtualNetworkName, info.SubnetName, vol.Location, clientID)
 	if err != nil {
 		return anfVolumeResponse{}, err
 	}
@@ -151,15 +142,11 @@ func (c *Client) getANFVolume(vol anfVolumeRequest, info cvsInfo, clientID strin
 	hostType := "CVSHost"
 	param := structs.Map(vol)
 	param["subnetId"] = subnet
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getANFVolume request failed ", statusCode)
 		return anfVolumeResponse{}, err
 	}
-	responseError := apiResponseChecker(statusCode, response, "getANFVolume")
-	if responseError != nil {
-		return anfVolumeResponse{}, responseError
-	}
 	var result anfVolumeResponse
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getANFVolume ", err)
@@ -169,27 +156,22 @@ func (c *Client) getANFVolume(vol anfVolumeRequest, info cvsInfo, clientID strin
 	return result, nil
 }
 
-func (c *Client) getCVSWorkingEnvironment(accountID string, WorkingEnvironment string, clientID string) (string, string, error) {
-	if c.Token == "" {
-		accesTokenResult, err := c.getAccessToken()
+func (c *Client) getCVSWorkingEnvironment(ctx context.Context, accountID string, WorkingEnvironment string, clientID string) (string, string, error) {
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			log.Print("Not able to get the access token.")
 			return "", "", err
 		}
-		c.Token = accesTokenResult.Token
 	}
 
 	baseURL := fmt.Sprintf("/cvs/accounts/%s/working-environments", accountID)
 	hostType := "CVSHost"
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getCVSWorkingEnvironment request failed ", statusCode)
 		return "", "", err
 	}
-	responseError := apiResponseChecker(statusCode, response, "getCVSWorkingEnvironment")
-	if responseError != nil {
-		return "", "", responseError
-	}
 	var results []map[string]interface{}
 	if err := json.Unmarshal(response, &results); err != nil {
 		log.Print("Failed to unmarshall response from getCVSWorkingEnvironment ", err)
@@ -205,20 +187,19 @@ func (c *Client) getCVSWorkingEnvironment(accountID string, WorkingEnvironment s
 	return "", "", fmt.Errorf(" working environment: %s doesn't exist", WorkingEnvironment)
 }
 
-func (c *Client) getCVSAPIRoot(accountName string, workingEnvironment string, clientID string) (string, error) {
-	if c.Token == "" {
-		accesTokenResult, err := c.getAccessToken()
+func (c *Client) getCVSAPIRoot(ctx context.Context, accountName string, workingEnvironment string, clientID string) (string, error) {
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			log.Print("Not able to get the access token.")
 			return "", err
 		}
-		c.Token = accesTokenResult.Token
 	}
-	accountID, err := c.getAccountByName(accountName, clientID)
+	accountID, err := c.getAccountByName(ctx, accountName, clientID)
 	if err != nil {
 		return "", err
 	}
-	credentialsID, provider, err := c.getCVSWorkingEnvironment(accountID, workingEnvironment, clientID)
+	credentialsID, provider, err := c.getCVSWorkingEnvironment(ctx, accountID, workingEnvironment, clientID)
 	if err != nil {
 		return "", err
 	}
@@ -234,27 +215,21 @@ func (c *Client) getCVSAPIRoot(accountName string, workingEnvironment string, cl
 	}
 }
 
-func (c *Client) getSubscription(baseURL string, subscription string, clientID string) (string, error) {
-	if c.Token == "" {
-		accesTokenResult, err := c.getAccessToken()
+func (c *Client) getSubscription(ctx context.Context, baseURL string, subscription string, clientID string) (string, error) {
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			log.Print("Not able to get the access token.")
 			return "", err
 		}
-		c.Token = accesTokenResult.Token
 	}
 	baseURL = fmt.Sprintf("%s/subscriptions", baseURL)
 	hostType := "CVSHost"
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getSubscriptions request failed ", statusCode)
 		return "", err
 	}
-	responseError := apiResponseChecker(statusCode, response, "getSubscriptions")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	var results []map[string]interface{}
 	if err := json.Unmarshal(response, &results); err != nil {
 		log.Print("Failed to unmarshall response from getSubscriptions ", err)
@@ -270,27 +245,21 @@ func (c *Client) getSubscription(baseURL string, subscription string, clientID s
 
 }
 
-func (c *Client) getSubnetID(baseURL string, virtualNetwork string, subnet string, location string, clientID string) (string, error) {
-	if c.Token == "" {
-		accesTokenResult, err := c.getAccessToken()
+func (c *Client) getSubnetID(ctx context.Context, baseURL string, virtualNetwork string, subnet string, location string, clientID string) (string, error) {
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			log.Print("Not able to get the access token.")
 			return "", err
 		}
-		c.Token = accesTokenResult.Token
 	}
 	baseURL = fmt.Sprintf("%s/virtualNetworks?location=%s", baseURL, location)
 	hostType := "CVSHost"
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getSubnetID request failed ", statusCode)
 		return "", err
 	}
-	responseError := apiResponseChecker(statusCode, response, "getSubnetID")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	var results []interface{}
 	if err := json.Unmarshal(response, &results); err != nil {
 		log.Print("Failed to unmarshall response from getSubnetID ", err)
@@ -311,26 +280,21 @@ func (c *Client) getSubnetID(baseURL string, virtualNetwork string, subnet strin
 
 }
 
-func (c *Client) deleteANFVolume(vol anfVolumeRequest, info cvsInfo, clientID string) error {
-	baseURL, err := c.getCVSAPIRoot(info.AccountName, vol.WorkingEnvironmentName, clientID)
+func (c *Client) deleteANFVolume(ctx context.Context, vol anfVolumeRequest, info cvsInfo, clientID string) error {
+	baseURL, err := c.getCVSAPIRoot(ctx, info.AccountName, vol.WorkingEnvironmentName, clientID)
 	if err != nil {
 		return err
 	}
-	subscription, err := c.getSubscription(baseURL, info.SubscriptionName, clientID)
+	subscription, err := c.getSubscription(ctx, baseURL, info.SubscriptionName, clientID)
 	if err != nil {
 		return err
 	}
 	baseURL = fmt.Sprintf("%s/subscriptions/%s/resourceGroups/%s/netAppAccounts/%s/capacityPools/%s/volumes/%s", baseURL, subscription, info.ResourceGroupsName, info.NetAppAccountName, info.CapacityPools, vol.Name)
 	hostType := "CVSHost"
-	statusCode, response, _, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("deleteANFVolume request failed ", statusCode)
 		return err
 	}
-	responseError := apiResponseChecker(statusCode, response, "deleteANFVolume")
-	if responseError != nil {
-		return responseError
-	}
-
 	return nil
 }
@@ -1,13 +1,14 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"time"
 
 	"github.com/fatih/structs"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // createAWSFSXDetails the users input for creating a FSX
@@ -111,7 +112,7 @@ func expandfsxTags(set *schema.Set) []fsxTags {
 	return tags
 }
 
-func (c *Client) getAWSCredentialsID(name string, tenantID string) (string, error) {
+func (c *Client) getAWSCredentialsID(ctx context.Context, name string, tenantID string) (string, error) {
 
 	log.Print("getAWSCredentialsID ", tenantID)
 
@@ -119,17 +120,12 @@ func (c *Client) getAWSCredentialsID(name string, tenantID string) (string, erro
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, "")
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, "")
 	if err != nil {
 		log.Print("getAWSCredentialsID request failed ", statusCode)
 		return "", err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "getAWSCredentialsID")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	var result []fsxResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getAWSCredentialsID ", err)
@@ -145,32 +141,26 @@ func (c *Client) getAWSCredentialsID(name string, tenantID string) (string, erro
 	return "", fmt.Errorf("aws_credentials_name not found")
 }
 
-func (c *Client) getAWSFSX(id string, tenantID string) (string, error) {
+func (c *Client) getAWSFSX(ctx context.Context, id string, tenantID string) (string, error) {
 
 	log.Print("getAWSFSX")
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in getAWSFSX request, failed to get AccessToken")
 		return "", err
 	}
-	c.Token = accessTokenResult.Token
 
 	baseURL := fmt.Sprintf("/fsx-ontap/working-environments/%s", tenantID)
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, "")
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, "")
 	if err != nil {
 		log.Print("getAWSFSX request failed ", statusCode, err)
 		return "", err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "getAWSFSX")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	var result []fsxResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getAWSFSX ", err)
@@ -186,32 +176,26 @@ func (c *Client) getAWSFSX(id string, tenantID string) (string, error) {
 	return "", nil
 }
 
-func (c *Client) getAWSFSXByID(id string, tenantID string) (fsxResult, error) {
+func (c *Client) getAWSFSXByID(ctx context.Context, id string, tenantID string) (fsxResult, error) {
 
 	log.Print("getAWSFSXByID")
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in getAWSFSXByID request, failed to get AccessToken")
 		return fsxResult{}, err
 	}
-	c.Token = accessTokenResult.Token
 
 	baseURL := fmt.Sprintf("/fsx-ontap/working-environments/%s/%s?provider-details=true", tenantID, id)
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, "")
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, "")
 	if err != nil {
 		log.Print("getAWSFSXByID request failed ", statusCode, err)
 		return fsxResult{}, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "getAWSFSXByID")
-	if responseError != nil {
-		return fsxResult{}, responseError
-	}
-
 	var result fsxResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getAWSFSXByID ", err)
@@ -221,18 +205,17 @@ func (c *Client) getAWSFSXByID(id string, tenantID string) (fsxResult, error) {
 	return result, nil
 }
 
-func (c *Client) importAWSFSX(fsxDetails createAWSFSXDetails, fileSystemID string) (string, error) {
+func (c *Client) importAWSFSX(ctx context.Context, fsxDetails createAWSFSXDetails, fileSystemID string) (string, error) {
 
 	log.Print("importAWSFSX")
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in importAWSFSX request, failed to get AccessToken")
 		return "", fmt.Errorf("in importAWSFSX request, failed to get AccessToken: %s", err)
 	}
-	c.Token = accessTokenResult.Token
 
-	fsxDetails.AWSCredentials, err = c.getAWSCredentialsID(fsxDetails.AWSCredentials, fsxDetails.TenantID)
+	fsxDetails.AWSCredentials, err = c.getAWSCredentialsID(ctx, fsxDetails.AWSCredentials, fsxDetails.TenantID)
 	if err != nil {
 		log.Print("importAWSFSX request failed ", err)
 		return "", fmt.Errorf("importAWSFSX request failed: %s", err)
@@ -243,17 +226,12 @@ func (c *Client) importAWSFSX(fsxDetails createAWSFSXDetails, fileSystemID strin
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, "")
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, "")
 	if err != nil {
 		log.Print("importAWSFSX request failed ", statusCode)
 		return "", fmt.Errorf("importAWSFSX request failed: %s", err)
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "importAWSFSX")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	var result []fsxResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from importAWSFSX ", err)
@@ -285,32 +263,26 @@ func (c *Client) importAWSFSX(fsxDetails createAWSFSXDetails, fileSystemID strin
 
 	params := structs.Map(recoverAWSFSXDetails)
 
-	statusCode, response, _, err = c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, "")
+	statusCode, response, _, err = c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, "")
 	if err != nil {
 		log.Print("importAWSFSX request failed ", statusCode)
 		return "", fmt.Errorf("importAWSFSX request failed: %s", err)
 	}
 
-	responseError = apiResponseChecker(statusCode, response, "importAWSFSX")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	return fileSystemID, nil
 }
 
-func (c *Client) createAWSFSX(fsxDetails createAWSFSXDetails) (fsxResult, error) {
+func (c *Client) createAWSFSX(ctx context.Context, fsxDetails createAWSFSXDetails) (fsxResult, error) {
 
 	log.Print("createFSX")
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in createFSX request, failed to get AccessToken")
 		return fsxResult{}, err
 	}
-	c.Token = accessTokenResult.Token
 
-	fsxDetails.AWSCredentials, err = c.getAWSCredentialsID(fsxDetails.AWSCredentials, fsxDetails.TenantID)
+	fsxDetails.AWSCredentials, err = c.getAWSCredentialsID(ctx, fsxDetails.AWSCredentials, fsxDetails.TenantID)
 	if err != nil {
 		log.Print("createFSX request failed ", err)
 		return fsxResult{}, err
@@ -319,28 +291,22 @@ func (c *Client) createAWSFSX(fsxDetails createAWSFSXDetails) (fsxResult, error)
 	baseURL := fmt.Sprintf("/fsx-ontap/working-environments/%s", fsxDetails.TenantID)
 
 	creationWaitTime := 60
-	creationRetryCount := 60
 	hostType := "CloudManagerHost"
 	params := structs.Map(fsxDetails)
 
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, "")
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, "")
 	if err != nil {
 		log.Print("createFSX request failed ", statusCode)
 		return fsxResult{}, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "createFSX")
-	if responseError != nil {
-		return fsxResult{}, responseError
-	}
-
 	var result fsxResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from createFSX ", err)
 		return fsxResult{}, err
 	}
 
-	err = c.waitOnCompletionFSX(result.ID, fsxDetails.TenantID, "FSX", "create", creationRetryCount, creationWaitTime)
+	err = c.waitOnCompletionFSX(ctx, result.ID, fsxDetails.TenantID, "FSX", "create", creationWaitTime)
 	if err != nil {
 		return fsxResult{}, err
 	}
@@ -348,7 +314,7 @@ func (c *Client) createAWSFSX(fsxDetails createAWSFSXDetails) (fsxResult, error)
 	return result, nil
 }
 
-func (c *Client) checkTaskStatusFSX(id string, tenantID string) (providerDetails, string, error) {
+func (c *Client) checkTaskStatusFSX(ctx context.Context, id string, tenantID string) (providerDetails, string, error) {
 
 	log.Printf("checkTaskStatusFSX: %s", tenantID)
 
@@ -356,29 +322,10 @@ func (c *Client) checkTaskStatusFSX(id string, tenantID string) (providerDetails
 
 	hostType := "CloudManagerHost"
 
-	var statusCode int
-	var response []byte
-	networkRetries := 3
-	for {
-		code, result, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, "")
-		if err != nil {
-			if networkRetries > 0 {
-				time.Sleep(1 * time.Second)
-				networkRetries--
-			} else {
-				log.Print("checkTaskStatusFSX request failed ", code)
-				return providerDetails{}, "", err
-			}
-		} else {
-			statusCode = code
-			response = result
-			break
-		}
-	}
-
-	responseError := apiResponseChecker(statusCode, response, "checkTaskStatusFSX")
-	if responseError != nil {
-		return providerDetails{}, "", responseError
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, "")
+	if err != nil {
+		log.Print("checkTaskStatusFSX request failed ", statusCode)
+		return providerDetails{}, "", err
 	}
 
 	var result fsxStatusResult
@@ -390,51 +337,43 @@ func (c *Client) checkTaskStatusFSX(id string, tenantID string) (providerDetails
 	return result.ProviderDetails, result.Error, nil
 }
 
-func (c *Client) waitOnCompletionFSX(id string, tenantID string, actionName string, task string, retries int, waitInterval int) error {
+func (c *Client) waitOnCompletionFSX(ctx context.Context, id string, tenantID string, actionName string, task string, waitInterval int) error {
 	for {
-		fsxStatus, failureErrorMessage, err := c.checkTaskStatusFSX(id, tenantID)
+		fsxStatus, failureErrorMessage, err := c.checkTaskStatusFSX(ctx, id, tenantID)
 		if err != nil {
-			return err
+			return pollTimeoutError(ctx, err, actionName, task)
 		}
 		if fsxStatus.Status.Status == "ON" && fsxStatus.Status.Lifecycle != "CREATING" {
 			return nil
 		} else if fsxStatus.Status.Status == "FAILED" {
 			return fmt.Errorf("Failed to %s %s, error: %s", task, actionName, failureErrorMessage)
-		} else if retries == 0 {
-			log.Print("Taking too long to ", task, actionName)
-			return fmt.Errorf("Taking too long for %s to %s or not properly setup", actionName, task)
 		}
 		log.Printf("Sleep for %d seconds", waitInterval)
-		time.Sleep(time.Duration(waitInterval) * time.Second)
-		retries--
+		if err := sleepWithContext(ctx, time.Duration(waitInterval)*time.Second); err != nil {
+			return pollTimeoutError(ctx, err, actionName, task)
+		}
 	}
 }
 
-func (c *Client) deleteAWSFSX(id string, tenantID string) error {
+func (c *Client) deleteAWSFSX(ctx context.Context, id string, tenantID string) error {
 
 	log.Print("deleteAWSFSX")
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in deleteAWSFSX request, failed to get AccessToken")
 		return err
 	}
-	c.Token = accessTokenResult.Token
 
 	baseURL := fmt.Sprintf("/fsx-ontap/working-environments/%s/%s", tenantID, id)
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, _, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, "")
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, "")
 	if err != nil {
 		log.Print("deleteAWSFSX request failed ", statusCode)
 		return err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "deleteAWSFSX")
-	if responseError != nil {
-		return responseError
-	}
-
 	return nil
 }
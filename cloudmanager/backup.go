@@ -0,0 +1,283 @@
+package cloudmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+// backupActivation is the Cloud Backup configuration of a working environment, backing up its volumes to the
+// object storage of the cloud provider
+type backupActivation struct {
+	Provider          string `structs:"provider" json:"provider"`
+	Region            string `structs:"region,omitempty" json:"region"`
+	Bucket            string `structs:"bucket,omitempty" json:"bucket"`
+	ResourceGroup     string `structs:"resourceGroup,omitempty" json:"resourceGroup"`
+	StorageAccount    string `structs:"storageAccount,omitempty" json:"storageAccount"`
+	ProjectID         string `structs:"projectId,omitempty" json:"projectId"`
+	EncryptionKeyID   string `structs:"encryptionKeyId,omitempty" json:"encryptionKeyId"`
+	IPSpace           string `structs:"ipSpace,omitempty" json:"ipSpace"`
+	PolicyName        string `structs:"policyName,omitempty" json:"policyName"`
+	AutoBackupEnabled bool   `structs:"autoBackupEnabled" json:"autoBackupEnabled"`
+	Status            string `structs:"-" json:"status"`
+}
+
+type backupActivationUpdateRequest struct {
+	PolicyName        string `structs:"policyName,omitempty"`
+	AutoBackupEnabled bool   `structs:"autoBackupEnabled"`
+}
+
+// backupPolicyRequest keeps the latest backups of each label, moving them to the archive storage class after
+// the number of days of the archive policy
+type backupPolicyRequest struct {
+	Name          string              `structs:"name,omitempty"`
+	Rules         []backupPolicyRule  `structs:"rules"`
+	ArchivePolicy backupArchivePolicy `structs:"archivePolicy"`
+}
+
+type backupPolicyRule struct {
+	Label     string `structs:"label" json:"label"`
+	Retention int    `structs:"retention" json:"retention"`
+}
+
+type backupArchivePolicy struct {
+	ArchiveAfterDays int    `structs:"archiveAfterDays" json:"archiveAfterDays"`
+	StorageClass     string `structs:"storageClass,omitempty" json:"storageClass"`
+}
+
+type backupPolicyResponse struct {
+	Name          string              `json:"name"`
+	Rules         []backupPolicyRule  `json:"rules"`
+	ArchivePolicy backupArchivePolicy `json:"archivePolicy"`
+}
+
+type volumeBackupRequest struct {
+	VolumeName string `structs:"volumeName,omitempty"`
+	SvmName    string `structs:"svmName,omitempty"`
+	PolicyName string `structs:"policyName"`
+}
+
+type volumeBackupResponse struct {
+	VolumeName   string `json:"volumeName"`
+	SvmName      string `json:"svmName"`
+	PolicyName   string `json:"policyName"`
+	BackupStatus string `json:"backupStatus"`
+}
+
+// backupPolicyLabels are the labels of the rules of a backup policy, by frequency
+var backupPolicyLabels = []string{"daily", "weekly", "monthly"}
+
+func expandBackupPolicyRules(retentions map[string]int) []backupPolicyRule {
+	rules := make([]backupPolicyRule, 0, len(backupPolicyLabels))
+	for _, label := range backupPolicyLabels {
+		if retentions[label] > 0 {
+			rules = append(rules, backupPolicyRule{Label: label, Retention: retentions[label]})
+		}
+	}
+	return rules
+}
+
+func flattenBackupPolicyRules(rules []backupPolicyRule) map[string]int {
+	retentions := map[string]int{}
+	for _, label := range backupPolicyLabels {
+		retentions[label] = 0
+	}
+	for _, rule := range rules {
+		retentions[rule.Label] = rule.Retention
+	}
+	return retentions
+}
+
+// backupURL returns the Cloud Backup URL of the working environment
+func (c *Client) backupURL(ctx context.Context, accountID string, workingEnvironmentID string) (string, error) {
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
+		if err != nil {
+			log.Print("in backupURL request, failed to get AccessToken")
+			return "", err
+		}
+	}
+	return fmt.Sprintf("/account/%s/providers/cloudmanager_cbs/api/v3/backup/working-environment/%s", accountID, workingEnvironmentID), nil
+}
+
+// callBackupAPI sends the request to Cloud Backup, waiting on the task it starts
+func (c *Client) callBackupAPI(ctx context.Context, method string, baseURL string, request interface{}, functionName string, actionName string, task string, clientID string) error {
+	hostType := "CloudManagerHost"
+	var params map[string]interface{}
+	if request != nil {
+		params = structs.Map(request)
+	}
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, method, baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Printf("%s request failed %d", functionName, statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, actionName, task, 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) activateBackup(ctx context.Context, accountID string, workingEnvironmentID string, activation backupActivation, clientID string) error {
+	baseURL, err := c.backupURL(ctx, accountID, workingEnvironmentID)
+	if err != nil {
+		return err
+	}
+	return c.callBackupAPI(ctx, "POST", baseURL, activation, "activateBackup", "backup", "activate", clientID)
+}
+
+// getBackupActivation returns the Cloud Backup configuration of the working environment, or errNotFound when
+// Cloud Backup is not active
+func (c *Client) getBackupActivation(ctx context.Context, accountID string, workingEnvironmentID string, clientID string) (backupActivation, error) {
+	baseURL, err := c.backupURL(ctx, accountID, workingEnvironmentID)
+	if err != nil {
+		return backupActivation{}, err
+	}
+	hostType := "CloudManagerHost"
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("getBackupActivation request failed ", statusCode)
+		return backupActivation{}, err
+	}
+	var result backupActivation
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getBackupActivation ", err)
+		return backupActivation{}, err
+	}
+	if result.Status == "" || result.Status == "OFF" {
+		return backupActivation{}, fmt.Errorf("backup of %s: %w", workingEnvironmentID, errNotFound)
+	}
+	return result, nil
+}
+
+// updateBackupActivation changes the default policy of the working environment, and whether new volumes are
+// backed up with it
+func (c *Client) updateBackupActivation(ctx context.Context, accountID string, workingEnvironmentID string, request backupActivationUpdateRequest, clientID string) error {
+	baseURL, err := c.backupURL(ctx, accountID, workingEnvironmentID)
+	if err != nil {
+		return err
+	}
+	return c.callBackupAPI(ctx, "PUT", baseURL, request, "updateBackupActivation", "backup", "update", clientID)
+}
+
+// deactivateBackup stops backing up the volumes of the working environment. The existing backups are kept.
+func (c *Client) deactivateBackup(ctx context.Context, accountID string, workingEnvironmentID string, clientID string) error {
+	baseURL, err := c.backupURL(ctx, accountID, workingEnvironmentID)
+	if err != nil {
+		return err
+	}
+	return c.callBackupAPI(ctx, "DELETE", baseURL, nil, "deactivateBackup", "backup", "deactivate", clientID)
+}
+
+func (c *Client) createBackupPolicy(ctx context.Context, accountID string, workingEnvironmentID string, policy backupPolicyRequest, clientID string) error {
+	baseURL, err := c.backupURL(ctx, accountID, workingEnvironmentID)
+	if err != nil {
+		return err
+	}
+	return c.callBackupAPI(ctx, "POST", baseURL+"/policy", policy, "createBackupPolicy", "backup policy", "create", clientID)
+}
+
+// getBackupPolicy returns the backup policy of the working environment, or errNotFound
+func (c *Client) getBackupPolicy(ctx context.Context, accountID string, workingEnvironmentID string, name string, clientID string) (backupPolicyResponse, error) {
+	baseURL, err := c.backupURL(ctx, accountID, workingEnvironmentID)
+	if err != nil {
+		return backupPolicyResponse{}, err
+	}
+	hostType := "CloudManagerHost"
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL+"/policy", nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("getBackupPolicy request failed ", statusCode)
+		return backupPolicyResponse{}, err
+	}
+	var result []backupPolicyResponse
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getBackupPolicy ", err)
+		return backupPolicyResponse{}, err
+	}
+	for _, policy := range result {
+		if policy.Name == name {
+			return policy, nil
+		}
+	}
+	return backupPolicyResponse{}, fmt.Errorf("backup policy %s: %w", name, errNotFound)
+}
+
+func (c *Client) updateBackupPolicy(ctx context.Context, accountID string, workingEnvironmentID string, policy backupPolicyRequest, clientID string) error {
+	baseURL, err := c.backupURL(ctx, accountID, workingEnvironmentID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/policy/%s", baseURL, policy.Name)
+	policy.Name = ""
+	return c.callBackupAPI(ctx, "PUT", baseURL, policy, "updateBackupPolicy", "backup policy", "update", clientID)
+}
+
+// deleteBackupPolicy deletes the backup policy, which cannot be used by a volume
+func (c *Client) deleteBackupPolicy(ctx context.Context, accountID string, workingEnvironmentID string, name string, clientID string) error {
+	baseURL, err := c.backupURL(ctx, accountID, workingEnvironmentID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/policy/%s", baseURL, name)
+	return c.callBackupAPI(ctx, "DELETE", baseURL, nil, "deleteBackupPolicy", "backup policy", "delete", clientID)
+}
+
+func (c *Client) enableVolumeBackup(ctx context.Context, accountID string, workingEnvironmentID string, request volumeBackupRequest, clientID string) error {
+	baseURL, err := c.backupURL(ctx, accountID, workingEnvironmentID)
+	if err != nil {
+		return err
+	}
+	return c.callBackupAPI(ctx, "POST", baseURL+"/volume", request, "enableVolumeBackup", "volume backup", "enable", clientID)
+}
+
+// getVolumeBackup returns the backup of the volume, or errNotFound when the volume is not backed up
+func (c *Client) getVolumeBackup(ctx context.Context, accountID string, workingEnvironmentID string, svmName string, volumeName string, clientID string) (volumeBackupResponse, error) {
+	baseURL, err := c.backupURL(ctx, accountID, workingEnvironmentID)
+	if err != nil {
+		return volumeBackupResponse{}, err
+	}
+	hostType := "CloudManagerHost"
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL+"/volume", nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("getVolumeBackup request failed ", statusCode)
+		return volumeBackupResponse{}, err
+	}
+	var result []volumeBackupResponse
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getVolumeBackup ", err)
+		return volumeBackupResponse{}, err
+	}
+	for _, backup := range result {
+		if backup.SvmName == svmName && backup.VolumeName == volumeName {
+			return backup, nil
+		}
+	}
+	return volumeBackupResponse{}, fmt.Errorf("backup of volume %s: %w", volumeName, errNotFound)
+}
+
+// updateVolumeBackup changes the backup policy of the volume
+func (c *Client) updateVolumeBackup(ctx context.Context, accountID string, workingEnvironmentID string, request volumeBackupRequest, clientID string) error {
+	baseURL, err := c.backupURL(ctx, accountID, workingEnvironmentID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/volume/%s/%s", baseURL, request.SvmName, request.VolumeName)
+	request.SvmName = ""
+	request.VolumeName = ""
+	return c.callBackupAPI(ctx, "PUT", baseURL, request, "updateVolumeBackup", "volume backup", "update", clientID)
+}
+
+// disableVolumeBackup stops backing up the volume. The existing backups are kept.
+func (c *Client) disableVolumeBackup(ctx context.Context, accountID string, workingEnvironmentID string, svmName string, volumeName string, clientID string) error {
+	baseURL, err := c.backupURL(ctx, accountID, workingEnvironmentID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/volume/%s/%s", baseURL, svmName, volumeName)
+	return c.callBackupAPI(ctx, "DELETE", baseURL, nil, "disableVolumeBackup", "volume backup", "disable", clientID)
+}
@@ -1,6 +1,7 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -36,24 +37,20 @@ type cifsDeleteRequest struct {
 	SvmName  string `structs:"svmName,omitempty"`
 }
 
-func (c *Client) createCIFS(cifs cifsRequest, clientID string) error {
-	baseURL, _, err := c.getAPIRoot(cifs.WorkingEnvironmentID, clientID)
+func (c *Client) createCIFS(ctx context.Context, cifs cifsRequest, clientID string) error {
+	baseURL, _, err := c.getAPIRoot(ctx, cifs.WorkingEnvironmentID, clientID)
 	hostType := "CloudManagerHost"
 	if err != nil {
 		return err
 	}
 	baseURL = fmt.Sprintf("%s/working-environments/%s/cifs", baseURL, cifs.WorkingEnvironmentID)
 	param := structs.Map(cifs)
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, param, c.Token, hostType, clientID)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, param, true, hostType, clientID)
 	if err != nil {
 		log.Print("createCIFS request failed ", statusCode)
 		return err
 	}
-	responseError := apiResponseChecker(statusCode, response, "createCifs")
-	if responseError != nil {
-		return responseError
-	}
-	err = c.waitOnCompletion(onCloudRequestID, "cifs", "create", 10, 10, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "cifs", "create", 10, clientID)
 	if err != nil {
 		return err
 	}
@@ -61,9 +58,9 @@ func (c *Client) createCIFS(cifs cifsRequest, clientID string) error {
 	return nil
 }
 
-func (c *Client) getCIFS(cifs cifsRequest, clientID string) ([]cifsResponse, error) {
+func (c *Client) getCIFS(ctx context.Context, cifs cifsRequest, clientID string) ([]cifsResponse, error) {
 	var result []cifsResponse
-	baseURL, _, err := c.getAPIRoot(cifs.WorkingEnvironmentID, clientID)
+	baseURL, _, err := c.getAPIRoot(ctx, cifs.WorkingEnvironmentID, clientID)
 	if err != nil {
 		return result, err
 	}
@@ -72,15 +69,11 @@ func (c *Client) getCIFS(cifs cifsRequest, clientID string) ([]cifsResponse, err
 		baseURL += "?svm=" + cifs.SvmName
 	}
 	hostType := "CloudManagerHost"
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("createCIFS request failed ", statusCode)
 		return result, err
 	}
-	responseError := apiResponseChecker(statusCode, response, "createCifs")
-	if responseError != nil {
-		return result, responseError
-	}
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getCIFS ", err)
 		return result, err
@@ -88,23 +81,18 @@ func (c *Client) getCIFS(cifs cifsRequest, clientID string) ([]cifsResponse, err
 	return result, nil
 }
 
-func (c *Client) deleteCIFS(cifs cifsDeleteRequest, workingEnvironmentID, clientID string) error {
-	baseURL, _, err := c.getAPIRoot(workingEnvironmentID, clientID)
+func (c *Client) deleteCIFS(ctx context.Context, cifs cifsDeleteRequest, workingEnvironmentID, clientID string) error {
+	baseURL, _, err := c.getAPIRoot(ctx, workingEnvironmentID, clientID)
 	hostType := "CloudManagerHost"
 	if err != nil {
 		return err
 	}
 	baseURL = fmt.Sprintf("%s/working-environments/%s/delete-cifs", baseURL, workingEnvironmentID)
 	param := structs.Map(cifs)
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, param, c.Token, hostType, clientID)
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "POST", baseURL, param, true, hostType, clientID)
 	if err != nil {
 		log.Print("deleteCIFS request failed ", statusCode)
 		return err
 	}
-	responseError := apiResponseChecker(statusCode, response, "deleteCIFS")
-	if responseError != nil {
-		return responseError
-	}
-
 	return nil
 }
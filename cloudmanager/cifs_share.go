@@ -0,0 +1,159 @@
+package cloudmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+type cifsShareRequest struct {
+	ShareName              string              `structs:"shareName,omitempty"`
+	SvmName                string              `structs:"svmName,omitempty"`
+	VolumeName             string              `structs:"volumeName,omitempty"`
+	Path                   string              `structs:"path"`
+	Comment                string              `structs:"comment"`
+	AccessControlList      []accessControlList `structs:"accessControlList"`
+	Oplocks                bool                `structs:"oplocks"`
+	ContinuouslyAvailable  bool                `structs:"continuouslyAvailable"`
+	AccessBasedEnumeration bool                `structs:"accessBasedEnumeration"`
+}
+
+type cifsShareResponse struct {
+	ShareName              string                      `json:"shareName"`
+	SvmName                string                      `json:"svmName"`
+	VolumeName             string                      `json:"volumeName"`
+	Path                   string                      `json:"path"`
+	Comment                string                      `json:"comment"`
+	AccessControlList      []accessControlListResponse `json:"accessControlList"`
+	Oplocks                bool                        `json:"oplocks"`
+	ContinuouslyAvailable  bool                        `json:"continuouslyAvailable"`
+	AccessBasedEnumeration bool                        `json:"accessBasedEnumeration"`
+}
+
+func expandCifsShareAccessControl(set *schema.Set) []accessControlList {
+	acls := make([]accessControlList, 0, set.Len())
+	for _, v := range set.List() {
+		acl := v.(map[string]interface{})
+		users := make([]string, 0)
+		for _, user := range acl["users"].(*schema.Set).List() {
+			users = append(users, user.(string))
+		}
+		acls = append(acls, accessControlList{Permission: acl["permission"].(string), Users: users})
+	}
+	return acls
+}
+
+func flattenCifsShareAccessControl(acls []accessControlListResponse) []interface{} {
+	result := make([]interface{}, 0, len(acls))
+	for _, acl := range acls {
+		result = append(result, map[string]interface{}{
+			"permission": acl.Permission,
+			"users":      acl.Users,
+		})
+	}
+	return result
+}
+
+// cifsSharesURL returns the URL of the CIFS shares of the working environment
+func (c *Client) cifsSharesURL(ctx context.Context, workingEnvironmentID string, clientID string) (string, error) {
+	baseURL, _, err := c.getAPIRoot(ctx, workingEnvironmentID, clientID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/working-environments/%s/cifs-shares", baseURL, workingEnvironmentID), nil
+}
+
+func (c *Client) createCifsShare(ctx context.Context, workingEnvironmentID string, share cifsShareRequest, clientID string) error {
+	baseURL, err := c.cifsSharesURL(ctx, workingEnvironmentID, clientID)
+	if err != nil {
+		return err
+	}
+	hostType := "CloudManagerHost"
+	params := structs.Map(share)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("createCifsShare request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "cifs share", "create", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getCifsShare returns the share of the SVM, or errNotFound
+func (c *Client) getCifsShare(ctx context.Context, workingEnvironmentID string, svmName string, name string, clientID string) (cifsShareResponse, error) {
+	baseURL, err := c.cifsSharesURL(ctx, workingEnvironmentID, clientID)
+	if err != nil {
+		return cifsShareResponse{}, err
+	}
+	baseURL = fmt.Sprintf("%s?svm=%s", baseURL, svmName)
+	hostType := "CloudManagerHost"
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("getCifsShare request failed ", statusCode)
+		return cifsShareResponse{}, err
+	}
+	var result []cifsShareResponse
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getCifsShare ", err)
+		return cifsShareResponse{}, err
+	}
+	for _, share := range result {
+		if share.ShareName == name {
+			return share, nil
+		}
+	}
+	return cifsShareResponse{}, fmt.Errorf("cifs share %s: %w", name, errNotFound)
+}
+
+// updateCifsShare replaces the path, comment, ACL and properties of the share
+func (c *Client) updateCifsShare(ctx context.Context, workingEnvironmentID string, share cifsShareRequest, clientID string) error {
+	baseURL, err := c.cifsSharesURL(ctx, workingEnvironmentID, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/%s/%s", baseURL, share.SvmName, share.ShareName)
+	share.ShareName = ""
+	share.SvmName = ""
+	share.VolumeName = ""
+	hostType := "CloudManagerHost"
+	params := structs.Map(share)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "PUT", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("updateCifsShare request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "cifs share", "update", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) deleteCifsShare(ctx context.Context, workingEnvironmentID string, svmName string, name string, clientID string) error {
+	baseURL, err := c.cifsSharesURL(ctx, workingEnvironmentID, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/%s/%s", baseURL, svmName, name)
+	hostType := "CloudManagerHost"
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("deleteCifsShare request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "cifs share", "delete", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -6,8 +6,10 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/compute/mgmt/compute"
 	"github.com/Azure/azure-sdk-for-go/profiles/latest/resources/mgmt/resources"
@@ -24,6 +26,9 @@ import (
 	"github.com/sirupsen/logrus"
 )
 
+// tokenRefreshMargin is how long before its expiry a cached access token is renewed
+const tokenRefreshMargin = 5 * time.Minute
+
 var ourlog = logrus.WithFields(logrus.Fields{
 	"prefix": "main",
 })
@@ -58,7 +63,6 @@ type Client struct {
 	GCPDeploymentTemplate   string
 	GCPServiceAccountKey    string
 	CVSHostName             string
-	Retries                 int
 
 	initOnce           sync.Once
 	instanceInput      *restapi.Client
@@ -68,10 +72,14 @@ type Client struct {
 	AWSProfile         string
 	AWSProfileFilePath string
 	AzureAuthMethods   []string
+	RetryPolicy        *restapi.RetryPolicy
+	Transport          http.RoundTripper
+	tokenMu            sync.Mutex
+	tokenExpiry        time.Time
 }
 
 // CallAWSInstanceCreate can be used to make a request to create AWS Instance
-func (c *Client) CallAWSInstanceCreate(occmDetails createOCCMDetails) (string, error) {
+func (c *Client) CallAWSInstanceCreate(ctx context.Context, occmDetails createOCCMDetails) (string, error) {
 
 	var sess *session.Session
 	if c.AWSProfile != "" {
@@ -161,7 +169,7 @@ func (c *Client) CallAWSInstanceCreate(occmDetails createOCCMDetails) (string, e
 		runInstancesInput.SecurityGroupIds = securityGroupIds
 	}
 	log.Print("CallAWSInstanceCreate occmDetails name:", occmDetails.Name)
-	runResult, err := svc.RunInstances(runInstancesInput)
+	runResult, err := svc.RunInstancesWithContext(ctx, runInstancesInput)
 
 	if err != nil {
 		log.Print("Could not create instance ", err)
@@ -174,7 +182,7 @@ func (c *Client) CallAWSInstanceCreate(occmDetails createOCCMDetails) (string, e
 }
 
 // CallAWSInstanceTerminate can be used to make a request to terminate AWS Instance
-func (c *Client) CallAWSInstanceTerminate(occmDetails deleteOCCMDetails) error {
+func (c *Client) CallAWSInstanceTerminate(ctx context.Context, occmDetails deleteOCCMDetails) error {
 
 	var sess *session.Session
 	if c.AWSProfile != "" {
@@ -197,7 +205,7 @@ func (c *Client) CallAWSInstanceTerminate(occmDetails deleteOCCMDetails) error {
 	}
 
 	// Specify the details of the instance that you want to terminate.
-	runResult, err := svc.TerminateInstances(input)
+	runResult, err := svc.TerminateInstancesWithContext(ctx, input)
 	if err != nil {
 		log.Print("Could not terminate instance ", err)
 		return err
@@ -236,7 +244,7 @@ func (c *Client) AzureAuthorize() (autorest.Authorizer, error) {
 }
 
 // CallDeployAzureVM can be used to make a request to deploy Azure VM
-func (c *Client) CallDeployAzureVM(occmDetails createOCCMDetails) (string, error) {
+func (c *Client) CallDeployAzureVM(ctx context.Context, occmDetails createOCCMDetails) (string, error) {
 
 	var template *map[string]interface{}
 	var params *map[string]interface{}
@@ -333,7 +341,7 @@ func (c *Client) CallDeployAzureVM(occmDetails createOCCMDetails) (string, error
 	deploymentsClient.Authorizer = authorizer
 	log.Printf("storageAccount %s virtualMachinesName %s", (*params)["storageAccount"], (*params)["virtualMachineName"])
 	deploymentFuture, err := deploymentsClient.CreateOrUpdate(
-		context.Background(),
+		ctx,
 		occmDetails.ResourceGroup,
 		occmDetails.Name,
 		resources.Deployment{
@@ -349,7 +357,7 @@ func (c *Client) CallDeployAzureVM(occmDetails createOCCMDetails) (string, error
 	}
 
 	log.Print("Wait for completion...")
-	err = deploymentFuture.Future.WaitForCompletionRef(context.Background(), deploymentsClient.BaseClient.Client)
+	err = deploymentFuture.Future.WaitForCompletionRef(ctx, deploymentsClient.BaseClient.Client)
 	if err != nil {
 		return "", err
 	}
@@ -359,7 +367,7 @@ func (c *Client) CallDeployAzureVM(occmDetails createOCCMDetails) (string, error
 	vmClient := compute.NewVirtualMachinesClient(occmDetails.SubscriptionID)
 	vmClient.Authorizer = authorizer
 	vmFuture, err := vmClient.Get(
-		context.Background(),
+		ctx,
 		occmDetails.ResourceGroup,
 		occmDetails.Name,
 		"",
@@ -374,7 +382,7 @@ func (c *Client) CallDeployAzureVM(occmDetails createOCCMDetails) (string, error
 }
 
 // CallGetAzureVM can be used to make a request to get Azure VM
-func (c *Client) CallGetAzureVM(occmDetails createOCCMDetails) (string, error) {
+func (c *Client) CallGetAzureVM(ctx context.Context, occmDetails createOCCMDetails) (string, error) {
 
 	deploymentsClient := resources.NewDeploymentsClient(occmDetails.SubscriptionID)
 	authorizer, err := c.AzureAuthorize()
@@ -384,7 +392,7 @@ func (c *Client) CallGetAzureVM(occmDetails createOCCMDetails) (string, error) {
 	deploymentsClient.Authorizer = authorizer
 
 	deploymentFuture, err := deploymentsClient.Get(
-		context.Background(),
+		ctx,
 		occmDetails.ResourceGroup,
 		occmDetails.Name,
 	)
@@ -401,7 +409,7 @@ func (c *Client) CallGetAzureVM(occmDetails createOCCMDetails) (string, error) {
 }
 
 // CallDeleteAzureVM can be used to make a request to delete Azure VM
-func (c *Client) CallDeleteAzureVM(occmDetails deleteOCCMDetails) error {
+func (c *Client) CallDeleteAzureVM(ctx context.Context, occmDetails deleteOCCMDetails) error {
 
 	authorizer, err := c.AzureAuthorize()
 	if err != nil {
@@ -413,7 +421,7 @@ func (c *Client) CallDeleteAzureVM(occmDetails deleteOCCMDetails) error {
 	vmClient := compute.NewVirtualMachinesClient(occmDetails.SubscriptionID)
 	vmClient.Authorizer = authorizer
 	vmFuture, err := vmClient.Delete(
-		context.Background(),
+		ctx,
 		occmDetails.ResourceGroup,
 		occmDetails.Name,
 	)
@@ -421,7 +429,7 @@ func (c *Client) CallDeleteAzureVM(occmDetails deleteOCCMDetails) error {
 		return fmt.Errorf("cannot delete vm: %v", err)
 	}
 
-	err = vmFuture.WaitForCompletionRef(context.Background(), vmClient.Client)
+	err = vmFuture.WaitForCompletionRef(ctx, vmClient.Client)
 	if err != nil {
 		return fmt.Errorf("cannot get the vm delete future response: %v", err)
 	}
@@ -431,7 +439,7 @@ func (c *Client) CallDeleteAzureVM(occmDetails deleteOCCMDetails) error {
 	nicClient := network.NewInterfacesClient(occmDetails.SubscriptionID)
 	nicClient.Authorizer = authorizer
 	nicFuture, err := nicClient.Delete(
-		context.Background(),
+		ctx,
 		occmDetails.ResourceGroup,
 		occmDetails.Name+"-nic",
 	)
@@ -439,7 +447,7 @@ func (c *Client) CallDeleteAzureVM(occmDetails deleteOCCMDetails) error {
 		return fmt.Errorf("cannot delete nic: %v", err)
 	}
 
-	err = nicFuture.WaitForCompletionRef(context.Background(), nicClient.Client)
+	err = nicFuture.WaitForCompletionRef(ctx, nicClient.Client)
 	if err != nil {
 		return fmt.Errorf("cannot get the nic delete future response: %v", err)
 	}
@@ -449,14 +457,14 @@ func (c *Client) CallDeleteAzureVM(occmDetails deleteOCCMDetails) error {
 	nsgClient := network.NewSecurityGroupsClient(occmDetails.SubscriptionID)
 	nsgClient.Authorizer = authorizer
 	nsgFuture, err := nsgClient.Delete(
-		context.Background(),
+		ctx,
 		occmDetails.ResourceGroup,
 		occmDetails.Name+"-nsg",
 	)
 	if err != nil {
 		return fmt.Errorf("cannot delete nsg: %v", err)
 	}
-	err = nsgFuture.WaitForCompletionRef(context.Background(), nsgClient.Client)
+	err = nsgFuture.WaitForCompletionRef(ctx, nsgClient.Client)
 	if err != nil {
 		return fmt.Errorf("cannot get the nsg delete future response: %v", err)
 	}
@@ -470,7 +478,7 @@ func (c *Client) CallDeleteAzureVM(occmDetails deleteOCCMDetails) error {
 	storageAccountsClient := storage.NewAccountsClient(occmDetails.SubscriptionID)
 	storageAccountsClient.Authorizer = authorizer
 	_, err = storageAccountsClient.Delete(
-		context.Background(),
+		ctx,
 		occmDetails.ResourceGroup,
 		occmDetails.StorageAccount,
 	)
@@ -483,7 +491,7 @@ func (c *Client) CallDeleteAzureVM(occmDetails deleteOCCMDetails) error {
 	ipClient := network.NewPublicIPAddressesClient(occmDetails.SubscriptionID)
 	ipClient.Authorizer = authorizer
 	ipFuture, err := ipClient.Delete(
-		context.Background(),
+		ctx,
 		occmDetails.ResourceGroup,
 		occmDetails.Name+"-ip",
 	)
@@ -491,7 +499,7 @@ func (c *Client) CallDeleteAzureVM(occmDetails deleteOCCMDetails) error {
 		return fmt.Errorf("cannot delete ipaddress: %v", err)
 	}
 
-	err = ipFuture.WaitForCompletionRef(context.Background(), ipClient.Client)
+	err = ipFuture.WaitForCompletionRef(ctx, ipClient.Client)
 	if err != nil {
 		return fmt.Errorf("cannot get the ipaddress delete future response: %v", err)
 	}
@@ -507,14 +515,14 @@ func (c *Client) CallDeleteAzureVM(occmDetails deleteOCCMDetails) error {
 	deploymentsClient.Authorizer = authorizer
 
 	deploymentFuture, err := deploymentsClient.Delete(
-		context.Background(),
+		ctx,
 		occmDetails.ResourceGroup,
 		occmDetails.Name,
 	)
 	if err != nil {
 		return err
 	}
-	err = deploymentFuture.Future.WaitForCompletionRef(context.Background(), deploymentsClient.BaseClient.Client)
+	err = deploymentFuture.Future.WaitForCompletionRef(ctx, deploymentsClient.BaseClient.Client)
 	if err != nil {
 		return err
 	}
@@ -524,7 +532,7 @@ func (c *Client) CallDeleteAzureVM(occmDetails deleteOCCMDetails) error {
 }
 
 // CallAMIGet can be used to make a request to get AWS AMI
-func (c *Client) CallAMIGet(occmDetails createOCCMDetails) (string, error) {
+func (c *Client) CallAMIGet(ctx context.Context, occmDetails createOCCMDetails) (string, error) {
 
 	var sess *session.Session
 	if c.AWSProfile != "" {
@@ -552,7 +560,7 @@ func (c *Client) CallAMIGet(occmDetails createOCCMDetails) (string, error) {
 		},
 	}
 
-	result, err := svc.DescribeImages(input)
+	result, err := svc.DescribeImagesWithContext(ctx, input)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			return "", aerr
@@ -573,7 +581,7 @@ func (c *Client) CallAMIGet(occmDetails createOCCMDetails) (string, error) {
 }
 
 // CallVPCGet can be used to make a request to get AWS AMI
-func (c *Client) CallVPCGet(subnet string, region string) (string, error) {
+func (c *Client) CallVPCGet(ctx context.Context, subnet string, region string) (string, error) {
 	var sess *session.Session
 	if c.AWSProfile != "" {
 		sess = session.Must(session.NewSession(
@@ -592,7 +600,7 @@ func (c *Client) CallVPCGet(subnet string, region string) (string, error) {
 		},
 	}
 
-	result, err := svc.DescribeSubnets(input)
+	result, err := svc.DescribeSubnetsWithContext(ctx, input)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			return "", aerr
@@ -606,7 +614,7 @@ func (c *Client) CallVPCGet(subnet string, region string) (string, error) {
 }
 
 // CallVNetGet can be used to make a request to get Azure virtual network
-func (c *Client) CallVNetGet(subscriptionID string, resourceGroup string) (string, string, error) {
+func (c *Client) CallVNetGet(ctx context.Context, subscriptionID string, resourceGroup string) (string, string, error) {
 
 	vNet := network.NewVirtualNetworksClient(subscriptionID)
 	authorizer, err := c.AzureAuthorize()
@@ -615,7 +623,7 @@ func (c *Client) CallVNetGet(subscriptionID string, resourceGroup string) (strin
 	}
 	vNet.Authorizer = authorizer
 
-	for resList, err := vNet.ListComplete(context.Background(), resourceGroup); resList.NotDone(); err = resList.Next() {
+	for resList, err := vNet.ListComplete(ctx, resourceGroup); resList.NotDone(); err = resList.Next() {
 		if err != nil {
 			log.Print("Could not get vNet ", err)
 			return "", "", err
@@ -631,7 +639,7 @@ func (c *Client) CallVNetGet(subscriptionID string, resourceGroup string) (strin
 }
 
 // CallVNetGetCidr can be used to make a request to get Azure virtual network
-func (c *Client) CallVNetGetCidr(subscriptionID string, resourceGroup string, vnet string) (string, error) {
+func (c *Client) CallVNetGetCidr(ctx context.Context, subscriptionID string, resourceGroup string, vnet string) (string, error) {
 
 	vNet := network.NewVirtualNetworksClient(subscriptionID)
 	authorizer, err := c.AzureAuthorize()
@@ -640,7 +648,7 @@ func (c *Client) CallVNetGetCidr(subscriptionID string, resourceGroup string, vn
 	}
 	vNet.Authorizer = authorizer
 
-	resList, err := vNet.Get(context.Background(), resourceGroup, vnet, "")
+	resList, err := vNet.Get(ctx, resourceGroup, vnet, "")
 	if err != nil {
 		log.Print("Could not get cidr ", err)
 		return "", err
@@ -653,15 +661,15 @@ func (c *Client) CallVNetGetCidr(subscriptionID string, resourceGroup string, vn
 }
 
 // CallAWSInstanceGet can be used to make a request to get AWS Instance
-func (c *Client) CallAWSInstanceGet(occmDetails createOCCMDetails) ([]ec2.Instance, error) {
+func (c *Client) CallAWSInstanceGet(ctx context.Context, occmDetails createOCCMDetails) ([]ec2.Instance, error) {
 	if occmDetails.Region == "" {
-		regions, err := c.CallAWSRegionGet(occmDetails)
+		regions, err := c.CallAWSRegionGet(ctx, occmDetails)
 		if err != nil {
 			return nil, err
 		}
 		var res []ec2.Instance
 		for _, region := range regions {
-			regionReservation, err := c.CallAWSGetReservationsForRegion(region)
+			regionReservation, err := c.CallAWSGetReservationsForRegion(ctx, region)
 			if err != nil {
 				return nil, err
 			}
@@ -711,7 +719,7 @@ func (c *Client) CallAWSInstanceGet(occmDetails createOCCMDetails) ([]ec2.Instan
 		},
 	}
 
-	result, err := svc.DescribeInstances(input)
+	result, err := svc.DescribeInstancesWithContext(ctx, input)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			switch aerr.Code() {
@@ -733,7 +741,7 @@ func (c *Client) CallAWSInstanceGet(occmDetails createOCCMDetails) ([]ec2.Instan
 }
 
 // CallAWSRegionGet describe all regions.
-func (c *Client) CallAWSRegionGet(occmDetails createOCCMDetails) ([]string, error) {
+func (c *Client) CallAWSRegionGet(ctx context.Context, occmDetails createOCCMDetails) ([]string, error) {
 	var sess *session.Session
 	if c.AWSProfile != "" {
 		sess = session.Must(session.NewSession(
@@ -745,7 +753,7 @@ func (c *Client) CallAWSRegionGet(occmDetails createOCCMDetails) ([]string, erro
 	}
 	svc := ec2.New(sess)
 
-	result, err := svc.DescribeRegions(nil)
+	result, err := svc.DescribeRegionsWithContext(ctx, nil)
 	if err != nil {
 		log.Printf("CallAWSRegionGet error: %#v", err)
 		if aerr, ok := err.(awserr.Error); ok {
@@ -764,10 +772,37 @@ func (c *Client) CallAWSRegionGet(occmDetails createOCCMDetails) ([]string, erro
 	return res, nil
 }
 
-// CallAPIMethod can be used to make a request to any CVO/OCCM API method, receiving results as byte
-func (c *Client) CallAPIMethod(method string, baseURL string, params map[string]interface{}, token string, hostType string, clientID string) (int, []byte, string, error) {
+// CallAPIMethod can be used to make a request to any CVO/OCCM API method, receiving results as byte.
+// authenticate sends the cached access token, which is renewed before it expires; requests that are
+// unauthenticated or authenticate on their own (GCP) pass false.
+func (c *Client) CallAPIMethod(ctx context.Context, method string, baseURL string, params map[string]interface{}, authenticate bool, hostType string, clientID string) (int, []byte, string, error) {
 	c.initOnce.Do(c.init)
-	c.waitForAvailableSlot()
+
+	var token string
+	if authenticate {
+		accessToken, err := c.getAccessToken(ctx)
+		if err != nil {
+			return 0, nil, "", err
+		}
+		token = accessToken.Token
+	}
+
+	statusCode, result, onCloudRequestID, err := c.doAPIRequest(ctx, method, baseURL, params, token, hostType, clientID)
+	if token != "" && restapi.IsStatus(err, http.StatusUnauthorized) {
+		log.Print("access token rejected, requesting a new one")
+		token, err = c.renewAccessToken(ctx, token)
+		if err != nil {
+			return statusCode, nil, "", err
+		}
+		statusCode, result, onCloudRequestID, err = c.doAPIRequest(ctx, method, baseURL, params, token, hostType, clientID)
+	}
+	return statusCode, result, onCloudRequestID, err
+}
+
+func (c *Client) doAPIRequest(ctx context.Context, method string, baseURL string, params map[string]interface{}, token string, hostType string, clientID string) (int, []byte, string, error) {
+	if err := c.waitForAvailableSlot(ctx); err != nil {
+		return 0, nil, "", err
+	}
 	defer c.releaseSlot()
 
 	ourlog.WithFields(logrus.Fields{
@@ -779,14 +814,14 @@ func (c *Client) CallAPIMethod(method string, baseURL string, params map[string]
 	if params == nil {
 		paramsNil = true
 	}
-	statusCode, result, onCloudRequestID, err := c.restapiClient.Do(baseURL, hostType, token, paramsNil, c.AccountID, clientID, &restapi.Request{
+	statusCode, result, onCloudRequestID, err := c.restapiClient.Do(ctx, baseURL, hostType, token, paramsNil, c.AccountID, clientID, &restapi.Request{
 		Method:                method,
 		Params:                params,
 		GCPDeploymentTemplate: c.GCPDeploymentTemplate,
 		GCPServiceAccountKey:  c.GCPServiceAccountKey,
 	}, c.Simulator)
 	if err != nil {
-		return statusCode, nil, "", err
+		return statusCode, result, onCloudRequestID, err
 	}
 	ourlog.WithFields(logrus.Fields{
 		"method": method,
@@ -811,6 +846,8 @@ func (c *Client) init() {
 		GCPDeploymentManager: c.GCPDeploymentManager,
 		CVSHostName:          c.CVSHostName,
 		GCPCompute:           c.GCPCompute,
+		RetryPolicy:          c.RetryPolicy,
+		Transport:            c.Transport,
 	}
 }
 
@@ -824,6 +861,56 @@ func (c *Client) GetRefreshToken() string {
 	return c.RefreshToken
 }
 
+// isAuthenticated reports whether an access token is cached
+func (c *Client) isAuthenticated() bool {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	return c.Token != ""
+}
+
+// getAccessToken returns the cached access token, requesting a new one when none is cached
+// or the cached one expires within tokenRefreshMargin
+func (c *Client) getAccessToken(ctx context.Context) (accesTokenResult, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.Token != "" && (c.tokenExpiry.IsZero() || time.Now().Add(tokenRefreshMargin).Before(c.tokenExpiry)) {
+		return accesTokenResult{Token: c.Token, ExpiresIn: int(time.Until(c.tokenExpiry).Seconds())}, nil
+	}
+	return c.refreshAccessTokenLocked(ctx)
+}
+
+// renewAccessToken replaces an access token rejected by the API. If another request already
+// renewed it, the newer token is returned without asking the auth host again.
+func (c *Client) renewAccessToken(ctx context.Context, rejected string) (string, error) {
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
+
+	if c.Token != "" && c.Token != rejected {
+		return c.Token, nil
+	}
+	result, err := c.refreshAccessTokenLocked(ctx)
+	if err != nil {
+		return "", err
+	}
+	return result.Token, nil
+}
+
+// refreshAccessTokenLocked requests a new access token and caches it with its expiry, tokenMu must be held
+func (c *Client) refreshAccessTokenLocked(ctx context.Context) (accesTokenResult, error) {
+	result, err := c.requestAccessToken(ctx)
+	if err != nil {
+		return accesTokenResult{}, err
+	}
+	c.Token = result.Token
+	c.tokenExpiry = time.Time{}
+	if result.ExpiresIn > 0 {
+		c.tokenExpiry = time.Now().Add(time.Duration(result.ExpiresIn) * time.Second)
+	}
+	return result, nil
+}
+
 // SetServiceCredential for the client to use for requests to the CVO/OCCM API
 func (c *Client) SetServiceCredential(SaSecretKey string, SaClientID string) {
 	c.SaSecretKey = SaSecretKey
@@ -835,8 +922,13 @@ func (c *Client) GetServiceCredential() (string, string) {
 	return c.SaSecretKey, c.SaClientID
 }
 
-func (c *Client) waitForAvailableSlot() {
-	c.requestSlots <- 1
+func (c *Client) waitForAvailableSlot(ctx context.Context) error {
+	select {
+	case c.requestSlots <- 1:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
 func (c *Client) releaseSlot() {
@@ -854,7 +946,7 @@ func (c *Client) GetSimulator() bool {
 }
 
 // CallAWSTagCreate creates tag
-func (c *Client) CallAWSTagCreate(occmDetails createOCCMDetails) error {
+func (c *Client) CallAWSTagCreate(ctx context.Context, occmDetails createOCCMDetails) error {
 
 	var sess *session.Session
 	if c.AWSProfile != "" {
@@ -887,7 +979,7 @@ func (c *Client) CallAWSTagCreate(occmDetails createOCCMDetails) error {
 		Tags: tags,
 	}
 
-	result, err := svc.CreateTags(input)
+	result, err := svc.CreateTagsWithContext(ctx, input)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			return aerr
@@ -900,7 +992,7 @@ func (c *Client) CallAWSTagCreate(occmDetails createOCCMDetails) error {
 }
 
 // CallAWSTagDelete deletes tag
-func (c *Client) CallAWSTagDelete(occmDetails createOCCMDetails) error {
+func (c *Client) CallAWSTagDelete(ctx context.Context, occmDetails createOCCMDetails) error {
 
 	var sess *session.Session
 	if c.AWSProfile != "" {
@@ -933,7 +1025,7 @@ func (c *Client) CallAWSTagDelete(occmDetails createOCCMDetails) error {
 		Tags: tags,
 	}
 
-	result, err := svc.DeleteTags(input)
+	result, err := svc.DeleteTagsWithContext(ctx, input)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			return aerr
@@ -946,7 +1038,7 @@ func (c *Client) CallAWSTagDelete(occmDetails createOCCMDetails) error {
 }
 
 // CallAWSDescribeInstanceAttribute returns disableAPITermination.
-func (c *Client) CallAWSDescribeInstanceAttribute(occmDetails createOCCMDetails) (bool, error) {
+func (c *Client) CallAWSDescribeInstanceAttribute(ctx context.Context, occmDetails createOCCMDetails) (bool, error) {
 
 	var sess *session.Session
 	if c.AWSProfile != "" {
@@ -965,7 +1057,7 @@ func (c *Client) CallAWSDescribeInstanceAttribute(occmDetails createOCCMDetails)
 		InstanceId: aws.String(occmDetails.InstanceID),
 	}
 
-	result, err := svc.DescribeInstanceAttribute(input)
+	result, err := svc.DescribeInstanceAttributeWithContext(ctx, input)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			switch aerr.Code() {
@@ -984,7 +1076,7 @@ func (c *Client) CallAWSDescribeInstanceAttribute(occmDetails createOCCMDetails)
 }
 
 // CallAWSGetReservationsForRegion gets reservations for a region.
-func (c *Client) CallAWSGetReservationsForRegion(region string) ([]ec2.Instance, error) {
+func (c *Client) CallAWSGetReservationsForRegion(ctx context.Context, region string) ([]ec2.Instance, error) {
 
 	var res []ec2.Instance
 
@@ -996,7 +1088,7 @@ func (c *Client) CallAWSGetReservationsForRegion(region string) ([]ec2.Instance,
 	svc := ec2.New(sess)
 	input := &ec2.DescribeInstancesInput{}
 
-	result, err := svc.DescribeInstances(input)
+	result, err := svc.DescribeInstancesWithContext(ctx, input)
 	if err != nil {
 		if aerr, ok := err.(awserr.Error); ok {
 			switch aerr.Code() {
@@ -1,10 +1,12 @@
 package restapi
 
 import (
+	"context"
 	"errors"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"time"
 )
 
 // Client represents a client for interaction with a CloudManager API
@@ -20,12 +22,14 @@ type Client struct {
 	GCPDeploymentManager string
 	CVSHostName          string
 	GCPCompute           string
-
-	httpClient http.Client
+	RetryPolicy          *RetryPolicy
+	// Transport sends the HTTP requests, http.DefaultTransport is used when nil
+	Transport http.RoundTripper
 }
 
-// Do sends the API Request, parses the response as JSON, and returns the HTTP status code as int, onCloudRequestID from header as string, the "result" value as byte
-func (c *Client) Do(baseURL string, hostType string, token string, paramsNil bool, accountID string, clientID string, req *Request, simulator bool) (int, []byte, string, error) {
+// Do sends the API Request, aborting when ctx is done, parses the response as JSON, and returns the HTTP status code as int, onCloudRequestID from header as string, the "result" value as byte.
+// A response with a non 2xx status code is returned along with an *APIError.
+func (c *Client) Do(ctx context.Context, baseURL string, hostType string, token string, paramsNil bool, accountID string, clientID string, req *Request, simulator bool) (int, []byte, string, error) {
 
 	var host string
 	var res []byte
@@ -52,14 +56,40 @@ func (c *Client) Do(baseURL string, hostType string, token string, paramsNil boo
 		gcpType = true
 	}
 
-	httpReq, err := req.BuildHTTPReq(host, token, c.Audience, baseURL, paramsNil, accountID, clientID, gcpType, simulator)
-	if err != nil {
-		return statusCode, res, onCloudRequestID, err
+	retryPolicy := DefaultRetryPolicy()
+	if c.RetryPolicy != nil {
+		retryPolicy = *c.RetryPolicy
 	}
-	httpRes, err := c.httpClient.Do(httpReq)
-	if err != nil {
-		log.Print("HTTP req failed")
-		return statusCode, res, onCloudRequestID, err
+
+	httpClient := http.Client{Transport: c.Transport}
+	var httpRes *http.Response
+	for attempt := 0; ; attempt++ {
+		// the request is rebuilt on every attempt so the body can be sent again
+		httpReq, err := req.BuildHTTPReq(ctx, host, token, c.Audience, baseURL, paramsNil, accountID, clientID, gcpType, simulator)
+		if err != nil {
+			return statusCode, res, onCloudRequestID, err
+		}
+		httpRes, err = httpClient.Do(httpReq)
+		if !retryPolicy.shouldRetry(req.Method, attempt, httpRes, err) {
+			if err != nil {
+				log.Print("HTTP req failed")
+				return statusCode, res, onCloudRequestID, err
+			}
+			break
+		}
+		wait := retryPolicy.backoff(attempt, httpRes)
+		if err != nil {
+			log.Printf("HTTP req %s %s failed: %v. Retry %d/%d in %v", req.Method, baseURL, err, attempt+1, retryPolicy.MaxRetries, wait)
+		} else {
+			log.Printf("HTTP req %s %s returned %d. Retry %d/%d in %v", req.Method, baseURL, httpRes.StatusCode, attempt+1, retryPolicy.MaxRetries, wait)
+			ioutil.ReadAll(httpRes.Body)
+			httpRes.Body.Close()
+		}
+		select {
+		case <-time.After(wait):
+		case <-ctx.Done():
+			return statusCode, res, onCloudRequestID, ctx.Err()
+		}
 	}
 
 	if httpRes.Header.Get("OnCloud-Request-Id") != "" {
@@ -69,7 +99,7 @@ func (c *Client) Do(baseURL string, hostType string, token string, paramsNil boo
 
 	defer httpRes.Body.Close()
 
-	res, err = ioutil.ReadAll(httpRes.Body)
+	res, err := ioutil.ReadAll(httpRes.Body)
 	if err != nil {
 		log.Print("HTTP decoder failed")
 		return statusCode, res, onCloudRequestID, err
@@ -80,5 +110,8 @@ func (c *Client) Do(baseURL string, hostType string, token string, paramsNil boo
 	}
 
 	statusCode = httpRes.StatusCode
+	if statusCode < 200 || statusCode >= 300 {
+		return statusCode, res, onCloudRequestID, NewAPIError(req.Method, baseURL, statusCode, res, onCloudRequestID)
+	}
 	return statusCode, res, onCloudRequestID, nil
 }
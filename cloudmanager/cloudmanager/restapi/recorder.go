@@ -0,0 +1,246 @@
+package restapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// Recorder modes
+const (
+	// RecordModeRecord sends the requests and saves every interaction to the cassette
+	RecordModeRecord = "record"
+	// RecordModeReplay serves the responses from the cassette without sending any request
+	RecordModeReplay = "replay"
+)
+
+// redacted replaces the value of sensitive headers and JSON fields in cassettes
+const redacted = "REDACTED"
+
+// sensitiveHeaders are replaced in recorded requests and responses
+var sensitiveHeaders = []string{"Authorization", "X-User-Token", "Cookie", "Set-Cookie"}
+
+// sensitiveFields are the substrings of the JSON field names, compared in lower case, whose values are redacted
+var sensitiveFields = []string{"password", "secret", "token", "privatekey", "private_key", "accesskey", "access_key", "passphrase"}
+
+// Interaction is a request and its response, as saved in a cassette
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the sanitized part of a request saved in a cassette
+type RecordedRequest struct {
+	Method  string      `json:"method"`
+	URL     string      `json:"url"`
+	Headers http.Header `json:"headers,omitempty"`
+	Body    string      `json:"body,omitempty"`
+}
+
+// RecordedResponse is the sanitized part of a response saved in a cassette
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Headers    http.Header `json:"headers,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+// Recorder is an http.RoundTripper recording the interactions with the API to a cassette file,
+// or replaying them from it, so issues can be reproduced and tests run offline.
+// Tokens, passwords and secrets are redacted before being written.
+type Recorder struct {
+	mode      string
+	path      string
+	transport http.RoundTripper
+
+	mu           sync.Mutex
+	interactions []Interaction
+	// replayed tracks the interactions already served in replay mode
+	replayed []bool
+}
+
+// NewRecorder returns a Recorder for the cassette at path. In replay mode the cassette is loaded,
+// in record mode requests are sent through transport, or http.DefaultTransport when nil.
+func NewRecorder(mode string, path string, transport http.RoundTripper) (*Recorder, error) {
+	if path == "" {
+		return nil, fmt.Errorf("a cassette path is required to %s HTTP interactions", mode)
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	r := &Recorder{mode: mode, path: path, transport: transport}
+	switch mode {
+	case RecordModeRecord:
+		return r, nil
+	case RecordModeReplay:
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read cassette: %v", err)
+		}
+		if err := json.Unmarshal(content, &r.interactions); err != nil {
+			return nil, fmt.Errorf("cannot parse cassette %s: %v", path, err)
+		}
+		r.replayed = make([]bool, len(r.interactions))
+		return r, nil
+	}
+	return nil, fmt.Errorf("expected record mode to be one of [%s %s], got: %s", RecordModeRecord, RecordModeReplay, mode)
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	if r.mode == RecordModeReplay {
+		return r.replay(req)
+	}
+	return r.record(req)
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		var err error
+		reqBody, err = ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	res, err := r.transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	resBody, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(resBody))
+
+	interaction := Interaction{
+		Request: RecordedRequest{
+			Method:  req.Method,
+			URL:     req.URL.String(),
+			Headers: sanitizeHeaders(req.Header),
+			Body:    sanitizeBody(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: res.StatusCode,
+			Headers:    sanitizeHeaders(res.Header),
+			Body:       sanitizeBody(resBody),
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.interactions = append(r.interactions, interaction)
+	// the cassette is rewritten after every interaction, so it is complete even if the provider is killed
+	if err := r.save(); err != nil {
+		log.Printf("cannot save cassette %s: %v", r.path, err)
+	}
+	return res, nil
+}
+
+func (r *Recorder) save() error {
+	content, err := json.MarshalIndent(r.interactions, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, content, 0600)
+}
+
+// replay serves the first interaction not yet replayed with the same method and URL
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	url := req.URL.String()
+	for i, interaction := range r.interactions {
+		if r.replayed[i] || interaction.Request.Method != req.Method || interaction.Request.URL != url {
+			continue
+		}
+		r.replayed[i] = true
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		header := http.Header{}
+		for key, values := range interaction.Response.Headers {
+			header[key] = append([]string(nil), values...)
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", interaction.Response.StatusCode, http.StatusText(interaction.Response.StatusCode)),
+			StatusCode:    interaction.Response.StatusCode,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(strings.NewReader(interaction.Response.Body)),
+			ContentLength: int64(len(interaction.Response.Body)),
+			Request:       req,
+		}, nil
+	}
+	return nil, fmt.Errorf("no recorded interaction left in cassette %s for %s %s", r.path, req.Method, url)
+}
+
+func sanitizeHeaders(headers http.Header) http.Header {
+	sanitized := http.Header{}
+	for key, values := range headers {
+		sanitized[key] = append([]string(nil), values...)
+	}
+	for _, key := range sensitiveHeaders {
+		if sanitized.Get(key) != "" {
+			sanitized.Set(key, redacted)
+		}
+	}
+	return sanitized
+}
+
+// sanitizeBody redacts the sensitive fields of a JSON body. Bodies which are not JSON are kept as is.
+func sanitizeBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var content interface{}
+	if err := json.Unmarshal(body, &content); err != nil {
+		return string(body)
+	}
+	sanitized, err := json.Marshal(sanitizeValue(content))
+	if err != nil {
+		return string(body)
+	}
+	return string(sanitized)
+}
+
+func sanitizeValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, field := range v {
+			if isSensitiveField(key) {
+				if _, ok := field.(string); ok {
+					v[key] = redacted
+					continue
+				}
+			}
+			v[key] = sanitizeValue(field)
+		}
+	case []interface{}:
+		for i := range v {
+			v[i] = sanitizeValue(v[i])
+		}
+	}
+	return value
+}
+
+func isSensitiveField(name string) bool {
+	name = strings.ToLower(name)
+	for _, field := range sensitiveFields {
+		if strings.Contains(name, field) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,116 @@
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorderRecordsSanitizedInteractions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"access_token":"secret-token","expires_in":3600,"name":"svm_1"}`))
+	}))
+	defer server.Close()
+
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(RecordModeRecord, cassette, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := newTestClient(server.URL, 0)
+	client.Transport = recorder
+	req := &Request{Method: "POST", Params: map[string]interface{}{"name": "vol1", "svmPassword": "P@ssw0rd"}}
+	_, res, _, err := client.Do(context.Background(), "/occm/api/vsa/volumes", "CloudManagerHost", "bearer-value", false, "", "", req, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(string(res), "secret-token") {
+		t.Fatalf("the response returned to the caller must not be redacted, got %s", res)
+	}
+
+	content, err := ioutil.ReadFile(cassette)
+	if err != nil {
+		t.Fatalf("cassette not written: %v", err)
+	}
+	for _, leaked := range []string{"secret-token", "P@ssw0rd", "bearer-value"} {
+		if strings.Contains(string(content), leaked) {
+			t.Errorf("cassette contains %q: %s", leaked, content)
+		}
+	}
+	var interactions []Interaction
+	if err := json.Unmarshal(content, &interactions); err != nil {
+		t.Fatalf("cannot parse cassette: %v", err)
+	}
+	if len(interactions) != 1 {
+		t.Fatalf("expected 1 interaction, got %d", len(interactions))
+	}
+	if got := interactions[0].Request.Headers.Get("Authorization"); got != redacted {
+		t.Errorf("expected Authorization to be redacted, got %q", got)
+	}
+	if !strings.Contains(interactions[0].Request.Body, `"name":"vol1"`) {
+		t.Errorf("expected non sensitive fields to be kept, got %s", interactions[0].Request.Body)
+	}
+}
+
+func TestRecorderReplaysInteractionsInOrder(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Write([]byte(`{"status":0}`))
+			return
+		}
+		w.Write([]byte(`{"status":1}`))
+	}))
+
+	cassette := filepath.Join(t.TempDir(), "cassette.json")
+	recorder, err := NewRecorder(RecordModeRecord, cassette, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := newTestClient(server.URL, 0)
+	client.Transport = recorder
+	for i := 0; i < 2; i++ {
+		if _, _, _, err := client.Do(context.Background(), "/occm/api/audit/activeTask/1", "CloudManagerHost", "", true, "", "", &Request{Method: "GET"}, false); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	server.Close()
+
+	replayer, err := NewRecorder(RecordModeReplay, cassette, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client.Transport = replayer
+	for _, expected := range []string{`{"status":0}`, `{"status":1}`} {
+		statusCode, res, _, err := client.Do(context.Background(), "/occm/api/audit/activeTask/1", "CloudManagerHost", "", true, "", "", &Request{Method: "GET"}, false)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if statusCode != http.StatusOK || string(res) != expected {
+			t.Fatalf("expected %s, got %d %s", expected, statusCode, res)
+		}
+	}
+
+	_, _, _, err = client.Do(context.Background(), "/occm/api/audit/activeTask/1", "CloudManagerHost", "", true, "", "", &Request{Method: "GET"}, false)
+	if err == nil || !strings.Contains(err.Error(), "no recorded interaction left") {
+		t.Fatalf("expected a replay miss, got %v", err)
+	}
+}
+
+func TestNewRecorderValidatesArguments(t *testing.T) {
+	if _, err := NewRecorder("playback", filepath.Join(t.TempDir(), "cassette.json"), nil); err == nil {
+		t.Error("expected an error for an unknown mode")
+	}
+	if _, err := NewRecorder(RecordModeRecord, "", nil); err == nil {
+		t.Error("expected an error without a cassette path")
+	}
+	if _, err := NewRecorder(RecordModeReplay, filepath.Join(t.TempDir(), "missing.json"), nil); err == nil {
+		t.Error("expected an error for a missing cassette")
+	}
+}
@@ -9,7 +9,6 @@ import (
 	"net/http"
 	"strings"
 
-	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 	"golang.org/x/oauth2/jwt"
 )
@@ -22,7 +21,7 @@ type Request struct {
 	GCPServiceAccountKey  string
 }
 
-func getGCPToken(url string, gcpServiceAccountKey string) (string, error) {
+func getGCPToken(ctx context.Context, url string, gcpServiceAccountKey string) (string, error) {
 	var token string
 	scopes := []string{
 		"https://www.googleapis.com/auth/cloud-platform",
@@ -45,14 +44,13 @@ func getGCPToken(url string, gcpServiceAccountKey string) (string, error) {
 			Scopes:     scopes,
 			TokenURL:   google.JWTTokenURL,
 		}
-		gcpToken, err := config.TokenSource(oauth2.NoContext).Token()
+		gcpToken, err := config.TokenSource(ctx).Token()
 		if err != nil {
 			return "", err
 		}
 		token = gcpToken.AccessToken
 	} else {
 		// find default application credential
-		ctx := context.Background()
 		credential, err := google.FindDefaultCredentials(ctx, scopes...)
 		if err != nil {
 			return "", fmt.Errorf("cannot get credentials: %v", err)
@@ -67,8 +65,8 @@ func getGCPToken(url string, gcpServiceAccountKey string) (string, error) {
 	return token, nil
 }
 
-// BuildHTTPReq builds an HTTP request to carry out the REST request
-func (r *Request) BuildHTTPReq(host string, token string, audience string, baseURL string, paramsNil bool, accountID string, clientID string, gcpType bool, cloudmanagerSimulator bool) (*http.Request, error) {
+// BuildHTTPReq builds an HTTP request bound to ctx to carry out the REST request
+func (r *Request) BuildHTTPReq(ctx context.Context, host string, token string, audience string, baseURL string, paramsNil bool, accountID string, clientID string, gcpType bool, cloudmanagerSimulator bool) (*http.Request, error) {
 
 	url := host + baseURL
 	var req *http.Request
@@ -78,33 +76,33 @@ func (r *Request) BuildHTTPReq(host string, token string, audience string, baseU
 	if gcpType == true {
 		if r.Method == "POST" {
 			if paramsNil {
-				req, err = http.NewRequest(r.Method, url, bytes.NewReader([]byte(r.GCPDeploymentTemplate)))
+				req, err = http.NewRequestWithContext(ctx, r.Method, url, bytes.NewReader([]byte(r.GCPDeploymentTemplate)))
 				if err != nil {
 					return nil, err
 				}
 			} else {
 				bodyJSON, err := json.Marshal(r.Params)
-				req, err = http.NewRequest(r.Method, url, bytes.NewReader([]byte(bodyJSON)))
+				req, err = http.NewRequestWithContext(ctx, r.Method, url, bytes.NewReader([]byte(bodyJSON)))
 				if err != nil {
 					return nil, err
 				}
 			}
 		} else {
 			var err error
-			req, err = http.NewRequest(r.Method, url, nil)
+			req, err = http.NewRequestWithContext(ctx, r.Method, url, nil)
 			if err != nil {
 				return nil, err
 			}
 		}
 
-		token, err = getGCPToken(url, r.GCPServiceAccountKey)
+		token, err = getGCPToken(ctx, url, r.GCPServiceAccountKey)
 		if err != nil {
 			return nil, err
 		}
 	} else {
 		if paramsNil {
 			var err error
-			req, err = http.NewRequest(r.Method, url, nil)
+			req, err = http.NewRequestWithContext(ctx, r.Method, url, nil)
 			if err != nil {
 				return nil, err
 			}
@@ -113,7 +111,7 @@ func (r *Request) BuildHTTPReq(host string, token string, audience string, baseU
 			if err != nil {
 				return nil, err
 			}
-			req, err = http.NewRequest(r.Method, url, bytes.NewReader(bodyJSON))
+			req, err = http.NewRequestWithContext(ctx, r.Method, url, bytes.NewReader(bodyJSON))
 			if err != nil {
 				return nil, err
 			}
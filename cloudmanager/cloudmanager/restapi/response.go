@@ -1,7 +1,10 @@
 package restapi
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 )
 
 // ResponseError represents an Error to a REST API call
@@ -14,3 +17,79 @@ type ResponseError struct {
 func (e *ResponseError) Error() string {
 	return fmt.Sprintf("Request returned an error. %+v", *e)
 }
+
+// APIError is returned when a REST API call is answered with a non 2xx status code
+type APIError struct {
+	// StatusCode is the HTTP status code of the response
+	StatusCode int
+	// Code is the BlueXP error code from the response body, if any
+	Code string
+	// Message is the error message from the response body, or the raw body when it can't be parsed
+	Message string
+	// RequestID is the OnCloud-Request-Id header of the response, to be quoted when opening a support case
+	RequestID string
+	Method    string
+	Endpoint  string
+	// Body is the raw response body
+	Body []byte
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s failed with code: %d", strings.TrimSpace(e.Method+" "+e.Endpoint), e.StatusCode)
+	if e.Code != "" {
+		fmt.Fprintf(&b, ", error code: %s", e.Code)
+	}
+	fmt.Fprintf(&b, ", message: %s", e.Message)
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, ", request ID: %s", e.RequestID)
+	}
+	return b.String()
+}
+
+// errorBody covers the error payloads of BlueXP and of the cloud provider APIs, which nest them under "error"
+type errorBody struct {
+	Code         interface{} `json:"code"`
+	Message      string      `json:"message"`
+	CauseMessage string      `json:"causeMessage"`
+	Error        *errorBody  `json:"error"`
+}
+
+// NewAPIError builds an APIError from a response, parsing the error code and message from the JSON body
+func NewAPIError(method string, endpoint string, statusCode int, body []byte, requestID string) *APIError {
+	apiErr := &APIError{
+		StatusCode: statusCode,
+		RequestID:  requestID,
+		Method:     method,
+		Endpoint:   endpoint,
+		Body:       body,
+		Message:    strings.TrimSpace(string(body)),
+	}
+
+	var parsed errorBody
+	if err := json.Unmarshal(body, &parsed); err != nil {
+		return apiErr
+	}
+	if parsed.Error != nil {
+		parsed = *parsed.Error
+	}
+	if parsed.Message != "" {
+		apiErr.Message = parsed.Message
+		if parsed.CauseMessage != "" && parsed.CauseMessage != parsed.Message {
+			apiErr.Message = fmt.Sprintf("%s: %s", parsed.Message, parsed.CauseMessage)
+		}
+	}
+	switch code := parsed.Code.(type) {
+	case string:
+		apiErr.Code = code
+	case float64:
+		apiErr.Code = fmt.Sprintf("%d", int(code))
+	}
+	return apiErr
+}
+
+// IsStatus reports whether err is, or wraps, an APIError with the given HTTP status code
+func IsStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
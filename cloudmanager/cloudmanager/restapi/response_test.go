@@ -0,0 +1,65 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDoReturnsAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("OnCloud-Request-Id", "req-123")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"Working environment not found","causeMessage":"VsaWorkingEnvironment VsaWorkingEnvironmentId(vsa-1) not found","code":"NotFound"}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL, 0)
+	statusCode, _, requestID, err := client.Do(context.Background(), "/occm/api/working-environments/vsa-1", "CloudManagerHost", "", true, "", "", &Request{Method: "GET"}, false)
+	if statusCode != http.StatusNotFound || requestID != "req-123" {
+		t.Fatalf("unexpected response %d %s", statusCode, requestID)
+	}
+
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected APIError, got %v", err)
+	}
+	if apiErr.Code != "NotFound" || apiErr.RequestID != "req-123" || apiErr.Method != "GET" || apiErr.Endpoint != "/occm/api/working-environments/vsa-1" {
+		t.Fatalf("unexpected APIError %+v", apiErr)
+	}
+	if apiErr.Message != "Working environment not found: VsaWorkingEnvironment VsaWorkingEnvironmentId(vsa-1) not found" {
+		t.Fatalf("unexpected message %q", apiErr.Message)
+	}
+	if !strings.Contains(err.Error(), "request ID: req-123") {
+		t.Fatalf("expected request ID in %q", err.Error())
+	}
+}
+
+func TestNewAPIError(t *testing.T) {
+	nested := NewAPIError("GET", "/deployments/occm", http.StatusConflict, []byte(`{"error":{"code":409,"message":"already exists"}}`), "")
+	if nested.Code != "409" || nested.Message != "already exists" {
+		t.Fatalf("unexpected APIError %+v", nested)
+	}
+
+	raw := NewAPIError("DELETE", "/occm/api/vsa/volumes", http.StatusBadGateway, []byte("Bad Gateway\n"), "")
+	if raw.Code != "" || raw.Message != "Bad Gateway" {
+		t.Fatalf("unexpected APIError %+v", raw)
+	}
+	if raw.Error() != "DELETE /occm/api/vsa/volumes failed with code: 502, message: Bad Gateway" {
+		t.Fatalf("unexpected error string %q", raw.Error())
+	}
+}
+
+func TestIsStatus(t *testing.T) {
+	err := fmt.Errorf("wrapped: %w", NewAPIError("GET", "/", http.StatusNotFound, nil, ""))
+	if !IsStatus(err, http.StatusNotFound) {
+		t.Fatal("expected wrapped APIError to match 404")
+	}
+	if IsStatus(err, http.StatusConflict) || IsStatus(errors.New("boom"), http.StatusNotFound) {
+		t.Fatal("unexpected status match")
+	}
+}
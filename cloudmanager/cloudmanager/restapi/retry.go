@@ -0,0 +1,108 @@
+package restapi
+
+import (
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Default values used when the provider does not configure a retry policy
+const (
+	DefaultMaxRetries   = 6
+	DefaultRetryWaitMin = 1 * time.Second
+	DefaultRetryWaitMax = 30 * time.Second
+)
+
+// RetryPolicy controls how the Client retries idempotent requests on transient failures
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt. Zero disables retries.
+	MaxRetries int
+	// RetryWaitMin is the base wait time for the exponential backoff
+	RetryWaitMin time.Duration
+	// RetryWaitMax caps the wait time between two attempts, including Retry-After
+	RetryWaitMax time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:   DefaultMaxRetries,
+		RetryWaitMin: DefaultRetryWaitMin,
+		RetryWaitMax: DefaultRetryWaitMax,
+	}
+}
+
+// isIdempotent reports whether a request with the given method can be safely resent
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// isRetryableStatus reports whether the HTTP status code is a transient failure
+func isRetryableStatus(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// shouldRetry decides if a request should be sent again after the given attempt (0 based)
+func (p RetryPolicy) shouldRetry(method string, attempt int, res *http.Response, err error) bool {
+	if attempt >= p.MaxRetries || !isIdempotent(method) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return isRetryableStatus(res.StatusCode)
+}
+
+// backoff returns how long to wait before the next attempt.
+// A Retry-After header on the response takes precedence over the exponential backoff.
+// Otherwise the wait is RetryWaitMin * 2^attempt, capped to RetryWaitMax, with full jitter.
+func (p RetryPolicy) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if wait, ok := retryAfter(res.Header.Get("Retry-After")); ok {
+			if p.RetryWaitMax > 0 && wait > p.RetryWaitMax {
+				return p.RetryWaitMax
+			}
+			return wait
+		}
+	}
+
+	wait := float64(p.RetryWaitMin) * math.Pow(2, float64(attempt))
+	if p.RetryWaitMax > 0 && wait > float64(p.RetryWaitMax) {
+		wait = float64(p.RetryWaitMax)
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return time.Duration(rand.Int63n(int64(wait)) + 1)
+}
+
+// retryAfter parses a Retry-After header given either as seconds or as an HTTP date
+func retryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		wait := time.Until(date)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+	return 0, false
+}
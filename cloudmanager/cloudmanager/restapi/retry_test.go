@@ -0,0 +1,124 @@
+package restapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestClient(url string, maxRetries int) *Client {
+	return &Client{
+		CloudManagerHost: url,
+		RetryPolicy: &RetryPolicy{
+			MaxRetries:   maxRetries,
+			RetryWaitMin: time.Millisecond,
+			RetryWaitMax: 5 * time.Millisecond,
+		},
+	}
+}
+
+func TestDoRetriesTransientStatus(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"status":1}`))
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL, 3)
+	statusCode, res, _, err := client.Do(context.Background(), "/occm/api/audit/activeTask/1", "CloudManagerHost", "", true, "", "", &Request{Method: "GET"}, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statusCode != http.StatusOK || string(res) != `{"status":1}` {
+		t.Fatalf("unexpected response %d %s", statusCode, res)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestDoStopsAfterMaxRetries(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL, 2)
+	statusCode, _, _, err := client.Do(context.Background(), "/occm/api/working-environments", "CloudManagerHost", "", true, "", "", &Request{Method: "GET"}, false)
+	if !IsStatus(err, http.StatusGatewayTimeout) {
+		t.Fatalf("expected APIError with status 504, got %v", err)
+	}
+	if statusCode != http.StatusGatewayTimeout {
+		t.Fatalf("expected status 504, got %d", statusCode)
+	}
+	if calls != 3 {
+		t.Fatalf("expected 3 calls, got %d", calls)
+	}
+}
+
+func TestDoDoesNotRetryPost(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := newTestClient(server.URL, 3)
+	statusCode, _, _, err := client.Do(context.Background(), "/occm/api/vsa/volumes", "CloudManagerHost", "", false, "", "", &Request{Method: "POST", Params: map[string]interface{}{"name": "vol1"}}, false)
+	if !IsStatus(err, http.StatusServiceUnavailable) {
+		t.Fatalf("expected APIError with status 503, got %v", err)
+	}
+	if statusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected status 503, got %d", statusCode)
+	}
+	if calls != 1 {
+		t.Fatalf("expected 1 call, got %d", calls)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxRetries: 5, RetryWaitMin: time.Second, RetryWaitMax: 10 * time.Second}
+
+	for attempt := 0; attempt < 6; attempt++ {
+		wait := policy.backoff(attempt, nil)
+		if wait <= 0 || wait > policy.RetryWaitMax {
+			t.Fatalf("attempt %d: backoff %v out of range", attempt, wait)
+		}
+	}
+
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("Retry-After", "3")
+	if wait := policy.backoff(0, res); wait != 3*time.Second {
+		t.Fatalf("expected Retry-After of 3s, got %v", wait)
+	}
+	res.Header.Set("Retry-After", "120")
+	if wait := policy.backoff(0, res); wait != policy.RetryWaitMax {
+		t.Fatalf("expected Retry-After capped to %v, got %v", policy.RetryWaitMax, wait)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	if _, ok := retryAfter(""); ok {
+		t.Fatal("expected empty Retry-After to be ignored")
+	}
+	if _, ok := retryAfter("soon"); ok {
+		t.Fatal("expected invalid Retry-After to be ignored")
+	}
+	if wait, ok := retryAfter("5"); !ok || wait != 5*time.Second {
+		t.Fatalf("expected 5s, got %v %v", wait, ok)
+	}
+	date := time.Now().Add(time.Hour).UTC().Format(http.TimeFormat)
+	if wait, ok := retryAfter(date); !ok || wait <= 0 || wait > time.Hour {
+		t.Fatalf("expected about 1h, got %v %v", wait, ok)
+	}
+}
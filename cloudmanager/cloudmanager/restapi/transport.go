@@ -0,0 +1,93 @@
+package restapi
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// TransportConfig holds the TLS and proxy settings applied to the requests sent to BlueXP, the Connector and CVS
+type TransportConfig struct {
+	// CABundle is a PEM encoded CA bundle, or the path of a file containing it, trusted in addition to the system CAs
+	CABundle string
+	// ClientCertificate and ClientKey are PEM encoded, or paths of files containing them, and enable mutual TLS
+	ClientCertificate string
+	ClientKey         string
+	// InsecureSkipVerify disables the verification of the server certificate, for lab use only
+	InsecureSkipVerify bool
+	// ProxyURL is the HTTP(S) proxy used for all requests. When empty, HTTPS_PROXY, HTTP_PROXY and NO_PROXY are honored.
+	ProxyURL string
+}
+
+// IsDefault reports whether no setting differs from http.DefaultTransport
+func (c TransportConfig) IsDefault() bool {
+	return c == TransportConfig{}
+}
+
+// NewTransport returns an http.Transport based on http.DefaultTransport with the TLS and proxy settings of config
+func NewTransport(config TransportConfig) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+
+	if config.CABundle != "" {
+		bundle, err := readPEM(config.CABundle)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read ca_bundle: %v", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil || pool == nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(bundle) {
+			return nil, fmt.Errorf("ca_bundle does not contain any PEM encoded certificate")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if config.ClientCertificate != "" || config.ClientKey != "" {
+		if config.ClientCertificate == "" || config.ClientKey == "" {
+			return nil, fmt.Errorf("client_certificate and client_key must be set together")
+		}
+		certPEM, err := readPEM(config.ClientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read client_certificate: %v", err)
+		}
+		keyPEM, err := readPEM(config.ClientKey)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read client_key: %v", err)
+		}
+		certificate, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("cannot load the client certificate: %v", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{certificate}
+	}
+
+	if config.InsecureSkipVerify {
+		log.Print("[WARN] TLS certificate verification is disabled, do not use insecure_skip_verify in production")
+		tlsConfig.InsecureSkipVerify = true
+	}
+	transport.TLSClientConfig = tlsConfig
+
+	if config.ProxyURL != "" {
+		proxyURL, err := url.Parse(config.ProxyURL)
+		if err != nil || proxyURL.Scheme == "" || proxyURL.Host == "" {
+			return nil, fmt.Errorf("expected proxy_url to be a URL such as http://proxy:3128, got: %s", config.ProxyURL)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	return transport, nil
+}
+
+// readPEM returns value when it is PEM encoded content, and the content of the file it names otherwise
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(value), nil
+	}
+	return ioutil.ReadFile(value)
+}
@@ -0,0 +1,124 @@
+package restapi
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func newTLSTestClient(t *testing.T, url string, config TransportConfig) *Client {
+	transport, err := NewTransport(config)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	client := newTestClient(url, 0)
+	client.Transport = transport
+	return client
+}
+
+func getActiveTask(client *Client) error {
+	_, _, _, err := client.Do(context.Background(), "/occm/api/audit/activeTask/1", "CloudManagerHost", "", true, "", "", &Request{Method: "GET"}, false)
+	return err
+}
+
+func TestTransportCABundle(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+	bundle := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	if err := getActiveTask(newTLSTestClient(t, server.URL, TransportConfig{ProxyURL: "http://127.0.0.1:1"})); err == nil {
+		t.Fatal("expected the request to fail through an unreachable proxy")
+	}
+	if err := getActiveTask(newTestClient(server.URL, 0)); err == nil {
+		t.Fatal("expected the self-signed certificate to be rejected without a CA bundle")
+	}
+	if err := getActiveTask(newTLSTestClient(t, server.URL, TransportConfig{CABundle: bundle})); err != nil {
+		t.Fatalf("expected the CA bundle to be trusted: %v", err)
+	}
+
+	bundleFile := filepath.Join(t.TempDir(), "ca.pem")
+	if err := ioutil.WriteFile(bundleFile, []byte(bundle), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if err := getActiveTask(newTLSTestClient(t, server.URL, TransportConfig{CABundle: bundleFile})); err != nil {
+		t.Fatalf("expected the CA bundle file to be trusted: %v", err)
+	}
+	if err := getActiveTask(newTLSTestClient(t, server.URL, TransportConfig{InsecureSkipVerify: true})); err != nil {
+		t.Fatalf("expected the verification to be skipped: %v", err)
+	}
+}
+
+func TestTransportClientCertificate(t *testing.T) {
+	certPEM, keyPEM := generateCertificate(t)
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{}`))
+	}))
+	server.TLS = &tls.Config{ClientAuth: tls.RequireAnyClientCert}
+	server.StartTLS()
+	defer server.Close()
+
+	if err := getActiveTask(newTLSTestClient(t, server.URL, TransportConfig{InsecureSkipVerify: true})); err == nil {
+		t.Fatal("expected the request to fail without a client certificate")
+	}
+	config := TransportConfig{InsecureSkipVerify: true, ClientCertificate: certPEM, ClientKey: keyPEM}
+	if err := getActiveTask(newTLSTestClient(t, server.URL, config)); err != nil {
+		t.Fatalf("expected the client certificate to be sent: %v", err)
+	}
+}
+
+func TestNewTransportValidatesConfig(t *testing.T) {
+	certPEM, _ := generateCertificate(t)
+	for name, config := range map[string]TransportConfig{
+		"missing CA file":     {CABundle: filepath.Join(t.TempDir(), "missing.pem")},
+		"CA without PEM":      {CABundle: "-----BEGIN nothing"},
+		"certificate alone":   {ClientCertificate: certPEM},
+		"invalid proxy":       {ProxyURL: "proxy:3128"},
+		"mismatched key pair": {ClientCertificate: certPEM, ClientKey: certPEM},
+	} {
+		if _, err := NewTransport(config); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+	if !(TransportConfig{}).IsDefault() || (TransportConfig{ProxyURL: "http://proxy:3128"}).IsDefault() {
+		t.Error("unexpected IsDefault result")
+	}
+}
+
+func generateCertificate(t *testing.T) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "terraform"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+	return string(certPEM), string(keyPEM)
+}
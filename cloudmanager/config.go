@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"log"
 	"strings"
+	"time"
+
+	"github.com/netapp/terraform-provider-netapp-cloudmanager/cloudmanager/cloudmanager/restapi"
 )
 
 // Config is a struct for user input
@@ -18,6 +21,12 @@ type configStruct struct {
 	AWSProfileFilePath string
 	AzureAuthMethods   []string
 	ConnectorHost      string
+	MaxRetries         int
+	RetryWaitMin       int
+	RetryWaitMax       int
+	HTTPRecordMode     string
+	HTTPCassette       string
+	Transport          restapi.TransportConfig
 }
 
 // Client is the main function to connect to the APi
@@ -84,6 +93,26 @@ func (c *configStruct) clientFun() (*Client, error) {
 	client.AWSProfile = c.AWSProfile
 	client.AWSProfileFilePath = c.AWSProfileFilePath
 	client.AzureAuthMethods = c.AzureAuthMethods
+	client.RetryPolicy = &restapi.RetryPolicy{
+		MaxRetries:   c.MaxRetries,
+		RetryWaitMin: time.Duration(c.RetryWaitMin) * time.Second,
+		RetryWaitMax: time.Duration(c.RetryWaitMax) * time.Second,
+	}
+	if !c.Transport.IsDefault() {
+		transport, err := restapi.NewTransport(c.Transport)
+		if err != nil {
+			return &Client{}, err
+		}
+		client.Transport = transport
+	}
+	if c.HTTPRecordMode != "" {
+		log.Printf("HTTP record mode: %s, cassette: %s", c.HTTPRecordMode, c.HTTPCassette)
+		recorder, err := restapi.NewRecorder(c.HTTPRecordMode, c.HTTPCassette, client.Transport)
+		if err != nil {
+			return &Client{}, err
+		}
+		client.Transport = recorder
+	}
 
 	return client, nil
 }
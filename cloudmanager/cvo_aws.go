@@ -1,6 +1,7 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -124,7 +125,7 @@ type wormRetentionPeriod struct {
 	Unit   string `structs:"unit"`
 }
 
-func (c *Client) getTenant(clientID string) (string, error) {
+func (c *Client) getTenant(ctx context.Context, clientID string) (string, error) {
 
 	log.Print("getTenant client=", clientID)
 
@@ -132,17 +133,12 @@ func (c *Client) getTenant(clientID string) (string, error) {
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getTenant request failed ", statusCode)
 		return "", err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "getTenant")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	var result []tenantResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getTenant ", err)
@@ -152,60 +148,18 @@ func (c *Client) getTenant(clientID string) (string, error) {
 	return result[0].PublicID, nil
 }
 
-func (c *Client) getCVOAWS(id string, clientID string) (string, error) {
-
-	log.Print("getCVOAWS")
-
-	accessTokenResult, err := c.getAccessToken()
-	if err != nil {
-		log.Print("in getCVOAWS request, failed to get AccessToken")
-		return "", err
-	}
-	c.Token = accessTokenResult.Token
-
-	baseURL := "/occm/api/working-environments"
-
-	hostType := "CloudManagerHost"
-
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
-	if err != nil {
-		log.Print("getCVOAWS request failed ", statusCode)
-		return "", err
-	}
-
-	responseError := apiResponseChecker(statusCode, response, "getCVOAWS")
-	if responseError != nil {
-		return "", responseError
-	}
-
-	var result cvoList
-	if err := json.Unmarshal(response, &result); err != nil {
-		log.Print("Failed to unmarshall response from getCVOAWS ", err)
-		return "", err
-	}
-
-	for _, cvoID := range result.CVO {
-		if cvoID.PublicID == id {
-			return cvoID.PublicID, nil
-		}
-	}
-
-	return "", nil
-}
-
-func (c *Client) createCVOAWS(cvoDetails createCVOAWSDetails, clientID string) (cvoResult, error) {
+func (c *Client) createCVOAWS(ctx context.Context, cvoDetails createCVOAWSDetails, clientID string) (cvoResult, error) {
 
 	log.Print("createCVO")
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in createCVO request, failed to get AccessToken")
 		return cvoResult{}, err
 	}
-	c.Token = accessTokenResult.Token
 
 	if cvoDetails.WorkspaceID == "" {
-		tenantID, err := c.getTenant(clientID)
+		tenantID, err := c.getTenant(ctx, clientID)
 		if err != nil {
 			log.Print("getTenant request failed ", err)
 			return cvoResult{}, err
@@ -217,7 +171,7 @@ func (c *Client) createCVOAWS(cvoDetails createCVOAWSDetails, clientID string) (
 	if cvoDetails.NssAccount == "" {
 		if cvoDetails.VsaMetadata.PlatformSerialNumber != "" {
 			if !strings.HasPrefix(cvoDetails.VsaMetadata.PlatformSerialNumber, "Eval-") && cvoDetails.VsaMetadata.LicenseType == "cot-premium-byol" {
-				nssAccount, err := c.getNSS(clientID)
+				nssAccount, err := c.getNSS(ctx, clientID)
 				if err != nil {
 					log.Print("getNSS request failed ", err)
 					return cvoResult{}, err
@@ -227,7 +181,7 @@ func (c *Client) createCVOAWS(cvoDetails createCVOAWSDetails, clientID string) (
 			}
 		} else if cvoDetails.HAParams.PlatformSerialNumberNode1 != "" && cvoDetails.HAParams.PlatformSerialNumberNode2 != "" {
 			if !strings.HasPrefix(cvoDetails.HAParams.PlatformSerialNumberNode1, "Eval-") && !strings.HasPrefix(cvoDetails.HAParams.PlatformSerialNumberNode2, "Eval-") && cvoDetails.VsaMetadata.LicenseType == "ha-cot-premium-byol" {
-				nssAccount, err := c.getNSS(clientID)
+				nssAccount, err := c.getNSS(ctx, clientID)
 				if err != nil {
 					log.Print("getNSS request failed ", err)
 					return cvoResult{}, err
@@ -239,7 +193,7 @@ func (c *Client) createCVOAWS(cvoDetails createCVOAWSDetails, clientID string) (
 	}
 
 	if cvoDetails.VpcID == "" {
-		vpcID, err := c.CallVPCGet(cvoDetails.SubnetID, cvoDetails.Region)
+		vpcID, err := c.CallVPCGet(ctx, cvoDetails.SubnetID, cvoDetails.Region)
 		if err != nil {
 			log.Print("CallVPCGet request failed")
 			return cvoResult{}, err
@@ -249,32 +203,23 @@ func (c *Client) createCVOAWS(cvoDetails createCVOAWSDetails, clientID string) (
 	}
 
 	var baseURL string
-	var CreationRetries int
 
-	log.Print("retries ", c.Retries)
 	if cvoDetails.IsHA == false {
 		baseURL = "/occm/api/vsa/working-environments"
-		CreationRetries = c.Retries
 	} else if cvoDetails.IsHA == true {
 		baseURL = "/occm/api/aws/ha/working-environments"
-		CreationRetries = c.Retries + 30
 	}
 
 	hostType := "CloudManagerHost"
 	params := structs.Map(cvoDetails)
 
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
 	if err != nil {
 		log.Print("createCVO request failed ", statusCode)
 		return cvoResult{}, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "createCVO")
-	if responseError != nil {
-		return cvoResult{}, responseError
-	}
-
-	err = c.waitOnCompletion(onCloudRequestID, "CVO", "create", CreationRetries, 60, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "CVO", "create", 60, clientID)
 	if err != nil {
 		return cvoResult{}, err
 	}
@@ -288,16 +233,15 @@ func (c *Client) createCVOAWS(cvoDetails createCVOAWSDetails, clientID string) (
 	return result, nil
 }
 
-func (c *Client) deleteCVO(id string, isHA bool, clientID string) error {
+func (c *Client) deleteCVO(ctx context.Context, id string, isHA bool, clientID string) error {
 
 	log.Print("deleteCVO")
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in deleteCVO request, failed to get AccessToken")
 		return err
 	}
-	c.Token = accessTokenResult.Token
 
 	var baseURL string
 
@@ -309,18 +253,13 @@ func (c *Client) deleteCVO(id string, isHA bool, clientID string) error {
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("deleteCVO request failed ", statusCode)
 		return err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "deleteCVO")
-	if responseError != nil {
-		return responseError
-	}
-
-	err = c.waitOnCompletion(onCloudRequestID, "CVO", "delete", 40, 60, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "CVO", "delete", 60, clientID)
 	if err != nil {
 		return err
 	}
@@ -1,6 +1,7 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -83,22 +84,21 @@ type nssAccountResult struct {
 	PublicID string `json:"publicId"`
 }
 
-func (c *Client) getNSS(clientID string) (string, error) {
+func (c *Client) getNSS(ctx context.Context, clientID string) (string, error) {
 
 	log.Print("getNSS")
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in createCVO request, failed to get AccessToken")
 		return "", err
 	}
-	c.Token = accessTokenResult.Token
 
 	baseURL := "/occm/api/accounts"
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getNSS request failed ", statusCode)
 		return "", err
@@ -107,11 +107,6 @@ func (c *Client) getNSS(clientID string) (string, error) {
 	log.Print("getNSS ")
 	log.Print(string(response))
 
-	responseError := apiResponseChecker(statusCode, response, "getNSS")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	var result accountForNSSResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getNSS ", err)
@@ -127,62 +122,18 @@ func (c *Client) getNSS(clientID string) (string, error) {
 	return result.NssAccounts[0].PublicID, nil
 }
 
-func (c *Client) getCVOAzure(id string, clientID string) (string, error) {
-
-	log.Print("getCVOAzure")
-
-	accessTokenResult, err := c.getAccessToken()
-	if err != nil {
-		log.Print("in getCVOAzure request, failed to get AccessToken")
-		return "", err
-	}
-	c.Token = accessTokenResult.Token
-
-	baseURL := "/occm/api/working-environments"
-
-	hostType := "CloudManagerHost"
-
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
-	if err != nil {
-		log.Print("getCVOAzure request failed ", statusCode)
-		return "", err
-	}
-
-	responseError := apiResponseChecker(statusCode, response, "getCVOAzure")
-	if responseError != nil {
-		return "", responseError
-	}
-
-	log.Print(string(response))
-
-	var result cvoListAzure
-	if err := json.Unmarshal(response, &result); err != nil {
-		log.Print("Failed to unmarshall response from getCVOAzure ", err)
-		return "", err
-	}
-
-	for _, cvoID := range result.CVO {
-		if cvoID.PublicID == id {
-			return cvoID.PublicID, nil
-		}
-	}
-
-	return "", nil
-}
-
-func (c *Client) createCVOAzure(cvoDetails createCVOAzureDetails, clientID string) (cvoResult, error) {
+func (c *Client) createCVOAzure(ctx context.Context, cvoDetails createCVOAzureDetails, clientID string) (cvoResult, error) {
 
 	log.Print("createCVO")
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in createCVO request, failed to get AccessToken")
 		return cvoResult{}, err
 	}
-	c.Token = accessTokenResult.Token
 
 	if cvoDetails.WorkspaceID == "" {
-		tenantID, err := c.getTenant(clientID)
+		tenantID, err := c.getTenant(ctx, clientID)
 		if err != nil {
 			log.Print("getTenant request failed ", err)
 			return cvoResult{}, err
@@ -192,7 +143,7 @@ func (c *Client) createCVOAzure(cvoDetails createCVOAzureDetails, clientID strin
 	}
 
 	if cvoDetails.NssAccount == "" && !strings.HasPrefix(cvoDetails.SerialNumber, "Eval-") && (cvoDetails.VsaMetadata.LicenseType == "azure-cot-premium-byol" || cvoDetails.VsaMetadata.LicenseType == "azure-ha-cot-premium-byol") {
-		nssAccount, err := c.getNSS(clientID)
+		nssAccount, err := c.getNSS(ctx, clientID)
 		if err != nil {
 			log.Print("getNSS request failed ", err)
 			return cvoResult{}, err
@@ -208,7 +159,7 @@ func (c *Client) createCVOAzure(cvoDetails createCVOAzureDetails, clientID strin
 		} else {
 			rg = cvoDetails.ResourceGroup
 		}
-		cidr, err := c.CallVNetGetCidr(cvoDetails.SubscriptionID, rg, cvoDetails.VnetForInternal)
+		cidr, err := c.CallVNetGetCidr(ctx, cvoDetails.SubscriptionID, rg, cvoDetails.VnetForInternal)
 		if err != nil {
 			log.Print("CallVNetGetCidr request failed")
 			return cvoResult{}, err
@@ -218,15 +169,11 @@ func (c *Client) createCVOAzure(cvoDetails createCVOAzureDetails, clientID strin
 	}
 
 	var baseURL string
-	var CreationRetries int
 
-	log.Print("retries ", c.Retries)
 	if !cvoDetails.IsHA {
 		baseURL = "/occm/api/azure/vsa/working-environments"
-		CreationRetries = c.Retries
 	} else if cvoDetails.IsHA {
 		baseURL = "/occm/api/azure/ha/working-environments"
-		CreationRetries = c.Retries + 30
 	}
 
 	log.Print(baseURL)
@@ -234,18 +181,13 @@ func (c *Client) createCVOAzure(cvoDetails createCVOAzureDetails, clientID strin
 	hostType := "CloudManagerHost"
 	params := structs.Map(cvoDetails)
 
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
 	if err != nil {
 		log.Print("createCVO request failed ", statusCode)
 		return cvoResult{}, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "createCVO")
-	if responseError != nil {
-		return cvoResult{}, responseError
-	}
-
-	err = c.waitOnCompletion(onCloudRequestID, "CVO", "create", CreationRetries, 60, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "CVO", "create", 60, clientID)
 	if err != nil {
 		return cvoResult{}, err
 	}
@@ -259,16 +201,15 @@ func (c *Client) createCVOAzure(cvoDetails createCVOAzureDetails, clientID strin
 	return result, nil
 }
 
-func (c *Client) deleteCVOAzure(id string, isHA bool, clientID string) error {
+func (c *Client) deleteCVOAzure(ctx context.Context, id string, isHA bool, clientID string) error {
 
 	log.Print("deleteCVO")
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in deleteCVO request, failed to get AccessToken")
 		return err
 	}
-	c.Token = accessTokenResult.Token
 
 	var baseURL string
 
@@ -280,18 +221,13 @@ func (c *Client) deleteCVOAzure(id string, isHA bool, clientID string) error {
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("deleteCVO request failed ", statusCode)
 		return err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "deleteCVO")
-	if responseError != nil {
-		return responseError
-	}
-
-	err = c.waitOnCompletion(onCloudRequestID, "CVO", "delete", 40, 60, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "CVO", "delete", 60, clientID)
 	if err != nil {
 		return err
 	}
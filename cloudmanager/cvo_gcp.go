@@ -1,13 +1,14 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 	"strings"
 
 	"github.com/fatih/structs"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // GCPLicenseTypes is the GCP License types
@@ -84,18 +85,17 @@ type haParamsGCP struct {
 	VPC3FirewallRuleName           string `structs:"vpc3FirewallRuleName,omitempty"`
 }
 
-func (c *Client) createCVOGCP(cvoDetails createCVOGCPDetails, clientID string) (cvoResult, error) {
+func (c *Client) createCVOGCP(ctx context.Context, cvoDetails createCVOGCPDetails, clientID string) (cvoResult, error) {
 	log.Printf("\n\ncreateCVO %s client_id %s", cvoDetails.Name, clientID)
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in createCVO request, failed to get AccessToken")
 		return cvoResult{}, err
 	}
-	c.Token = accessTokenResult.Token
 
 	if cvoDetails.WorkspaceID == "" {
-		tenantID, err := c.getTenant(clientID)
+		tenantID, err := c.getTenant(ctx, clientID)
 		if err != nil {
 			log.Print("getTenant request failed ", err)
 			return cvoResult{}, err
@@ -105,7 +105,7 @@ func (c *Client) createCVOGCP(cvoDetails createCVOGCPDetails, clientID string) (
 	}
 
 	if cvoDetails.NssAccount == "" && (cvoDetails.VsaMetadata.LicenseType == "gcp-cot-premium-byol" || cvoDetails.VsaMetadata.LicenseType == "gcp-ha-cot-premium-byol") && !strings.HasPrefix(cvoDetails.SerialNumber, "Eval-") {
-		nssAccount, err := c.getNSS(clientID)
+		nssAccount, err := c.getNSS(ctx, clientID)
 		if err != nil {
 			log.Print("getNSS request failed ", err)
 			return cvoResult{}, err
@@ -120,26 +120,13 @@ func (c *Client) createCVOGCP(cvoDetails createCVOGCPDetails, clientID string) (
 	params := structs.Map(cvoDetails)
 
 	log.Printf("Create GCP CVO: %#v", params)
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	statusCode, response, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
 	if err != nil {
 		log.Print("createCVO request failed ", statusCode)
 		return cvoResult{}, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "createCVO")
-	if responseError != nil {
-		return cvoResult{}, responseError
-	}
-
-	log.Print("retries ", c.Retries)
-	var CreationRetries int
-	if cvoDetails.IsHA == false {
-		CreationRetries = c.Retries
-	} else if cvoDetails.IsHA == true {
-		CreationRetries = c.Retries + 30
-	}
-
-	err = c.waitOnCompletion(onCloudRequestID, "CVO", "create", CreationRetries, 60, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "CVO", "create", 60, clientID)
 	if err != nil {
 		return cvoResult{}, err
 	}
@@ -154,33 +141,27 @@ func (c *Client) createCVOGCP(cvoDetails createCVOGCPDetails, clientID string) (
 	return result, nil
 }
 
-func (c *Client) deleteCVOGCP(id string, isHA bool, clientID string) error {
+func (c *Client) deleteCVOGCP(ctx context.Context, id string, isHA bool, clientID string) error {
 
 	log.Printf("deleteCVO: id %s client %s", id, clientID)
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in deleteCVO request, failed to get AccessToken")
 		return err
 	}
-	c.Token = accessTokenResult.Token
 
 	baseURL := getAPIRootForWorkingEnvironment(isHA, id)
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Printf("deleteCVO %s request failed %#v", id, statusCode)
 		return err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "deleteCVO")
-	if responseError != nil {
-		return responseError
-	}
-
-	err = c.waitOnCompletion(onCloudRequestID, "CVO", "delete", 40, 60, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "CVO", "delete", 60, clientID)
 	if err != nil {
 		return err
 	}
@@ -189,15 +170,14 @@ func (c *Client) deleteCVOGCP(id string, isHA bool, clientID string) error {
 }
 
 // This is used on GCP CVO HA only
-func (c *Client) addSVMtoCVO(id string, clientID string, svmName string) error {
+func (c *Client) addSVMtoCVO(ctx context.Context, id string, clientID string, svmName string) error {
 	log.Printf("addSVMtoCVO: id %s client %s svm %s", id, clientID, svmName)
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("In addSVMtoCVO request, failed to get AccessToken")
 		return err
 	}
-	c.Token = accessTokenResult.Token
 
 	// GCP CVO SVM add and deletion only support HA
 	baseURL := getAPIRootForWorkingEnvironment(true, id) + "/svm"
@@ -207,31 +187,25 @@ func (c *Client) addSVMtoCVO(id string, clientID string, svmName string) error {
 	svm.SvmName = svmName
 	params := structs.Map(svm)
 	log.Printf("\taddSVMtoCVO params: %#v", params)
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
 	if err != nil {
 		log.Print("addSVMtoCVO request failed ", statusCode)
 		return err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "addSVMtoCVO")
-	if responseError != nil {
-		return responseError
-	}
-
-	err = c.waitOnCompletion(onCloudRequestID, "CVO_SVM", "add", 60, 60, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "CVO_SVM", "add", 60, clientID)
 
 	return err
 }
 
-func (c *Client) deleteSVMfromCVO(id string, clientID string, svmName string) error {
+func (c *Client) deleteSVMfromCVO(ctx context.Context, id string, clientID string, svmName string) error {
 	log.Printf("deleteSVMfromCVO: id %s client %s svm %s", id, clientID, svmName)
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("In deleteSVMfromCVO request, failed to get AccessToken")
 		return err
 	}
-	c.Token = accessTokenResult.Token
 
 	// GCP CVO SVM add and deletion only support HA
 	baseURL := getAPIRootForWorkingEnvironment(true, id)
@@ -239,18 +213,13 @@ func (c *Client) deleteSVMfromCVO(id string, clientID string, svmName string) er
 	log.Print("\tDelete svm url: ", baseURL)
 	hostType := "CloudManagerHost"
 
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Printf("deleteSVMfromCVO %s request failed %#v", id, statusCode)
 		return err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "deleteSVMfromCVO")
-	if responseError != nil {
-		return responseError
-	}
-
-	err = c.waitOnCompletion(onCloudRequestID, "CVO_SVM", "delete", 40, 60, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "CVO_SVM", "delete", 60, clientID)
 
 	return err
 }
@@ -1,15 +1,16 @@
 package cloudmanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceAWSFSX() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceAWSFSXRead,
+		ReadContext: dataSourceAWSFSXRead,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -40,7 +41,7 @@ func dataSourceAWSFSX() *schema.Resource {
 	}
 }
 
-func dataSourceAWSFSXRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceAWSFSXRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Fetching aws fsx: %#v", d)
 
 	client := meta.(*Client)
@@ -48,28 +49,28 @@ func dataSourceAWSFSXRead(d *schema.ResourceData, meta interface{}) error {
 	id := d.Get("id").(string)
 	tenantID := d.Get("tenant_id").(string)
 
-	res, err := client.getAWSFSXByID(id, tenantID)
+	res, err := client.getAWSFSXByID(ctx, id, tenantID)
 	if err != nil {
 		log.Print("Error getting AWS FSX")
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(res.ID)
 	err = d.Set("name", res.Name)
 	if err != nil {
-		return fmt.Errorf("Error setting fsx name: %s", err.Error())
+		return diag.Errorf("Error setting fsx name: %s", err.Error())
 	}
 	err = d.Set("status", res.ProviderDetails.Status.Status)
 	if err != nil {
-		return fmt.Errorf("Error setting fsx status: %s", err.Error())
+		return diag.Errorf("Error setting fsx status: %s", err.Error())
 	}
 	err = d.Set("lifecycle_status", res.ProviderDetails.Status.Lifecycle)
 	if err != nil {
-		return fmt.Errorf("Error setting fsx lifecycle: %s", err.Error())
+		return diag.Errorf("Error setting fsx lifecycle: %s", err.Error())
 	}
 	err = d.Set("region", res.Region)
 	if err != nil {
-		return fmt.Errorf("Error setting fsx region: %s", err.Error())
+		return diag.Errorf("Error setting fsx region: %s", err.Error())
 	}
 	return nil
 }
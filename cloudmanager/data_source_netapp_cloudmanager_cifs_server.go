@@ -1,16 +1,18 @@
 package cloudmanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceCVOCIFS() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceCVOCIFSRead,
+		ReadContext: dataSourceCVOCIFSRead,
 
 		Schema: map[string]*schema.Schema{
 			"domain": {
@@ -99,7 +101,7 @@ func dataSourceCVOCIFS() *schema.Resource {
 	}
 }
 
-func dataSourceCVOCIFSRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceCVOCIFSRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Fetching cifs: %#v", d)
 
 	client := meta.(*Client)
@@ -107,31 +109,31 @@ func dataSourceCVOCIFSRead(d *schema.ResourceData, meta interface{}) error {
 	cifs := cifsRequest{}
 	if v, ok := d.GetOk("working_environment_id"); ok {
 		cifs.WorkingEnvironmentID = v.(string)
-		weInfo, err := client.getWorkingEnvironmentInfo(v.(string), clientID)
+		weInfo, err := client.getWorkingEnvironmentInfo(ctx, v.(string), clientID)
 		if err != nil {
 			return nil
 		}
-		weInfo, err = client.findWorkingEnvironmentByName(weInfo.Name, clientID)
+		weInfo, err = client.findWorkingEnvironmentByName(ctx, weInfo.Name, clientID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else if v, ok := d.GetOk("working_environment_name"); ok {
-		weInfo, err := client.findWorkingEnvironmentByName(v.(string), clientID)
+		weInfo, err := client.findWorkingEnvironmentByName(ctx, v.(string), clientID)
 		if err != nil {
 			return nil
 		}
 		cifs.WorkingEnvironmentID = weInfo.PublicID
 	} else {
-		return fmt.Errorf("either working_environment_id or working_environment_name is required")
+		return attributeErrorf("working_environment_id", "either working_environment_id or working_environment_name is required")
 	}
 
 	if v, ok := d.GetOk("svm_name"); ok {
 		cifs.SvmName = v.(string)
 	}
-	res, err := client.getCIFS(cifs, clientID)
+	res, err := client.getCIFS(ctx, cifs, clientID)
 	if err != nil {
 		log.Print("Error reading cifs")
-		return err
+		return diag.FromErr(err)
 	}
 	for _, cifsConfig := range res {
 		d.SetId(cifs.WorkingEnvironmentID)
@@ -142,5 +144,5 @@ func dataSourceCVOCIFSRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("organizational_unit", cifsConfig.OrganizationalUnit)
 		return nil
 	}
-	return fmt.Errorf("error reading cifs: cifs doesn't exist")
+	return diag.Errorf("error reading cifs: cifs doesn't exist")
 }
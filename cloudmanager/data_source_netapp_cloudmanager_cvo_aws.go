@@ -1,15 +1,16 @@
 package cloudmanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceCVOAWS() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceCVOAWSRead,
+		ReadContext: dataSourceCVOAWSRead,
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -27,7 +28,7 @@ func dataSourceCVOAWS() *schema.Resource {
 	}
 }
 
-func dataSourceCVOAWSRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceCVOAWSRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Reading CVO: %#v", d)
 	client := meta.(*Client)
 
@@ -35,17 +36,17 @@ func dataSourceCVOAWSRead(d *schema.ResourceData, meta interface{}) error {
 
 	if a, ok := d.GetOk("id"); ok {
 		WorkingEnvironmentID := a.(string)
-		workingEnvDetail, err := client.findWorkingEnvironmentByID(WorkingEnvironmentID, clientID)
+		workingEnvDetail, err := client.findWorkingEnvironmentByID(ctx, WorkingEnvironmentID, clientID)
 		if err != nil {
-			return fmt.Errorf("Cannot find working environment by working_environment_id %s", WorkingEnvironmentID)
+			return diag.Errorf("Cannot find working environment by working_environment_id %s", WorkingEnvironmentID)
 		}
 		d.SetId(workingEnvDetail.PublicID)
 		d.Set("name", workingEnvDetail.Name)
 		d.Set("svm_name", workingEnvDetail.SvmName)
 	} else if a, ok = d.GetOk("name"); ok {
-		workingEnvDetail, err := client.findWorkingEnvironmentByName(a.(string), clientID)
+		workingEnvDetail, err := client.findWorkingEnvironmentByName(ctx, a.(string), clientID)
 		if err != nil {
-			return fmt.Errorf("Cannot find working environment by working_environment_name %s", a.(string))
+			return diag.Errorf("Cannot find working environment by working_environment_name %s", a.(string))
 		}
 		d.SetId(workingEnvDetail.PublicID)
 		d.Set("name", workingEnvDetail.Name)
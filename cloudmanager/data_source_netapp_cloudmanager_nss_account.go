@@ -1,15 +1,16 @@
 package cloudmanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceCVONssAccount() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceCVONssAccountRead,
+		ReadContext: dataSourceCVONssAccountRead,
 
 		Schema: map[string]*schema.Schema{
 			"username": {
@@ -31,17 +32,17 @@ func dataSourceCVONssAccount() *schema.Resource {
 	}
 }
 
-func dataSourceCVONssAccountRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceCVONssAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Getting nss account: %s", d.Get("username").(string))
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
-	res, err := client.getNssAccount(d.Get("username").(string), clientID)
+	res, err := client.getNssAccount(ctx, d.Get("username").(string), clientID)
 	if err != nil {
 		log.Printf("Error getting nss account: %s", d.Get("username").(string))
-		return err
+		return diag.FromErr(err)
 	}
 	if res == nil {
-		return fmt.Errorf("Failed to find account: %s", d.Get("username"))
+		return diag.Errorf("Failed to find account: %s", d.Get("username"))
 	}
 	d.Set("username", res["nssUserName"])
 	d.SetId(res["publicId"].(string))
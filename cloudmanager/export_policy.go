@@ -0,0 +1,176 @@
+package cloudmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+
+	"github.com/fatih/structs"
+)
+
+type exportPolicyRequest struct {
+	Name    string       `structs:"name,omitempty"`
+	SvmName string       `structs:"svmName,omitempty"`
+	Rules   []exportRule `structs:"rules"`
+}
+
+// exportRule is a rule of an export policy, the rules being evaluated by ascending index
+type exportRule struct {
+	Index         int      `structs:"index" json:"index"`
+	ClientMatch   []string `structs:"clientMatch" json:"clientMatch"`
+	RoRule        []string `structs:"roRule" json:"roRule"`
+	RwRule        []string `structs:"rwRule" json:"rwRule"`
+	Superuser     []string `structs:"superuser,omitempty" json:"superuser"`
+	Protocols     []string `structs:"protocols,omitempty" json:"protocols"`
+	AnonymousUser string   `structs:"anonymousUser,omitempty" json:"anonymousUser"`
+}
+
+type exportPolicyResponse struct {
+	Name    string       `json:"name"`
+	SvmName string       `json:"svmName"`
+	Rules   []exportRule `json:"rules"`
+}
+
+func expandExportPolicyRules(rules []interface{}) []exportRule {
+	result := make([]exportRule, 0, len(rules))
+	for i, v := range rules {
+		rule := v.(map[string]interface{})
+		result = append(result, exportRule{
+			Index:         i + 1,
+			ClientMatch:   expandStringList(rule["client_match"].([]interface{})),
+			RoRule:        expandStringList(rule["ro_rule"].([]interface{})),
+			RwRule:        expandStringList(rule["rw_rule"].([]interface{})),
+			Superuser:     expandStringList(rule["superuser"].([]interface{})),
+			Protocols:     expandStringList(rule["protocols"].([]interface{})),
+			AnonymousUser: rule["anonymous_user"].(string),
+		})
+	}
+	return result
+}
+
+func flattenExportPolicyRules(rules []exportRule) []interface{} {
+	sort.SliceStable(rules, func(i, j int) bool {
+		return rules[i].Index < rules[j].Index
+	})
+	result := make([]interface{}, 0, len(rules))
+	for _, rule := range rules {
+		result = append(result, map[string]interface{}{
+			"client_match":   rule.ClientMatch,
+			"ro_rule":        rule.RoRule,
+			"rw_rule":        rule.RwRule,
+			"superuser":      rule.Superuser,
+			"protocols":      rule.Protocols,
+			"anonymous_user": rule.AnonymousUser,
+		})
+	}
+	return result
+}
+
+func expandStringList(list []interface{}) []string {
+	result := make([]string, 0, len(list))
+	for _, v := range list {
+		result = append(result, v.(string))
+	}
+	return result
+}
+
+// exportPoliciesURL returns the URL of the export policies of the working environment or file system
+func (c *Client) exportPoliciesURL(ctx context.Context, id string, clientID string) (string, error) {
+	baseURL, _, err := c.getAPIRoot(ctx, id, clientID)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/working-environments/%s/export-policies", baseURL, id), nil
+}
+
+func (c *Client) createExportPolicy(ctx context.Context, id string, policy exportPolicyRequest, clientID string) error {
+	baseURL, err := c.exportPoliciesURL(ctx, id, clientID)
+	if err != nil {
+		return err
+	}
+	hostType := "CloudManagerHost"
+	params := structs.Map(policy)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("createExportPolicy request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "export policy", "create", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getExportPolicy returns the export policy of the SVM, or errNotFound
+func (c *Client) getExportPolicy(ctx context.Context, id string, svmName string, name string, clientID string) (exportPolicyResponse, error) {
+	baseURL, err := c.exportPoliciesURL(ctx, id, clientID)
+	if err != nil {
+		return exportPolicyResponse{}, err
+	}
+	baseURL = fmt.Sprintf("%s?svm=%s", baseURL, svmName)
+	hostType := "CloudManagerHost"
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("getExportPolicy request failed ", statusCode)
+		return exportPolicyResponse{}, err
+	}
+	var result []exportPolicyResponse
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getExportPolicy ", err)
+		return exportPolicyResponse{}, err
+	}
+	for _, policy := range result {
+		if policy.Name == name {
+			return policy, nil
+		}
+	}
+	return exportPolicyResponse{}, fmt.Errorf("export policy %s: %w", name, errNotFound)
+}
+
+// updateExportPolicy replaces the rules of the export policy
+func (c *Client) updateExportPolicy(ctx context.Context, id string, policy exportPolicyRequest, clientID string) error {
+	baseURL, err := c.exportPoliciesURL(ctx, id, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/%s/%s", baseURL, policy.SvmName, policy.Name)
+	policy.Name = ""
+	policy.SvmName = ""
+	hostType := "CloudManagerHost"
+	params := structs.Map(policy)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "PUT", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("updateExportPolicy request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "export policy", "update", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) deleteExportPolicy(ctx context.Context, id string, svmName string, name string, clientID string) error {
+	baseURL, err := c.exportPoliciesURL(ctx, id, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/%s/%s", baseURL, svmName, name)
+	hostType := "CloudManagerHost"
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("deleteExportPolicy request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "export policy", "delete", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -1,12 +1,13 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
 
 	"github.com/fatih/structs"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 // GiBToBytes converting GB to bytes
@@ -118,23 +119,19 @@ type weeklySchedule struct {
 	SnapshotsToKeep int    `structs:"snapshotsToKeep"`
 }
 
-func (c *Client) createGCPVolume(vol gcpVolumeRequest, info cvsInfo, clientID string) (gcpVolumeResponse, error) {
-	baseURL, err := c.getCVSAPIRoot(info.AccountName, vol.WorkingEnvironmentName, clientID)
+func (c *Client) createGCPVolume(ctx context.Context, vol gcpVolumeRequest, info cvsInfo, clientID string) (gcpVolumeResponse, error) {
+	baseURL, err := c.getCVSAPIRoot(ctx, info.AccountName, vol.WorkingEnvironmentName, clientID)
 	if err != nil {
 		return gcpVolumeResponse{}, err
 	}
 	baseURL = fmt.Sprintf("%s/locations/%s/volumes", baseURL, vol.Region)
 	hostType := "CVSHost"
 	param := structs.Map(vol)
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, param, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "POST", baseURL, param, true, hostType, clientID)
 	if err != nil {
 		log.Print("createGCPVolume request failed ", statusCode)
 		return gcpVolumeResponse{}, err
 	}
-	responseError := apiResponseChecker(statusCode, response, "createGCPVolume")
-	if responseError != nil {
-		return gcpVolumeResponse{}, responseError
-	}
 	var result gcpVolumeResponse
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from createGCPVolume", err)
@@ -143,42 +140,33 @@ func (c *Client) createGCPVolume(vol gcpVolumeRequest, info cvsInfo, clientID st
 	return result, nil
 }
 
-func (c *Client) deleteGCPVolume(vol gcpVolumeRequest, info cvsInfo, clientID string) error {
-	baseURL, err := c.getCVSAPIRoot(info.AccountName, vol.WorkingEnvironmentName, clientID)
+func (c *Client) deleteGCPVolume(ctx context.Context, vol gcpVolumeRequest, info cvsInfo, clientID string) error {
+	baseURL, err := c.getCVSAPIRoot(ctx, info.AccountName, vol.WorkingEnvironmentName, clientID)
 	if err != nil {
 		return err
 	}
 	baseURL = fmt.Sprintf("%s/locations/%s/volumes/%s", baseURL, vol.Region, vol.VolumeID)
 	hostType := "CVSHost"
-	statusCode, response, _, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("deleteGCPVolume request failed ", statusCode)
 		return err
 	}
-	responseError := apiResponseChecker(statusCode, response, "deleteGCPVolume")
-	if responseError != nil {
-		return responseError
-	}
 	return nil
 }
 
-func (c *Client) getGCPVolume(vol gcpVolumeRequest, info cvsInfo, clientID string) (gcpVolumeResponse, error) {
-	baseURL, err := c.getCVSAPIRoot(info.AccountName, vol.WorkingEnvironmentName, clientID)
+func (c *Client) getGCPVolume(ctx context.Context, vol gcpVolumeRequest, info cvsInfo, clientID string) (gcpVolumeResponse, error) {
+	baseURL, err := c.getCVSAPIRoot(ctx, info.AccountName, vol.WorkingEnvironmentName, clientID)
 	if err != nil {
 		return gcpVolumeResponse{}, err
 	}
 	baseURL = fmt.Sprintf("%s/locations/%s/volumes/%s", baseURL, vol.Region, vol.VolumeID)
 	hostType := "CVSHost"
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getGCPVolume request failed ", statusCode)
 		return gcpVolumeResponse{}, err
 	}
-	responseError := apiResponseChecker(statusCode, response, "getGCPVolume")
-	if responseError != nil {
-		return gcpVolumeResponse{}, responseError
-	}
-
 	var result gcpVolumeResponse
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getGCPVolume ", err)
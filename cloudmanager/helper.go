@@ -1,14 +1,20 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/fatih/structs"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/netapp/terraform-provider-netapp-cloudmanager/cloudmanager/cloudmanager/restapi"
 )
 
 // only list what is needed
@@ -232,19 +238,7 @@ type policySchedule struct {
 	Retention int    `json:"retention"`
 }
 
-// Check HTTP response code, return error if HTTP request is not successed.
-func apiResponseChecker(statusCode int, response []byte, funcName string) error {
-
-	if statusCode >= 300 || statusCode < 200 {
-		log.Printf("%s request failed: %v", funcName, string(response))
-		return fmt.Errorf("code: %d, message: %s", statusCode, string(response))
-	}
-
-	return nil
-
-}
-
-func (c *Client) checkTaskStatus(id string, clientID string) (int, string, error) {
+func (c *Client) checkTaskStatus(ctx context.Context, id string, clientID string) (int, string, error) {
 
 	log.Printf("checkTaskStatus: %s", id)
 
@@ -252,36 +246,12 @@ func (c *Client) checkTaskStatus(id string, clientID string) (int, string, error
 
 	hostType := "CloudManagerHost"
 
-	var statusCode int
-	var response []byte
-	networkRetries := 6
-	for {
-		code, result, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
-		if err != nil || code == 504 {
-			if networkRetries > 0 {
-				if code == 504 {
-					time.Sleep(15 * time.Second)
-				} else {
-					time.Sleep(1 * time.Second)
-				}
-				networkRetries--
-				log.Printf("checkTaskStatus id=%s code=%v error=%v Retries %v client=%s", id, code, err, networkRetries, clientID)
-			} else {
-				log.Printf("checkTaskStatus request failed after 3 retries: %v, %v", code, err)
-				return 0, "", err
-			}
-		} else {
-			log.Printf("checkTaskStatus get request %s response code %v clientID %s", id, code, clientID)
-			statusCode = code
-			response = result
-			break
-		}
-	}
-
-	responseError := apiResponseChecker(statusCode, response, "checkTaskStatus")
-	if responseError != nil {
-		return 0, "", responseError
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Printf("checkTaskStatus request failed: %v, %v", statusCode, err)
+		return 0, "", err
 	}
+	log.Printf("checkTaskStatus get request %s response code %v clientID %s", id, statusCode, clientID)
 
 	var result cvoStatusResult
 	if err := json.Unmarshal(response, &result); err != nil {
@@ -292,68 +262,108 @@ func (c *Client) checkTaskStatus(id string, clientID string) (int, string, error
 	return result.Status, result.Error, nil
 }
 
-func (c *Client) waitOnCompletion(id string, actionName string, task string, retries int, waitInterval int, clientID string) error {
+// sleep for the given duration, returning early with the context error if ctx is done
+func sleepWithContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// errNotFound is wrapped by the lookups which don't find the requested object
+var errNotFound = errors.New("doesn't exist")
+
+// isNotFound reports whether err means the object no longer exists, either from a lookup or a 404 from the API
+func isNotFound(err error) bool {
+	return errors.Is(err, errNotFound) || restapi.IsStatus(err, http.StatusNotFound)
+}
+
+// removeFromState clears the ID of a resource whose object no longer exists, so that Terraform plans to create it again
+func removeFromState(d *schema.ResourceData, kind string) diag.Diagnostics {
+	log.Printf("[WARN] %s %s not found, removing it from state", kind, d.Id())
+	d.SetId("")
+	return nil
+}
+
+// attributeErrorf returns an error diagnostic pointing at the given top level attribute of the configuration
+func attributeErrorf(attribute string, format string, a ...interface{}) diag.Diagnostics {
+	return diag.Diagnostics{{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf(format, a...),
+		AttributePath: cty.GetAttrPath(attribute),
+	}}
+}
+
+// default value of the deprecated retries option of the CVO resources
+const cvoDefaultRetries = 60
+
+// timeout to create a CVO. The deprecated retries option (one retry per minute, 30 more for HA)
+// still extends the create timeout when it has been raised from its default value.
+func cvoCreateTimeout(d *schema.ResourceData) time.Duration {
+	timeout := d.Timeout(schema.TimeoutCreate)
+	retries := d.Get("retries").(int)
+	if retries <= cvoDefaultRetries {
+		return timeout
+	}
+	if d.Get("is_ha").(bool) {
+		retries += 30
+	}
+	if legacyTimeout := time.Duration(retries) * time.Minute; legacyTimeout > timeout {
+		return legacyTimeout
+	}
+	return timeout
+}
+
+// convert an error caused by the deadline of ctx while polling into a timeout error
+func pollTimeoutError(ctx context.Context, err error, actionName string, task string) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		log.Print("Taking too long to ", task, actionName)
+		return fmt.Errorf("taking too long for %s to %s or not properly setup, timeout reached", actionName, task)
+	}
+	return err
+}
+
+// poll the task until it completes or fails, giving up when the deadline of ctx is reached
+func (c *Client) waitOnCompletion(ctx context.Context, id string, actionName string, task string, waitInterval int, clientID string) error {
 	for {
-		cvoStatus, failureErrorMessage, err := c.checkTaskStatus(id, clientID)
+		cvoStatus, failureErrorMessage, err := c.checkTaskStatus(ctx, id, clientID)
 		if err != nil {
-			return err
+			return pollTimeoutError(ctx, err, actionName, task)
 		}
 		if cvoStatus == 1 {
 			return nil
 		} else if cvoStatus == -1 {
 			return fmt.Errorf("failed to %s %s, error: %s", task, actionName, failureErrorMessage)
-		} else if cvoStatus == 0 {
-			if retries == 0 {
-				log.Print("Taking too long to ", task, actionName)
-				return fmt.Errorf("taking too long for %s to %s or not properly setup", actionName, task)
-			}
-			log.Printf("Sleep for %d seconds", waitInterval)
-			time.Sleep(time.Duration(waitInterval) * time.Second)
-			retries--
 		}
-
+		log.Printf("Sleep for %d seconds", waitInterval)
+		if err := sleepWithContext(ctx, time.Duration(waitInterval)*time.Second); err != nil {
+			return pollTimeoutError(ctx, err, actionName, task)
+		}
 	}
 }
 
 // get working environment information by working environment id
 // response: publicId, name, isHA, providerName, workingEnvironmentType, ...
-func (c *Client) getWorkingEnvironmentInfo(id string, clientID string) (workingEnvironmentInfo, error) {
+func (c *Client) getWorkingEnvironmentInfo(ctx context.Context, id string, clientID string) (workingEnvironmentInfo, error) {
 	baseURL := fmt.Sprintf("/occm/api/ontaps/working-environments/%s", id)
 	hostType := "CloudManagerHost"
 
-	if c.Token == "" {
-		accesTokenResult, err := c.getAccessToken()
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			return workingEnvironmentInfo{}, err
 		}
-		c.Token = accesTokenResult.Token
-	}
-	var statusCode int
-	var response []byte
-	networkRetries := 3
-	for {
-		log.Print("Call API ", baseURL)
-		code, result, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
-		if err != nil {
-			if networkRetries > 0 {
-				time.Sleep(1 * time.Second)
-				networkRetries--
-			} else {
-				log.Printf("getWorkingEnvironmentInfo: ID %s request failed. Err: %v", id, err)
-				return workingEnvironmentInfo{}, err
-			}
-		} else {
-			statusCode = code
-			response = result
-			break
-		}
 	}
-	responseError := apiResponseChecker(statusCode, response, "getWorkingEnvironmentInfo")
-	if responseError != nil {
-		log.Printf("apiResponseChecker error %v", responseError)
-		return workingEnvironmentInfo{}, responseError
+	log.Print("Call API ", baseURL)
+	_, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Printf("getWorkingEnvironmentInfo: ID %s request failed. Err: %v", id, err)
+		return workingEnvironmentInfo{}, err
 	}
-
 	var result workingEnvironmentInfo
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getWorkingEnvironmentInfo ", err)
@@ -372,7 +382,7 @@ func findWE(name string, weList []workingEnvironmentInfo) (workingEnvironmentInf
 			return weList[i], nil
 		}
 	}
-	return workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment %s in the list", name)
+	return workingEnvironmentInfo{}, fmt.Errorf("working environment %s: %w", name, errNotFound)
 }
 
 func findWEForID(id string, weList []workingEnvironmentInfo) (workingEnvironmentInfo, error) {
@@ -383,45 +393,34 @@ func findWEForID(id string, weList []workingEnvironmentInfo) (workingEnvironment
 			return weList[i], nil
 		}
 	}
-	return workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment %s in the list", id)
+	return workingEnvironmentInfo{}, fmt.Errorf("working environment %s: %w", id, errNotFound)
 }
 
-func (c *Client) findWorkingEnvironmentByName(name string, clientID string) (workingEnvironmentInfo, error) {
+func (c *Client) findWorkingEnvironmentByName(ctx context.Context, name string, clientID string) (workingEnvironmentInfo, error) {
 	// check working environment exists or not
 	baseURL := fmt.Sprintf("/occm/api/working-environments/exists/%s", name)
 	hostType := "CloudManagerHost"
 
-	if c.Token == "" {
-		accesTokenResult, err := c.getAccessToken()
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			return workingEnvironmentInfo{}, err
 		}
-		c.Token = accesTokenResult.Token
 	}
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("findWorkingEnvironmentByName request failed. (check exists) ", statusCode)
 		return workingEnvironmentInfo{}, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "findWorkingEnvironmentByName")
-	if responseError != nil {
-		return workingEnvironmentInfo{}, responseError
-	}
-
 	// get working environment information
 	baseURL = "/occm/api/working-environments"
-	statusCode, response, _, err = c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err = c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Printf("findWorkingEnvironmentByName %s request failed (%d)", name, statusCode)
 		return workingEnvironmentInfo{}, err
 	}
 
-	responseError = apiResponseChecker(statusCode, response, "findWorkingEnvironmentByName")
-	if responseError != nil {
-		return workingEnvironmentInfo{}, responseError
-	}
-
 	var workingEnvironments workingEnvironmentResult
 	if err := json.Unmarshal(response, &workingEnvironments); err != nil {
 		log.Print("Failed to unmarshall response from findWorkingEnvironmentByName")
@@ -452,42 +451,36 @@ func (c *Client) findWorkingEnvironmentByName(name string, clientID string) (wor
 }
 
 // get WE directly from REST API using a given ID
-func (c *Client) findWorkingEnvironmentByID(id string, clientID string) (workingEnvironmentInfo, error) {
+func (c *Client) findWorkingEnvironmentByID(ctx context.Context, id string, clientID string) (workingEnvironmentInfo, error) {
 
-	workingEnvInfo, err := c.getWorkingEnvironmentInfo(id, clientID)
+	workingEnvInfo, err := c.getWorkingEnvironmentInfo(ctx, id, clientID)
 	if err != nil {
-		return workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by working_environment_id %s", id)
+		return workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by working_environment_id %s: %w", id, err)
 	}
-	workingEnvDetail, err := c.findWorkingEnvironmentByName(workingEnvInfo.Name, clientID)
+	workingEnvDetail, err := c.findWorkingEnvironmentByName(ctx, workingEnvInfo.Name, clientID)
 	if err != nil {
-		return workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by working_environment_name %s", workingEnvInfo.Name)
+		return workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by working_environment_name %s: %w", workingEnvInfo.Name, err)
 	}
 	return workingEnvDetail, nil
 }
 
-func (c *Client) getFSXWorkingEnvironmentInfo(tenantID string, id string, clientID string) (workingEnvironmentInfo, error) {
+func (c *Client) getFSXWorkingEnvironmentInfo(ctx context.Context, tenantID string, id string, clientID string) (workingEnvironmentInfo, error) {
 	baseURL := fmt.Sprintf("/fsx-ontap/working-environments/%s/%s", tenantID, id)
 	hostType := "CloudManagerHost"
 	var result workingEnvironmentInfo
 
-	if c.Token == "" {
-		accesTokenResult, err := c.getAccessToken()
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			return workingEnvironmentInfo{}, err
 		}
-		c.Token = accesTokenResult.Token
 	}
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Printf("getFSXWorkingEnvironmentInfo %s request failed (%d)", id, statusCode)
 		log.Printf("error: %#v", err)
 		return workingEnvironmentInfo{}, err
 	}
-	responseError := apiResponseChecker(statusCode, response, "getFSXWorkingEnvironmentInfo")
-	if responseError != nil {
-		return workingEnvironmentInfo{}, responseError
-	}
-
 	var system map[string]interface{}
 	if err := json.Unmarshal(response, &system); err != nil {
 		log.Print("Failed to unmarshall response from getFSXWorkingEnvironmentInfo ", err)
@@ -496,15 +489,11 @@ func (c *Client) getFSXWorkingEnvironmentInfo(tenantID string, id string, client
 	result.Name = system["name"].(string)
 
 	baseURL = fmt.Sprintf("/occm/api/fsx/working-environments/%s/svms", id)
-	statusCode, response, _, err = c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err = c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Printf("getFSXWorkingEnvironmentInfo %s request failed (%d)", id, statusCode)
 		return workingEnvironmentInfo{}, err
 	}
-	responseError = apiResponseChecker(statusCode, response, "getFSXWorkingEnvironmentInfo")
-	if responseError != nil {
-		return workingEnvironmentInfo{}, responseError
-	}
 	var info []map[string]interface{}
 	if err := json.Unmarshal(response, &info); err != nil {
 		log.Print("Failed to unmarshall response from getWorkingEnvironmentInfo ", err)
@@ -516,15 +505,14 @@ func (c *Client) getFSXWorkingEnvironmentInfo(tenantID string, id string, client
 	return result, nil
 }
 
-func (c *Client) getAPIRoot(workingEnvironmentID string, clientID string) (string, string, error) {
+func (c *Client) getAPIRoot(ctx context.Context, workingEnvironmentID string, clientID string) (string, string, error) {
 
-	if c.Token == "" {
-		accesTokenResult, err := c.getAccessToken()
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			log.Print("Not able to get the access token.")
 			return "", "", err
 		}
-		c.Token = accesTokenResult.Token
 	}
 
 	// fsx working environment starts with "fs-" prefix.
@@ -535,7 +523,7 @@ func (c *Client) getAPIRoot(workingEnvironmentID string, clientID string) (strin
 	if strings.HasPrefix(workingEnvironmentID, "OnPrem") {
 		return "/occm/api/onprem", "", nil
 	}
-	workingEnvDetail, err := c.getWorkingEnvironmentInfo(workingEnvironmentID, clientID)
+	workingEnvDetail, err := c.getWorkingEnvironmentInfo(ctx, workingEnvironmentID, clientID)
 	if err != nil {
 		log.Print("Cannot get working environment information.")
 		return "", "", err
@@ -583,28 +571,28 @@ func getAPIRootForWorkingEnvironment(isHA bool, workingEnvironmentID string) str
 }
 
 // read working environemnt information and return the details
-func (c *Client) getWorkingEnvironmentDetail(d *schema.ResourceData, clientID string) (workingEnvironmentInfo, error) {
+func (c *Client) getWorkingEnvironmentDetail(ctx context.Context, d *schema.ResourceData, clientID string) (workingEnvironmentInfo, error) {
 	var workingEnvDetail workingEnvironmentInfo
 	var err error
 
 	if a, ok := d.GetOk("file_system_id"); ok {
-		workingEnvDetail, err = c.getFSXWorkingEnvironmentInfo(d.Get("tenant_id").(string), a.(string), clientID)
+		workingEnvDetail, err = c.getFSXWorkingEnvironmentInfo(ctx, d.Get("tenant_id").(string), a.(string), clientID)
 		if err != nil {
-			return workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by working_environment_id %s", a.(string))
+			return workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by working_environment_id %s: %w", a.(string), err)
 		}
 		return workingEnvDetail, nil
 	}
 
 	if a, ok := d.GetOk("working_environment_id"); ok {
 		WorkingEnvironmentID := a.(string)
-		workingEnvDetail, err = c.findWorkingEnvironmentByID(WorkingEnvironmentID, clientID)
+		workingEnvDetail, err = c.findWorkingEnvironmentByID(ctx, WorkingEnvironmentID, clientID)
 		if err != nil {
-			return workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by working_environment_id %s", WorkingEnvironmentID)
+			return workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by working_environment_id %s: %w", WorkingEnvironmentID, err)
 		}
 	} else if a, ok = d.GetOk("working_environment_name"); ok {
-		workingEnvDetail, err = c.findWorkingEnvironmentByName(a.(string), clientID)
+		workingEnvDetail, err = c.findWorkingEnvironmentByName(ctx, a.(string), clientID)
 		if err != nil {
-			return workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by working_environment_name %s", a.(string))
+			return workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by working_environment_name %s: %w", a.(string), err)
 		}
 		log.Printf("Get environment id %v by %v", workingEnvDetail.PublicID, a.(string))
 	} else {
@@ -613,7 +601,7 @@ func (c *Client) getWorkingEnvironmentDetail(d *schema.ResourceData, clientID st
 	return workingEnvDetail, nil
 }
 
-func (c *Client) getFSXSVM(id string, clientID string) (string, error) {
+func (c *Client) getFSXSVM(ctx context.Context, id string, clientID string) (string, error) {
 
 	log.Print("getFSXSVM")
 
@@ -621,17 +609,12 @@ func (c *Client) getFSXSVM(id string, clientID string) (string, error) {
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getFSXSVM request failed ", statusCode)
 		return "", err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "getFSXSVM")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	var result []fsxSVMResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getFSXSVM ", err)
@@ -645,32 +628,26 @@ func (c *Client) getFSXSVM(id string, clientID string) (string, error) {
 	return result[0].Name, nil
 }
 
-func (c *Client) getAWSFSXByName(name string, tenantID string, clientID string) (string, error) {
+func (c *Client) getAWSFSXByName(ctx context.Context, name string, tenantID string, clientID string) (string, error) {
 
 	log.Print("getAWSFSXByName")
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in getAWSFSXByName request, failed to get AccessToken")
 		return "", err
 	}
-	c.Token = accessTokenResult.Token
 
 	baseURL := fmt.Sprintf("/fsx-ontap/working-environments/%s", tenantID)
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getAWSFSXByName request failed ", statusCode, err)
 		return "", err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "getAWSFSXByName")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	var result []fsxResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getAWSFSXByName ", err)
@@ -687,7 +664,7 @@ func (c *Client) getAWSFSXByName(name string, tenantID string, clientID string)
 }
 
 // read working environemnt information and return the details
-func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData, clientID string) (workingEnvironmentInfo, workingEnvironmentInfo, error) {
+func (c *Client) getWorkingEnvironmentDetailForSnapMirror(ctx context.Context, d *schema.ResourceData, clientID string) (workingEnvironmentInfo, workingEnvironmentInfo, error) {
 	var sourceWorkingEnvDetail workingEnvironmentInfo
 	var destWorkingEnvDetail workingEnvironmentInfo
 	var err error
@@ -698,7 +675,7 @@ func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData
 		if strings.HasPrefix(WorkingEnvironmentID, "fs-") {
 			if b, ok := d.GetOk("tenant_id"); ok {
 				tenantID := b.(string)
-				id, err := c.getAWSFSX(WorkingEnvironmentID, tenantID)
+				id, err := c.getAWSFSX(ctx, WorkingEnvironmentID, tenantID)
 				if err != nil {
 					log.Print("Error getting AWS FSX")
 					return workingEnvironmentInfo{}, workingEnvironmentInfo{}, err
@@ -709,7 +686,7 @@ func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData
 					return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("could not find source working environment ID %v", WorkingEnvironmentID)
 				}
 				sourceWorkingEnvDetail.PublicID = WorkingEnvironmentID
-				svmName, err := c.getFSXSVM(WorkingEnvironmentID, clientID)
+				svmName, err := c.getFSXSVM(ctx, WorkingEnvironmentID, clientID)
 				if err != nil {
 					return workingEnvironmentInfo{}, workingEnvironmentInfo{}, err
 				}
@@ -718,25 +695,25 @@ func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData
 				return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("cannot find FSX working environment by destination_working_environment_id %s, need tenant_id", WorkingEnvironmentID)
 			}
 		} else {
-			sourceWorkingEnvDetail, err = c.findWorkingEnvironmentForID(WorkingEnvironmentID, clientID)
+			sourceWorkingEnvDetail, err = c.findWorkingEnvironmentForID(ctx, WorkingEnvironmentID, clientID)
 			if err != nil {
-				return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by source_working_environment_id %s", WorkingEnvironmentID)
+				return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by source_working_environment_id %s: %w", WorkingEnvironmentID, err)
 			}
 		}
 	} else if a, ok = d.GetOk("source_working_environment_name"); ok {
-		sourceWorkingEnvDetail, err = c.findWorkingEnvironmentByName(a.(string), clientID)
+		sourceWorkingEnvDetail, err = c.findWorkingEnvironmentByName(ctx, a.(string), clientID)
 		if sourceWorkingEnvDetail.PublicID == "" {
 			if b, ok := d.GetOk("tenant_id"); ok {
 				workingEnvironmentName := a.(string)
 				tenantID := b.(string)
-				WorkingEnvironmentID, err := c.getAWSFSXByName(workingEnvironmentName, tenantID, clientID)
+				WorkingEnvironmentID, err := c.getAWSFSXByName(ctx, workingEnvironmentName, tenantID, clientID)
 				if err != nil {
 					log.Print("Error getting AWS FSX: ", err)
 					return workingEnvironmentInfo{}, workingEnvironmentInfo{}, err
 				}
 				sourceWorkingEnvDetail.PublicID = WorkingEnvironmentID
 				if sourceWorkingEnvDetail.PublicID != "" {
-					svmName, err := c.getFSXSVM(WorkingEnvironmentID, clientID)
+					svmName, err := c.getFSXSVM(ctx, WorkingEnvironmentID, clientID)
 					if err != nil {
 						return workingEnvironmentInfo{}, workingEnvironmentInfo{}, err
 					}
@@ -745,7 +722,7 @@ func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData
 			}
 		}
 		if err != nil && sourceWorkingEnvDetail.PublicID == "" {
-			return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by source_working_environment_name %s", a.(string))
+			return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by source_working_environment_name %s: %w", a.(string), err)
 		}
 		log.Printf("Get environment id %v by %v", sourceWorkingEnvDetail.PublicID, a.(string))
 	} else {
@@ -758,7 +735,7 @@ func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData
 		if strings.HasPrefix(WorkingEnvironmentID, "fs-") {
 			if b, ok := d.GetOk("tenant_id"); ok {
 				tenantID := b.(string)
-				id, err := c.getAWSFSX(WorkingEnvironmentID, tenantID)
+				id, err := c.getAWSFSX(ctx, WorkingEnvironmentID, tenantID)
 				if err != nil {
 					log.Print("Error getting AWS FSX")
 					return workingEnvironmentInfo{}, workingEnvironmentInfo{}, err
@@ -768,7 +745,7 @@ func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData
 					return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("could not find destination working environment ID %v", WorkingEnvironmentID)
 				}
 				destWorkingEnvDetail.PublicID = WorkingEnvironmentID
-				svmName, err := c.getFSXSVM(WorkingEnvironmentID, clientID)
+				svmName, err := c.getFSXSVM(ctx, WorkingEnvironmentID, clientID)
 				if err != nil {
 					return workingEnvironmentInfo{}, workingEnvironmentInfo{}, err
 				}
@@ -777,27 +754,27 @@ func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData
 				return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("cannot find FSX working environment by destination_working_environment_id %s, need tenant_id", WorkingEnvironmentID)
 			}
 		} else {
-			destWorkingEnvDetail, err = c.findWorkingEnvironmentForID(WorkingEnvironmentID, clientID)
+			destWorkingEnvDetail, err = c.findWorkingEnvironmentForID(ctx, WorkingEnvironmentID, clientID)
 			if err != nil {
-				return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by destination_working_environment_id %s", WorkingEnvironmentID)
+				return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by destination_working_environment_id %s: %w", WorkingEnvironmentID, err)
 			}
 			log.Print("findWorkingEnvironmentForID", destWorkingEnvDetail)
 		}
 	} else if a, ok = d.GetOk("destination_working_environment_name"); ok {
-		destWorkingEnvDetail, err = c.findWorkingEnvironmentByName(a.(string), clientID)
+		destWorkingEnvDetail, err = c.findWorkingEnvironmentByName(ctx, a.(string), clientID)
 		log.Printf("Get environment id %v by %v", destWorkingEnvDetail.PublicID, a.(string))
 		if destWorkingEnvDetail.PublicID == "" {
 			if b, ok := d.GetOk("tenant_id"); ok {
 				workingEnvironmentName := a.(string)
 				tenantID := b.(string)
-				WorkingEnvironmentID, err := c.getAWSFSXByName(workingEnvironmentName, tenantID, clientID)
+				WorkingEnvironmentID, err := c.getAWSFSXByName(ctx, workingEnvironmentName, tenantID, clientID)
 				if err != nil {
 					log.Print("Error getting AWS FSX: ", err)
 					return workingEnvironmentInfo{}, workingEnvironmentInfo{}, err
 				}
 				if destWorkingEnvDetail.PublicID != "" {
 					destWorkingEnvDetail.PublicID = WorkingEnvironmentID
-					svmName, err := c.getFSXSVM(WorkingEnvironmentID, clientID)
+					svmName, err := c.getFSXSVM(ctx, WorkingEnvironmentID, clientID)
 					if err != nil {
 						return workingEnvironmentInfo{}, workingEnvironmentInfo{}, err
 					}
@@ -806,7 +783,7 @@ func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData
 			}
 		}
 		if err != nil && destWorkingEnvDetail.PublicID == "" {
-			return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by destination_working_environment_name %s", a.(string))
+			return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by destination_working_environment_name %s: %w", a.(string), err)
 		}
 	} else {
 		return workingEnvironmentInfo{}, workingEnvironmentInfo{}, fmt.Errorf("cannot find working environment by destination_working_environment_id or destination_working_environment_name")
@@ -815,28 +792,22 @@ func (c *Client) getWorkingEnvironmentDetailForSnapMirror(d *schema.ResourceData
 }
 
 // get all WE from REST API and then using a given ID get the WE
-func (c *Client) findWorkingEnvironmentForID(id string, clientID string) (workingEnvironmentInfo, error) {
+func (c *Client) findWorkingEnvironmentForID(ctx context.Context, id string, clientID string) (workingEnvironmentInfo, error) {
 	hostType := "CloudManagerHost"
 
-	if c.Token == "" {
-		accesTokenResult, err := c.getAccessToken()
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			return workingEnvironmentInfo{}, err
 		}
-		c.Token = accesTokenResult.Token
 	}
 	baseURL := "/occm/api/working-environments"
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Printf("findWorkingEnvironmentForId %s request failed (%d)", id, statusCode)
 		return workingEnvironmentInfo{}, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "findWorkingEnvironmentForId")
-	if responseError != nil {
-		return workingEnvironmentInfo{}, responseError
-	}
-
 	var workingEnvironments workingEnvironmentResult
 	if err := json.Unmarshal(response, &workingEnvironments); err != nil {
 		log.Print("Failed to unmarshall response from findWorkingEnvironmentForId")
@@ -867,35 +838,16 @@ func (c *Client) findWorkingEnvironmentForID(id string, clientID string) (workin
 }
 
 // get working environment properties
-func (c *Client) getWorkingEnvironmentProperties(apiRoot string, id string, field string, clientID string) (workingEnvironmentOntapClusterPropertiesResponse, error) {
+func (c *Client) getWorkingEnvironmentProperties(ctx context.Context, apiRoot string, id string, field string, clientID string) (workingEnvironmentOntapClusterPropertiesResponse, error) {
 	hostType := "CloudManagerHost"
 	baseURL := fmt.Sprintf("%s/working-environments/%s?fields=%s", apiRoot, id, field)
 	log.Printf("Call %s", baseURL)
 
-	var statusCode int
-	var response []byte
-	networkRetries := 3
-	for {
-		code, result, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
-		if err != nil {
-			if networkRetries > 0 {
-				time.Sleep(1 * time.Second)
-				networkRetries--
-			} else {
-				log.Printf("getWorkingEnvironmentProperties %s request failed (%d) %s", baseURL, statusCode, err)
-				return workingEnvironmentOntapClusterPropertiesResponse{}, err
-			}
-		} else {
-			statusCode = code
-			response = result
-			break
-		}
-	}
-	responseError := apiResponseChecker(statusCode, response, "getWorkingEnvironmentProperties")
-	if responseError != nil {
-		return workingEnvironmentOntapClusterPropertiesResponse{}, responseError
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Printf("getWorkingEnvironmentProperties %s request failed (%d) %s", baseURL, statusCode, err)
+		return workingEnvironmentOntapClusterPropertiesResponse{}, err
 	}
-
 	var result workingEnvironmentOntapClusterPropertiesResponse
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getWorkingEnvironmentProperties ", err)
@@ -951,8 +903,8 @@ func expandUserTags(set *schema.Set) []userTags {
 	return tags
 }
 
-func (c *Client) callCMUpdateAPI(method string, request interface{}, baseURL string, id string, functionName string, clientID string) error {
-	apiRoot, _, err := c.getAPIRoot(id, clientID)
+func (c *Client) callCMUpdateAPI(ctx context.Context, method string, request interface{}, baseURL string, id string, functionName string, clientID string) error {
+	apiRoot, _, err := c.getAPIRoot(ctx, id, clientID)
 	if err != nil {
 		return err
 	}
@@ -961,31 +913,26 @@ func (c *Client) callCMUpdateAPI(method string, request interface{}, baseURL str
 	hostType := "CloudManagerHost"
 	params := structs.Map(request)
 
-	if c.Token == "" {
-		accessTokenResult, err := c.getAccessToken()
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			log.Printf("in %s request, failed to get AccessToken", functionName)
 			return err
 		}
-		c.Token = accessTokenResult.Token
 	}
 
-	statusCode, response, _, err := c.CallAPIMethod(method, baseURL, params, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, method, baseURL, params, true, hostType, clientID)
 	if err != nil {
 		log.Printf("%s request failed: %d", functionName, statusCode)
 		log.Print("call api response: ", response)
 		return err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, functionName)
-	if responseError != nil {
-		return responseError
-	}
 	return nil
 }
 
 // modify CVO SVM name
-func (c *Client) updateCVOSVMName(d *schema.ResourceData, clientID string, svmName string, svmNewName string) error {
+func (c *Client) updateCVOSVMName(ctx context.Context, d *schema.ResourceData, clientID string, svmName string, svmNewName string) error {
 	var request svmNameModificationRequest
 	// Update svm name
 	id := d.Id()
@@ -993,7 +940,7 @@ func (c *Client) updateCVOSVMName(d *schema.ResourceData, clientID string, svmNa
 	request.SvmNewName = svmNewName
 	baseURL := fmt.Sprintf("/working-environments/%s/svm", id)
 	log.Printf("Modify %s SVM %s with %s", id, svmName, svmNewName)
-	updateErr := c.callCMUpdateAPI("PUT", request, baseURL, id, "updateCVOSVMName", clientID)
+	updateErr := c.callCMUpdateAPI(ctx, "PUT", request, baseURL, id, "updateCVOSVMName", clientID)
 	if updateErr != nil {
 		return updateErr
 	}
@@ -1002,7 +949,7 @@ func (c *Client) updateCVOSVMName(d *schema.ResourceData, clientID string, svmNa
 }
 
 // update CVO user-tags
-func updateCVOUserTags(d *schema.ResourceData, meta interface{}, tagName string, clientID string) error {
+func updateCVOUserTags(ctx context.Context, d *schema.ResourceData, meta interface{}, tagName string, clientID string) error {
 	client := meta.(*Client)
 	var request modifyUserTagsRequest
 	if c, ok := d.GetOk(tagName); ok {
@@ -1019,7 +966,7 @@ func updateCVOUserTags(d *schema.ResourceData, meta interface{}, tagName string,
 	// Update tags
 	id := d.Id()
 	baseURL := fmt.Sprintf("/working-environments/%s/user-tags", id)
-	updateErr := client.callCMUpdateAPI("PUT", request, baseURL, id, "updateCVOUserTags", clientID)
+	updateErr := client.callCMUpdateAPI(ctx, "PUT", request, baseURL, id, "updateCVOUserTags", clientID)
 	if updateErr != nil {
 		return updateErr
 	}
@@ -1028,14 +975,14 @@ func updateCVOUserTags(d *schema.ResourceData, meta interface{}, tagName string,
 }
 
 // set the cluster password of a specific cloud volumes ONTAP
-func updateCVOSVMPassword(d *schema.ResourceData, meta interface{}, clientID string) error {
+func updateCVOSVMPassword(ctx context.Context, d *schema.ResourceData, meta interface{}, clientID string) error {
 	client := meta.(*Client)
 	var request setPasswordRequest
 	request.Password = d.Get("svm_password").(string)
 	// Update password
 	id := d.Id()
 	baseURL := fmt.Sprintf("/working-environments/%s/set-password", id)
-	updateErr := client.callCMUpdateAPI("PUT", request, baseURL, id, "updateCVOSVMPassword", clientID)
+	updateErr := client.callCMUpdateAPI(ctx, "PUT", request, baseURL, id, "updateCVOSVMPassword", clientID)
 	if updateErr != nil {
 		return updateErr
 	}
@@ -1044,7 +991,7 @@ func updateCVOSVMPassword(d *schema.ResourceData, meta interface{}, clientID str
 }
 
 // update SVMs on GCP CVO HA
-func (c *Client) updateCVOSVMs(d *schema.ResourceData, clientID string) error {
+func (c *Client) updateCVOSVMs(ctx context.Context, d *schema.ResourceData, clientID string) error {
 	id := d.Id()
 	currentSVMs, expectSVMs := d.GetChange("svm")
 	cSVMs := expandGCPSVMs(currentSVMs.(*schema.Set))
@@ -1077,7 +1024,7 @@ func (c *Client) updateCVOSVMs(d *schema.ResourceData, clientID string) error {
 	for svmName := range expectList {
 		if len(currentList) > 0 {
 			// update SVM
-			respErr := c.updateCVOSVMName(d, clientID, currentList[j], svmName)
+			respErr := c.updateCVOSVMName(ctx, d, clientID, currentList[j], svmName)
 			if respErr != nil {
 				return respErr
 			}
@@ -1085,7 +1032,7 @@ func (c *Client) updateCVOSVMs(d *schema.ResourceData, clientID string) error {
 			j++
 		} else {
 			// add SVM
-			respErr := c.addSVMtoCVO(id, clientID, svmName)
+			respErr := c.addSVMtoCVO(ctx, id, clientID, svmName)
 			if respErr != nil {
 				log.Printf("Error adding SVM %v: %v", svmName, respErr)
 				return respErr
@@ -1095,7 +1042,7 @@ func (c *Client) updateCVOSVMs(d *schema.ResourceData, clientID string) error {
 	if len(currentList) > 0 {
 		for _, svmName := range currentList {
 			// delete SVM
-			respErr := c.deleteSVMfromCVO(id, clientID, svmName)
+			respErr := c.deleteSVMfromCVO(ctx, id, clientID, svmName)
 			if respErr != nil {
 				log.Printf("Error deleting SVM %v: %v", svmName, respErr)
 				return respErr
@@ -1105,40 +1052,37 @@ func (c *Client) updateCVOSVMs(d *schema.ResourceData, clientID string) error {
 	return nil
 }
 
-func (c *Client) waitOnCompletionCVOUpdate(id string, retryCount int, waitInterval int, clientID string) error {
+func (c *Client) waitOnCompletionCVOUpdate(ctx context.Context, id string, waitInterval int, clientID string) error {
 	// check upgrade status
 	log.Print("Check CVO update status")
 	// check upgrade status
-	apiRoot, _, err := c.getAPIRoot(id, clientID)
+	apiRoot, _, err := c.getAPIRoot(ctx, id, clientID)
 	if err != nil {
 		return fmt.Errorf("cannot get root API")
 	}
 
 	for {
-		cvoResp, err := c.getWorkingEnvironmentProperties(apiRoot, id, "status,ontapClusterProperties", clientID)
+		cvoResp, err := c.getWorkingEnvironmentProperties(ctx, apiRoot, id, "status,ontapClusterProperties", clientID)
 		if err != nil {
-			return err
+			return pollTimeoutError(ctx, err, "CVO", "update")
 		}
 		if cvoResp.Status.Status != "UPDATING" {
 			log.Print("CVO update is done")
 			return nil
 		}
-		if retryCount <= 0 {
-			log.Print("Taking too long for status to be active")
-			return fmt.Errorf("taking too long for CVO to be active or not properly setup")
+		log.Printf("Update status %s...", cvoResp.Status.Status)
+		if err := sleepWithContext(ctx, time.Duration(waitInterval)*time.Second); err != nil {
+			return pollTimeoutError(ctx, err, "CVO", "update")
 		}
-		log.Printf("Update status %s...(%d)", cvoResp.Status.Status, retryCount)
-		time.Sleep(time.Duration(waitInterval) * time.Second)
-		retryCount--
 	}
 }
 
-func (c *Client) getCVOProperties(id string, clientID string) (workingEnvironmentOntapClusterPropertiesResponse, error) {
-	apiRoot, _, err := c.getAPIRoot(id, clientID)
+func (c *Client) getCVOProperties(ctx context.Context, id string, clientID string) (workingEnvironmentOntapClusterPropertiesResponse, error) {
+	apiRoot, _, err := c.getAPIRoot(ctx, id, clientID)
 	if err != nil {
-		return workingEnvironmentOntapClusterPropertiesResponse{}, fmt.Errorf("cannot get root API")
+		return workingEnvironmentOntapClusterPropertiesResponse{}, fmt.Errorf("cannot get root API: %w", err)
 	}
-	cvoResp, err := c.getWorkingEnvironmentProperties(apiRoot, id, "*", clientID)
+	cvoResp, err := c.getWorkingEnvironmentProperties(ctx, apiRoot, id, "*", clientID)
 	if err != nil {
 		return workingEnvironmentOntapClusterPropertiesResponse{}, err
 	}
@@ -1146,7 +1090,7 @@ func (c *Client) getCVOProperties(id string, clientID string) (workingEnvironmen
 }
 
 // set the license_type and instance type of a specific cloud volumes ONTAP
-func updateCVOLicenseInstanceType(d *schema.ResourceData, meta interface{}, clientID string) error {
+func updateCVOLicenseInstanceType(ctx context.Context, d *schema.ResourceData, meta interface{}, clientID string) error {
 	client := meta.(*Client)
 	var request licenseAndInstanceTypeModificationRequest
 	if c, ok := d.GetOk("instance_type"); ok {
@@ -1159,16 +1103,12 @@ func updateCVOLicenseInstanceType(d *schema.ResourceData, meta interface{}, clie
 	// Update license type and instance type
 	id := d.Id()
 	baseURL := fmt.Sprintf("/working-environments/%s/license-instance-type", id)
-	updateErr := client.callCMUpdateAPI("PUT", request, baseURL, id, "updateCVOLicenseInstanceType", clientID)
+	updateErr := client.callCMUpdateAPI(ctx, "PUT", request, baseURL, id, "updateCVOLicenseInstanceType", clientID)
 	if updateErr != nil {
 		return updateErr
 	}
 	// check upgrade status
-	retryCount := 65
-	if d.Get("is_ha").(bool) {
-		retryCount = retryCount * 2
-	}
-	err := client.waitOnCompletionCVOUpdate(id, retryCount, 60, clientID)
+	err := client.waitOnCompletionCVOUpdate(ctx, id, 60, clientID)
 	if err != nil {
 		return fmt.Errorf("update CVO failed %v", err)
 	}
@@ -1177,7 +1117,7 @@ func updateCVOLicenseInstanceType(d *schema.ResourceData, meta interface{}, clie
 }
 
 // update tier_level of a specific cloud volumes ONTAP
-func updateCVOTierLevel(d *schema.ResourceData, meta interface{}, clientID string) error {
+func updateCVOTierLevel(ctx context.Context, d *schema.ResourceData, meta interface{}, clientID string) error {
 	client := meta.(*Client)
 	var request changeTierLevelRequest
 	if c, ok := d.GetOk("tier_level"); ok {
@@ -1185,7 +1125,7 @@ func updateCVOTierLevel(d *schema.ResourceData, meta interface{}, clientID strin
 	}
 	id := d.Id()
 	baseURL := fmt.Sprintf("/working-environments/%s/change-tier-level", id)
-	updateErr := client.callCMUpdateAPI("POST", request, baseURL, id, "updateCVOTierLevel", clientID)
+	updateErr := client.callCMUpdateAPI(ctx, "POST", request, baseURL, id, "updateCVOTierLevel", clientID)
 	if updateErr != nil {
 		return updateErr
 	}
@@ -1194,7 +1134,7 @@ func updateCVOTierLevel(d *schema.ResourceData, meta interface{}, clientID strin
 }
 
 // update writing_speed_state of a specific CVO
-func updateCVOWritingSpeedState(d *schema.ResourceData, meta interface{}, clientID string) error {
+func updateCVOWritingSpeedState(ctx context.Context, d *schema.ResourceData, meta interface{}, clientID string) error {
 	client := meta.(*Client)
 	var request changeWritingSpeedStateRequest
 	if c, ok := d.GetOk("writing_speed_state"); ok {
@@ -1203,18 +1143,13 @@ func updateCVOWritingSpeedState(d *schema.ResourceData, meta interface{}, client
 	log.Printf("writing_speed_state value %s", request.WritingSpeedState)
 	id := d.Id()
 	baseURL := fmt.Sprintf("/working-environments/%s/writing-speed", id)
-	updateErr := client.callCMUpdateAPI("PUT", request, baseURL, id, "updateCVOWritingSpeedState", clientID)
+	updateErr := client.callCMUpdateAPI(ctx, "PUT", request, baseURL, id, "updateCVOWritingSpeedState", clientID)
 	if updateErr != nil {
 		return updateErr
 	}
 
 	// check upgrade status
-	retryCount := 10
-	if d.Get("is_ha").(bool) {
-		retryCount = retryCount * 2
-	}
-
-	err := client.waitOnCompletionCVOUpdate(id, retryCount, 60, clientID)
+	err := client.waitOnCompletionCVOUpdate(ctx, id, 60, clientID)
 	if err != nil {
 		return fmt.Errorf("update CVO failed %v", err)
 	}
@@ -1222,14 +1157,14 @@ func updateCVOWritingSpeedState(d *schema.ResourceData, meta interface{}, client
 	return nil
 }
 
-func (c *Client) waitOnCompletionOntapImageUpgrade(apiRoot string, id string, targetVersion string, retryCount int, waitInterval int, clientID string) error {
+func (c *Client) waitOnCompletionOntapImageUpgrade(ctx context.Context, apiRoot string, id string, targetVersion string, waitInterval int, clientID string) error {
 	// check upgrade status
 	log.Print("Check CVO ontap image upgrade status")
 
 	for {
-		cvoResp, err := c.getWorkingEnvironmentProperties(apiRoot, id, "status,ontapClusterProperties", clientID)
+		cvoResp, err := c.getWorkingEnvironmentProperties(ctx, apiRoot, id, "status,ontapClusterProperties", clientID)
 		if err != nil {
-			return err
+			return pollTimeoutError(ctx, err, "ONTAP image", "upgrade")
 		}
 		if cvoResp.Status.Status != "UPDATING" && cvoResp.OntapClusterProperties.OntapVersion != "" {
 			if strings.Contains(targetVersion, cvoResp.OntapClusterProperties.OntapVersion) {
@@ -1239,23 +1174,20 @@ func (c *Client) waitOnCompletionOntapImageUpgrade(apiRoot string, id string, ta
 			log.Printf("Update ontap image failed on checking version (%s, %s)", cvoResp.OntapClusterProperties.OntapVersion, targetVersion)
 			return fmt.Errorf("update ontap version failed. Current version %s", cvoResp.OntapClusterProperties.OntapVersion)
 		}
-		if retryCount <= 0 {
-			log.Print("Taking too long for status to be active")
-			return fmt.Errorf("taking too long for CVO to be active or not properly setup")
+		log.Printf("Update %s status %s...", targetVersion, cvoResp.Status.Status)
+		if err := sleepWithContext(ctx, time.Duration(waitInterval)*time.Second); err != nil {
+			return pollTimeoutError(ctx, err, "ONTAP image", "upgrade")
 		}
-		log.Printf("Update %s status %s...(%d)", targetVersion, cvoResp.Status.Status, retryCount)
-		time.Sleep(time.Duration(waitInterval) * time.Second)
-		retryCount--
 	}
 }
 
 // check if ontap_version is the list of upgrade available versions
-func (c *Client) upgradeOntapVersionAvailable(apiRoot string, id string, ontapVersion string, clientID string) (string, error) {
+func (c *Client) upgradeOntapVersionAvailable(ctx context.Context, apiRoot string, id string, ontapVersion string, clientID string) (string, error) {
 	log.Print("upgradeOntapVersionAvailable: Check if target version is in the upgrade version list")
 
 	var upgradeOntapVersions []upgradeVersion
 
-	WEProperties, err := c.getWorkingEnvironmentProperties(apiRoot, id, "ontapClusterProperties.fields(upgradeVersions)", clientID)
+	WEProperties, err := c.getWorkingEnvironmentProperties(ctx, apiRoot, id, "ontapClusterProperties.fields(upgradeVersions)", clientID)
 	if err != nil {
 		return "", fmt.Errorf("upgradeOntapVersionAvailable %s not able to get the properties %v", id, err)
 	}
@@ -1275,37 +1207,32 @@ func (c *Client) upgradeOntapVersionAvailable(apiRoot string, id string, ontapVe
 	return "", fmt.Errorf("working environment %s: no upgrade version availble", id)
 }
 
-func (c *Client) setConfigFlag(request setFlagRequest, keyPath string, clientID string) error {
+func (c *Client) setConfigFlag(ctx context.Context, request setFlagRequest, keyPath string, clientID string) error {
 	log.Print("setConfigFlag: set flag to allow ONTAP image upgrade")
 
 	hostType := "CloudManagerHost"
 
 	baseURL := fmt.Sprintf("/occm/api/occm/config/%s", keyPath)
 	params := structs.Map(request)
-	statusCode, response, _, err := c.CallAPIMethod("PUT", baseURL, params, c.Token, hostType, clientID)
-
-	responseError := apiResponseChecker(statusCode, response, "setUpgradeCheckingBypass")
-	if responseError != nil {
-		return responseError
-	}
-
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "PUT", baseURL, params, true, hostType, clientID)
 	if err != nil {
 		log.Print("setUpgradeCheckingBypass request failed ", statusCode)
 		return err
 	}
+
 	return nil
 
 }
 
 // upgrade CVO ontap version
-func (c *Client) upgradeCVOOntapImage(apiRoot string, id string, ontapVersion string, isHa bool, clientID string) error {
+func (c *Client) upgradeCVOOntapImage(ctx context.Context, apiRoot string, id string, ontapVersion string, isHa bool, clientID string) error {
 	// set config flag to skip the upgrade check
 	var setFlag setFlagRequest
 	setFlag.Value = true
 	setFlag.ValueType = "BOOLEAN"
 
 	log.Printf("Set config flag")
-	setFlagErr := c.setConfigFlag(setFlag, "skip-eligibility-paygo-upgrade", clientID)
+	setFlagErr := c.setConfigFlag(ctx, setFlag, "skip-eligibility-paygo-upgrade", clientID)
 	if setFlagErr != nil {
 		log.Printf("upgradeCVOOntapVersion failed on setConfigFlag call %v", setFlagErr)
 		return setFlagErr
@@ -1318,18 +1245,14 @@ func (c *Client) upgradeCVOOntapImage(apiRoot string, id string, ontapVersion st
 
 	baseURL := fmt.Sprintf("/working-environments/%s/update-image", id)
 	log.Printf("upgradeCVOOntapVersion - %s %v", baseURL, request)
-	updateErr := c.callCMUpdateAPI("POST", request, baseURL, id, "upgradeCVOOntapVersion", clientID)
+	updateErr := c.callCMUpdateAPI(ctx, "POST", request, baseURL, id, "upgradeCVOOntapVersion", clientID)
 	if updateErr != nil {
 		log.Printf("upgradeCVOOntapVersion failed on API call %v", updateErr)
 		return updateErr
 	}
 
 	// check upgrade status
-	retryCount := 65
-	if isHa {
-		retryCount = retryCount * 2
-	}
-	err := c.waitOnCompletionOntapImageUpgrade(apiRoot, id, ontapVersion, retryCount, 60, clientID)
+	err := c.waitOnCompletionOntapImageUpgrade(ctx, apiRoot, id, ontapVersion, 60, clientID)
 	if err != nil {
 		return fmt.Errorf("upgrade ontap image %s failed %v", ontapVersion, err)
 	}
@@ -1337,20 +1260,20 @@ func (c *Client) upgradeCVOOntapImage(apiRoot string, id string, ontapVersion st
 	return nil
 }
 
-func (c *Client) doUpgradeCVOOntapVersion(id string, isHA bool, ontapVersion string, clientID string) error {
+func (c *Client) doUpgradeCVOOntapVersion(ctx context.Context, id string, isHA bool, ontapVersion string, clientID string) error {
 	// only when the upgrade_ontap_version is true, use_latest_version is false and the ontap_version is not "latest"
 	log.Print("Check CVO ontap image upgrade status ... ")
-	apiRoot, _, err := c.getAPIRoot(id, clientID)
+	apiRoot, _, err := c.getAPIRoot(ctx, id, clientID)
 	if err != nil {
 		return fmt.Errorf("cannot get root API")
 	}
 
-	upgradeVersion, err := c.upgradeOntapVersionAvailable(apiRoot, id, ontapVersion, clientID)
+	upgradeVersion, err := c.upgradeOntapVersionAvailable(ctx, apiRoot, id, ontapVersion, clientID)
 	if err != nil {
 		return err
 	}
 
-	return c.upgradeCVOOntapImage(apiRoot, id, upgradeVersion, isHA, clientID)
+	return c.upgradeCVOOntapImage(ctx, apiRoot, id, upgradeVersion, isHA, clientID)
 }
 
 func checkOntapVersionChangeWithoutUpgrade(d *schema.ResourceData) error {
@@ -1372,7 +1295,7 @@ func checkOntapVersionChangeWithoutUpgrade(d *schema.ResourceData) error {
 	return nil
 }
 
-func (c *Client) checkAndDoUpgradeOntapVersion(d *schema.ResourceData, clientID string) error {
+func (c *Client) checkAndDoUpgradeOntapVersion(ctx context.Context, d *schema.ResourceData, clientID string) error {
 	upgradeOntapVersion := d.Get("upgrade_ontap_version").(bool)
 	if upgradeOntapVersion {
 		ontapVersion := d.Get("ontap_version").(string)
@@ -1385,7 +1308,7 @@ func (c *Client) checkAndDoUpgradeOntapVersion(d *schema.ResourceData, clientID
 			return fmt.Errorf("ontap_version cannot be upgraded with \"use_latest_version\" true")
 		}
 		id := d.Id()
-		respErr := c.doUpgradeCVOOntapVersion(id, d.Get("is_ha").(bool), ontapVersion, clientID)
+		respErr := c.doUpgradeCVOOntapVersion(ctx, id, d.Get("is_ha").(bool), ontapVersion, clientID)
 		if respErr != nil {
 			currentVersion, _ := d.GetChange("ontap_version")
 			d.Set("ontap_version", currentVersion)
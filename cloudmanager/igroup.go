@@ -0,0 +1,122 @@
+package cloudmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+type igroup struct {
+	IgroupName             string   `json:"igroupName"`
+	OsType                 string   `json:"osType"`
+	PortsetName            string   `json:"portsetName"`
+	IgroupType             string   `json:"igroupType"`
+	Initiators             []string `json:"initiators"`
+	WorkingEnvironmentID   string   `structs:"workingEnvironmentId"`
+	SvmName                string   `structs:"svmName"`
+	WorkingEnvironmentType string   `structs:"workingEnvironmentType,omitempty"`
+}
+
+func (c *Client) getIgroups(ctx context.Context, request igroup, clientID string) ([]igroup, error) {
+	hostType := "CloudManagerHost"
+	var result []igroup
+	baseURL, err := c.igroupsURL(ctx, request, clientID)
+	if err != nil {
+		return result, err
+	}
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("getIgroups request failed ", statusCode)
+		return result, err
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getIgroups ", err)
+		return result, err
+	}
+	return result, nil
+}
+
+type igroupRequest struct {
+	IgroupName  string   `structs:"igroupName,omitempty"`
+	OsType      string   `structs:"osType,omitempty"`
+	PortsetName string   `structs:"portsetName,omitempty"`
+	Initiators  []string `structs:"initiators"`
+}
+
+// getIgroup returns the igroup with the name of the request, or errNotFound
+func (c *Client) getIgroup(ctx context.Context, request igroup, clientID string) (igroup, error) {
+	igroups, err := c.getIgroups(ctx, request, clientID)
+	if err != nil {
+		return igroup{}, err
+	}
+	for _, current := range igroups {
+		if current.IgroupName == request.IgroupName {
+			return current, nil
+		}
+	}
+	return igroup{}, fmt.Errorf("igroup %s: %w", request.IgroupName, errNotFound)
+}
+
+// igroupsURL returns the URL of the igroups of the SVM of the request
+func (c *Client) igroupsURL(ctx context.Context, request igroup, clientID string) (string, error) {
+	baseURL, _, err := c.getAPIRoot(ctx, request.WorkingEnvironmentID, clientID)
+	if err != nil {
+		return "", err
+	}
+	if request.WorkingEnvironmentType == "ON_PREM" {
+		log.Print("igroups onPrem")
+		return fmt.Sprintf("/occm/api/ontaps/working-environments/%s/volumes/%s/igroups", request.WorkingEnvironmentID, request.SvmName), nil
+	}
+	return fmt.Sprintf("%s/volumes/igroups/%s/%s", baseURL, request.WorkingEnvironmentID, request.SvmName), nil
+}
+
+func (c *Client) createIgroup(ctx context.Context, ig igroup, request igroupRequest, clientID string) error {
+	hostType := "CloudManagerHost"
+	baseURL, err := c.igroupsURL(ctx, ig, clientID)
+	if err != nil {
+		return err
+	}
+	params := structs.Map(request)
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("createIgroup request failed ", statusCode)
+		return err
+	}
+	return nil
+}
+
+// updateIgroup replaces the OS type and the initiators of the igroup
+func (c *Client) updateIgroup(ctx context.Context, ig igroup, request igroupRequest, clientID string) error {
+	hostType := "CloudManagerHost"
+	baseURL, err := c.igroupsURL(ctx, ig, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/%s", baseURL, request.IgroupName)
+	request.IgroupName = ""
+	params := structs.Map(request)
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "PUT", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("updateIgroup request failed ", statusCode)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) deleteIgroup(ctx context.Context, ig igroup, clientID string) error {
+	hostType := "CloudManagerHost"
+	baseURL, err := c.igroupsURL(ctx, ig, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/%s", baseURL, ig.IgroupName)
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("deleteIgroup request failed ", statusCode)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package cloudmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+type initiator struct {
+	AliasName              string `structs:"aliasName,omitempty"`
+	Iqn                    string `structs:"iqn,omitempty"`
+	WorkingEnvironmentID   string `structs:"workingEnvironmentId,omitempty"`
+	SvmName                string `structs:"svmName,omitempty"`
+	WorkingEnvironmentType string `structs:"workingEnvironmentType,omitempty"`
+}
+
+func (c *Client) createInitiator(ctx context.Context, request initiator, clientID string) error {
+	hostType := "CloudManagerHost"
+	baseURL, _, err := c.getAPIRoot(ctx, request.WorkingEnvironmentID, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/volumes/initiator", baseURL)
+	params := structs.Map(request)
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("createInitiator request failed ", statusCode)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) getInitiator(ctx context.Context, request initiator, clientID string) ([]initiator, error) {
+	hostType := "CloudManagerHost"
+	baseURL, _, err := c.getAPIRoot(ctx, request.WorkingEnvironmentID, clientID)
+	var result []initiator
+	if err != nil {
+		return result, err
+	}
+	baseURL = fmt.Sprintf("%s/volumes/initiator", baseURL)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("getInitiator request failed ", statusCode)
+		return result, err
+	}
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getInitiator ", err)
+		return result, err
+	}
+	return result, nil
+}
+
+// getInitiatorByIqn returns the initiator with the IQN, or errNotFound
+func (c *Client) getInitiatorByIqn(ctx context.Context, request initiator, clientID string) (initiator, error) {
+	initiators, err := c.getInitiator(ctx, request, clientID)
+	if err != nil {
+		return initiator{}, err
+	}
+	for _, current := range initiators {
+		if current.Iqn == request.Iqn {
+			return current, nil
+		}
+	}
+	return initiator{}, fmt.Errorf("initiator %s: %w", request.Iqn, errNotFound)
+}
+
+// updateInitiator changes the alias of the initiator
+func (c *Client) updateInitiator(ctx context.Context, request initiator, clientID string) error {
+	hostType := "CloudManagerHost"
+	baseURL, _, err := c.getAPIRoot(ctx, request.WorkingEnvironmentID, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/volumes/initiator/%s", baseURL, request.Iqn)
+	params := map[string]interface{}{"aliasName": request.AliasName}
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "PUT", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("updateInitiator request failed ", statusCode)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) deleteInitiator(ctx context.Context, request initiator, clientID string) error {
+	hostType := "CloudManagerHost"
+	baseURL, _, err := c.getAPIRoot(ctx, request.WorkingEnvironmentID, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/volumes/initiator/%s", baseURL, request.Iqn)
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("deleteInitiator request failed ", statusCode)
+		return err
+	}
+	return nil
+}
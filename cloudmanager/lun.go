@@ -0,0 +1,156 @@
+package cloudmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+type lunRequest struct {
+	Name         string `structs:"name,omitempty"`
+	Size         size   `structs:"size"`
+	OsType       string `structs:"osType,omitempty"`
+	SpaceReserve bool   `structs:"spaceReserve"`
+}
+
+type lunResponse struct {
+	Name         string       `json:"name"`
+	Path         string       `json:"path"`
+	Size         size         `json:"size"`
+	OsType       string       `json:"osType"`
+	SpaceReserve bool         `json:"spaceReserve"`
+	SerialNumber string       `json:"serialNumber"`
+	Mappings     []lunMapping `json:"mappings"`
+}
+
+// lunMapping makes the LUN visible to the initiators of the igroup under the LUN ID
+type lunMapping struct {
+	IgroupName string `structs:"igroupName" json:"igroupName"`
+	LunID      int    `structs:"lunId" json:"lunId"`
+}
+
+func (c *Client) createLun(ctx context.Context, vol volumeRequest, lun lunRequest, clientID string) error {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL += "/luns"
+	hostType := "CloudManagerHost"
+	params := structs.Map(lun)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("createLun request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "LUN", "create", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getLun returns the LUN of the volume with its igroup mappings, or errNotFound
+func (c *Client) getLun(ctx context.Context, vol volumeRequest, name string, clientID string) (lunResponse, error) {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return lunResponse{}, err
+	}
+	baseURL += "/luns"
+	hostType := "CloudManagerHost"
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("getLun request failed ", statusCode)
+		return lunResponse{}, err
+	}
+	var result []lunResponse
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getLun ", err)
+		return lunResponse{}, err
+	}
+	for _, lun := range result {
+		if lun.Name == name {
+			return lun, nil
+		}
+	}
+	return lunResponse{}, fmt.Errorf("LUN %s: %w", name, errNotFound)
+}
+
+// updateLun resizes the LUN and changes its space reservation
+func (c *Client) updateLun(ctx context.Context, vol volumeRequest, lun lunRequest, clientID string) error {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/luns/%s", baseURL, lun.Name)
+	lun.Name = ""
+	lun.OsType = ""
+	hostType := "CloudManagerHost"
+	params := structs.Map(lun)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "PUT", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("updateLun request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "LUN", "update", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) mapLun(ctx context.Context, vol volumeRequest, name string, mapping lunMapping, clientID string) error {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/luns/%s/mappings", baseURL, name)
+	hostType := "CloudManagerHost"
+	params := structs.Map(mapping)
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("mapLun request failed ", statusCode)
+		return err
+	}
+	return nil
+}
+
+func (c *Client) unmapLun(ctx context.Context, vol volumeRequest, name string, igroupName string, clientID string) error {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/luns/%s/mappings/%s", baseURL, name, igroupName)
+	hostType := "CloudManagerHost"
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("unmapLun request failed ", statusCode)
+		return err
+	}
+	return nil
+}
+
+// deleteLun deletes the LUN, which has to be unmapped first
+func (c *Client) deleteLun(ctx context.Context, vol volumeRequest, name string, clientID string) error {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/luns/%s", baseURL, name)
+	hostType := "CloudManagerHost"
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("deleteLun request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "LUN", "delete", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
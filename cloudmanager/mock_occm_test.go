@@ -0,0 +1,1325 @@
+package cloudmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/netapp/terraform-provider-netapp-cloudmanager/cloudmanager/cloudmanager/restapi"
+)
+
+// mockOCCMAPIRoot matches the API roots returned by getAPIRoot
+const mockOCCMAPIRoot = `/occm/api/(?:vsa|aws/ha|azure/vsa|azure/ha|gcp/vsa|gcp/ha|fsx|onprem)`
+
+// mockWorkingEnvironment is a working environment known to the mock OCCM
+type mockWorkingEnvironment struct {
+	PublicID               string
+	Name                   string
+	ProviderName           string
+	IsHA                   bool
+	WorkingEnvironmentType string
+	SvmName                string
+	TenantID               string
+	// SnapshotPolicies are the snapshot policies as returned by the API, with a name and schedules
+	SnapshotPolicies []map[string]interface{}
+}
+
+type mockRoute struct {
+	method  string
+	pattern *regexp.Regexp
+	handler func(w http.ResponseWriter, r *http.Request, args []string)
+}
+
+// mockOCCM is an in-memory fake of the BlueXP and Connector (OCCM) APIs used by the provider,
+// so the CRUD functions of the resources can be tested without network access or a refresh token.
+// Tasks complete immediately, so the polling helpers never have to wait.
+type mockOCCM struct {
+	*httptest.Server
+	t *testing.T
+
+	mu                  sync.Mutex
+	routes              []mockRoute
+	workingEnvironments map[string]*mockWorkingEnvironment
+	volumes             map[string][]map[string]interface{}
+	aggregates          map[string][]map[string]interface{}
+	cifs                map[string][]map[string]interface{}
+	nssAccounts         []map[string]interface{}
+	snapMirrors         []map[string]interface{}
+	snapshots           map[string][]string
+	initiators          []map[string]interface{}
+	igroups             map[string][]map[string]interface{}
+	cifsShares          map[string][]map[string]interface{}
+	exportPolicies      map[string][]map[string]interface{}
+	qtrees              map[string][]map[string]interface{}
+	quotaRules          map[string][]map[string]interface{}
+	svms                map[string][]map[string]interface{}
+	luns                map[string][]map[string]interface{}
+	backups             map[string]map[string]interface{}
+	backupPolicies      map[string][]map[string]interface{}
+	volumeBackups       map[string][]map[string]interface{}
+	tasks               map[string]map[string]interface{}
+	validTokens         map[string]bool
+	tokenCount          int
+	nextID              int
+	// requests holds "METHOD path" for every request received, for assertions
+	requests []string
+	// restores holds the body of every snapshot restore, for assertions
+	restores []map[string]interface{}
+}
+
+// newMockOCCM starts a mock OCCM server, which is closed when the test ends
+func newMockOCCM(t *testing.T) *mockOCCM {
+	m := &mockOCCM{
+		t:                   t,
+		workingEnvironments: map[string]*mockWorkingEnvironment{},
+		volumes:             map[string][]map[string]interface{}{},
+		aggregates:          map[string][]map[string]interface{}{},
+		cifs:                map[string][]map[string]interface{}{},
+		snapshots:           map[string][]string{},
+		igroups:             map[string][]map[string]interface{}{},
+		cifsShares:          map[string][]map[string]interface{}{},
+		exportPolicies:      map[string][]map[string]interface{}{},
+		qtrees:              map[string][]map[string]interface{}{},
+		quotaRules:          map[string][]map[string]interface{}{},
+		svms:                map[string][]map[string]interface{}{},
+		luns:                map[string][]map[string]interface{}{},
+		backups:             map[string]map[string]interface{}{},
+		backupPolicies:      map[string][]map[string]interface{}{},
+		volumeBackups:       map[string][]map[string]interface{}{},
+		tasks:               map[string]map[string]interface{}{},
+		validTokens:         map[string]bool{},
+	}
+	m.registerRoutes()
+	m.Server = httptest.NewServer(http.HandlerFunc(m.serveHTTP))
+	t.Cleanup(m.Close)
+	return m
+}
+
+// newMockVsa starts a mock OCCM with the AWS working environment mockvsa (VsaWorkingEnvironment-mock),
+// returning it with a client talking to it
+func newMockVsa(t *testing.T) (*mockOCCM, *Client) {
+	m := newMockOCCM(t)
+	m.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "VsaWorkingEnvironment-mock", Name: "mockvsa", ProviderName: "Amazon"})
+	return m, m.client()
+}
+
+// client returns a Client talking to the mock with service account credentials
+func (m *mockOCCM) client() *Client {
+	client := &Client{
+		CloudManagerHost: m.URL,
+		SaAuthHost:       m.URL + "/auth/oauth/token",
+		Audience:         "https://api.cloud.netapp.com",
+		RetryPolicy:      &restapi.RetryPolicy{},
+	}
+	client.SetServiceCredential("mock-secret", "mock-client")
+	return client
+}
+
+// addWorkingEnvironment registers a working environment, defaulting the SVM name to svm_<name>
+func (m *mockOCCM) addWorkingEnvironment(we mockWorkingEnvironment) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if we.SvmName == "" {
+		we.SvmName = "svm_" + we.Name
+	}
+	if we.WorkingEnvironmentType == "" {
+		we.WorkingEnvironmentType = "VSA"
+	}
+	m.workingEnvironments[we.PublicID] = &we
+	m.svms[we.PublicID] = []map[string]interface{}{m.newSVM(we.SvmName)}
+}
+
+// addVolume registers a volume in the working environment or file system
+func (m *mockOCCM) addVolume(id string, svm string, name string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.volumes[id] = append(m.volumes[id], map[string]interface{}{
+		"uuid":    m.newID("volume-"),
+		"name":    name,
+		"svmName": svm,
+		"size":    map[string]interface{}{"size": float64(10), "unit": "GB"},
+	})
+}
+
+// addSnapMirror registers a relationship from the source volume to the destination volume
+func (m *mockOCCM) addSnapMirror(sourceID string, sourceSvm string, sourceVolume string, destinationID string, destinationSvm string, destinationVolume string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.snapMirrors = append(m.snapMirrors, map[string]interface{}{
+		"source":             map[string]interface{}{"workingEnvironmentId": sourceID, "svmName": sourceSvm, "volumeName": sourceVolume},
+		"destination":        map[string]interface{}{"workingEnvironmentId": destinationID, "svmName": destinationSvm, "volumeName": destinationVolume},
+		"policyName":         "MirrorAllSnapshots",
+		"scheduleName":       "1hour",
+		"maxTransferRate":    map[string]interface{}{"size": float64(100000), "unit": "KB"},
+		"mirrorState":        "snapmirrored",
+		"relationshipStatus": "idle",
+		"lagTime":            map[string]interface{}{"length": float64(5), "unit": "MINUTES"},
+		"lastTransferInfo":   map[string]interface{}{"transferSize": map[string]interface{}{"size": float64(2), "unit": "MB"}, "transferEndTime": float64(1700000000000)},
+		"healthy":            true,
+	})
+}
+
+// expireTokens invalidates every access token issued so far, as if they had expired
+func (m *mockOCCM) expireTokens() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.validTokens = map[string]bool{}
+}
+
+// handle registers an additional route, pattern being anchored and matched against the URL path
+func (m *mockOCCM) handle(method string, pattern string, handler func(w http.ResponseWriter, r *http.Request, args []string)) {
+	m.routes = append(m.routes, mockRoute{method: method, pattern: regexp.MustCompile("^" + pattern + "$"), handler: handler})
+}
+
+// override registers a route served before the default ones, pattern being anchored and matched against the URL path
+func (m *mockOCCM) override(method string, pattern string, handler func(w http.ResponseWriter, r *http.Request, args []string)) {
+	m.routes = append([]mockRoute{{method: method, pattern: regexp.MustCompile("^" + pattern + "$"), handler: handler}}, m.routes...)
+}
+
+// requestCount returns how many requests were received for the method and path
+func (m *mockOCCM) requestCount(method string, path string) int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	count := 0
+	for _, request := range m.requests {
+		if request == method+" "+path {
+			count++
+		}
+	}
+	return count
+}
+
+func (m *mockOCCM) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.requests = append(m.requests, r.Method+" "+r.URL.Path)
+
+	if r.URL.Path != "/auth/oauth/token" && !m.validTokens[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")] {
+		writeMockJSON(w, http.StatusUnauthorized, map[string]interface{}{"message": "Unauthorized", "code": "Unauthorized"})
+		return
+	}
+	for _, route := range m.routes {
+		if route.method != r.Method {
+			continue
+		}
+		if args := route.pattern.FindStringSubmatch(r.URL.Path); args != nil {
+			route.handler(w, r, args[1:])
+			return
+		}
+	}
+	m.t.Logf("mock OCCM: no route for %s %s", r.Method, r.URL.String())
+	writeMockJSON(w, http.StatusNotFound, map[string]interface{}{"message": fmt.Sprintf("no route for %s %s", r.Method, r.URL.Path)})
+}
+
+func writeMockJSON(w http.ResponseWriter, statusCode int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body)
+}
+
+func readMockJSON(r *http.Request) map[string]interface{} {
+	body, _ := ioutil.ReadAll(r.Body)
+	var params map[string]interface{}
+	json.Unmarshal(body, &params)
+	return params
+}
+
+func (m *mockOCCM) newID(prefix string) string {
+	m.nextID++
+	return fmt.Sprintf("%s%d", prefix, m.nextID)
+}
+
+// completeTask answers an asynchronous request with a task that has already succeeded
+func (m *mockOCCM) completeTask(w http.ResponseWriter, body interface{}) {
+	id := m.newID("task-")
+	m.tasks[id] = map[string]interface{}{"status": 1}
+	w.Header().Set("OnCloud-Request-Id", id)
+	if body == nil {
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+	writeMockJSON(w, http.StatusAccepted, body)
+}
+
+func (m *mockOCCM) notFound(w http.ResponseWriter, what string, id string) {
+	writeMockJSON(w, http.StatusNotFound, map[string]interface{}{"message": fmt.Sprintf("%s %s not found", what, id), "code": "NotFound"})
+}
+
+func (m *mockOCCM) workingEnvironmentJSON(we *mockWorkingEnvironment) map[string]interface{} {
+	return map[string]interface{}{
+		"publicId":               we.PublicID,
+		"name":                   we.Name,
+		"providerName":           we.ProviderName,
+		"cloudProviderName":      we.ProviderName,
+		"isHA":                   we.IsHA,
+		"workingEnvironmentType": we.WorkingEnvironmentType,
+		"svmName":                we.SvmName,
+		"tenantId":               we.TenantID,
+	}
+}
+
+func (m *mockOCCM) registerRoutes() {
+	m.handle("POST", `/auth/oauth/token`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		m.tokenCount++
+		token := fmt.Sprintf("mock-token-%d", m.tokenCount)
+		m.validTokens[token] = true
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{"access_token": token, "expires_in": 3600})
+	})
+
+	m.handle("GET", `/tenancy/account`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		writeMockJSON(w, http.StatusOK, []map[string]interface{}{{"accountPublicId": "account-mock", "accountName": "mock"}})
+	})
+
+	m.handle("GET", `/occm/api/audit/activeTask/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		task, ok := m.tasks[args[0]]
+		if !ok {
+			m.notFound(w, "task", args[0])
+			return
+		}
+		writeMockJSON(w, http.StatusOK, task)
+	})
+
+	// working environments
+	m.handle("GET", `/occm/api/working-environments/exists/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for _, we := range m.workingEnvironments {
+			if we.Name == args[0] {
+				writeMockJSON(w, http.StatusOK, true)
+				return
+			}
+		}
+		m.notFound(w, "working environment", args[0])
+	})
+	m.handle("GET", `/occm/api/working-environments`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		result := map[string][]map[string]interface{}{
+			"vsaWorkingEnvironments":      {},
+			"onPremWorkingEnvironments":   {},
+			"azureVsaWorkingEnvironments": {},
+			"gcpVsaWorkingEnvironments":   {},
+		}
+		for _, we := range m.workingEnvironments {
+			key := "vsaWorkingEnvironments"
+			if we.WorkingEnvironmentType == "ON_PREM" {
+				key = "onPremWorkingEnvironments"
+			} else if we.ProviderName == "Azure" {
+				key = "azureVsaWorkingEnvironments"
+			} else if we.ProviderName == "GCP" {
+				key = "gcpVsaWorkingEnvironments"
+			}
+			result[key] = append(result[key], m.workingEnvironmentJSON(we))
+		}
+		writeMockJSON(w, http.StatusOK, result)
+	})
+	m.handle("GET", `/occm/api/ontaps/working-environments/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		we, ok := m.workingEnvironments[args[0]]
+		if !ok {
+			m.notFound(w, "working environment", args[0])
+			return
+		}
+		writeMockJSON(w, http.StatusOK, m.workingEnvironmentJSON(we))
+	})
+	m.handle("GET", mockOCCMAPIRoot+`/working-environments/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		we, ok := m.workingEnvironments[args[0]]
+		if !ok {
+			m.notFound(w, "working environment", args[0])
+			return
+		}
+		result := m.workingEnvironmentJSON(we)
+		policies := we.SnapshotPolicies
+		if policies == nil {
+			policies = []map[string]interface{}{}
+		}
+		result["snapshotPolicies"] = policies
+		writeMockJSON(w, http.StatusOK, result)
+	})
+	snapshotPolicy := func(params map[string]interface{}) map[string]interface{} {
+		schedules := []interface{}{}
+		if list, ok := params["schedules"].([]interface{}); ok {
+			for _, x := range list {
+				schedule := x.(map[string]interface{})
+				schedules = append(schedules, map[string]interface{}{"frequency": strings.ToLower(schedule["scheduleType"].(string)), "retention": schedule["retention"]})
+			}
+		}
+		return map[string]interface{}{"name": params["snapshotPolicyName"], "schedules": schedules}
+	}
+	m.handle("POST", mockOCCMAPIRoot+`/working-environments/([^/]+)/snapshot-policy`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		we, ok := m.workingEnvironments[args[0]]
+		if !ok {
+			m.notFound(w, "working environment", args[0])
+			return
+		}
+		we.SnapshotPolicies = append(we.SnapshotPolicies, snapshotPolicy(readMockJSON(r)))
+		m.completeTask(w, nil)
+	})
+	m.handle("PUT", mockOCCMAPIRoot+`/working-environments/([^/]+)/snapshot-policy`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		we, ok := m.workingEnvironments[args[0]]
+		if !ok {
+			m.notFound(w, "working environment", args[0])
+			return
+		}
+		policy := snapshotPolicy(readMockJSON(r))
+		for i := range we.SnapshotPolicies {
+			if we.SnapshotPolicies[i]["name"] == policy["name"] {
+				we.SnapshotPolicies[i] = policy
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "snapshot policy", fmt.Sprint(policy["name"]))
+	})
+	m.handle("DELETE", mockOCCMAPIRoot+`/working-environments/([^/]+)/snapshot-policy/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		we, ok := m.workingEnvironments[args[0]]
+		if !ok {
+			m.notFound(w, "working environment", args[0])
+			return
+		}
+		for i := range we.SnapshotPolicies {
+			if we.SnapshotPolicies[i]["name"] == args[1] {
+				we.SnapshotPolicies = append(we.SnapshotPolicies[:i], we.SnapshotPolicies[i+1:]...)
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "snapshot policy", args[1])
+	})
+
+	// volumes, keyed by working environment or file system ID
+	m.handle("POST", mockOCCMAPIRoot+`/volumes/quote`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		params := readMockJSON(r)
+		aggregateName, _ := params["aggregateName"].(string)
+		if aggregateName == "" {
+			aggregateName = "aggr1"
+		}
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{"newAggregate": false, "aggregateName": aggregateName, "numOfDisks": float64(0)})
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/volumes`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		params := readMockJSON(r)
+		id, _ := params["fileSystemId"].(string)
+		if id == "" {
+			id, _ = params["workingEnvironmentId"].(string)
+		}
+		for _, vol := range m.volumes[id] {
+			if vol["name"] == params["name"] && vol["svmName"] == params["svmName"] {
+				writeMockJSON(w, http.StatusConflict, map[string]interface{}{"message": fmt.Sprintf("volume %s already exists", params["name"])})
+				return
+			}
+		}
+		vol := map[string]interface{}{
+			"uuid":             m.newID("volume-"),
+			"name":             params["name"],
+			"svmName":          params["svmName"],
+			"aggregateName":    params["aggregateName"],
+			"size":             params["size"],
+			"snapshotPolicy":   params["snapshotPolicyName"],
+			"thinProvisioning": params["enableThinProvisioning"],
+			"compression":      params["enableCompression"],
+			"deduplication":    params["enableDeduplication"],
+			"exportPolicyInfo": params["exportPolicyInfo"],
+			"capacityTier":     params["capacityTier"],
+			"tieringPolicy":    params["tieringPolicy"],
+		}
+		if shareInfo, ok := params["shareInfo"].(map[string]interface{}); ok && shareInfo["shareName"] != nil {
+			vol["shareInfo"] = []map[string]interface{}{{
+				"shareName":         shareInfo["shareName"],
+				"accessControlList": []interface{}{shareInfo["accessControl"]},
+			}}
+		}
+		m.volumes[id] = append(m.volumes[id], vol)
+		m.completeTask(w, nil)
+	})
+	m.handle("GET", mockOCCMAPIRoot+`/volumes`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		id := r.URL.Query().Get("fileSystemId")
+		if id == "" {
+			id = r.URL.Query().Get("workingEnvironmentId")
+		}
+		volumes := m.volumes[id]
+		if volumes == nil {
+			volumes = []map[string]interface{}{}
+		}
+		writeMockJSON(w, http.StatusOK, volumes)
+	})
+	m.handle("PUT", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		vol := m.findVolume(args[0], args[1], args[2])
+		if vol == nil {
+			m.notFound(w, "volume", args[2])
+			return
+		}
+		params := readMockJSON(r)
+		if v, ok := params["snapshotPolicyName"]; ok {
+			vol["snapshotPolicy"] = v
+		}
+		if v, ok := params["tieringPolicy"]; ok {
+			vol["tieringPolicy"] = v
+		}
+		if v, ok := params["exportPolicyInfo"]; ok {
+			vol["exportPolicyInfo"] = v
+		}
+		if v, ok := params["size"].(map[string]interface{}); ok && v["size"] != float64(0) {
+			vol["size"] = v
+		}
+		m.completeTask(w, nil)
+	})
+	m.handle("DELETE", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		volumes := m.volumes[args[0]]
+		for i, vol := range volumes {
+			if vol["svmName"] == args[1] && vol["name"] == args[2] {
+				m.volumes[args[0]] = append(volumes[:i], volumes[i+1:]...)
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "volume", args[2])
+	})
+
+	m.handle("POST", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/clone`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		parent := m.findVolume(args[0], args[1], args[2])
+		if parent == nil {
+			m.notFound(w, "volume", args[2])
+			return
+		}
+		params := readMockJSON(r)
+		if snapshot, ok := params["parentSnapshot"].(string); ok {
+			found := false
+			for _, name := range m.snapshots[strings.Join(args, "/")] {
+				found = found || name == snapshot
+			}
+			if !found {
+				m.notFound(w, "snapshot", snapshot)
+				return
+			}
+		}
+		clone := map[string]interface{}{}
+		for k, v := range parent {
+			clone[k] = v
+		}
+		clone["uuid"] = m.newID("volume-")
+		clone["name"] = params["newVolumeName"]
+		clone["parentVolumeName"] = args[2]
+		m.volumes[args[0]] = append(m.volumes[args[0]], clone)
+		m.completeTask(w, nil)
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/split`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		vol := m.findVolume(args[0], args[1], args[2])
+		if vol == nil || vol["parentVolumeName"] == nil {
+			m.notFound(w, "clone", args[2])
+			return
+		}
+		delete(vol, "parentVolumeName")
+		m.completeTask(w, nil)
+	})
+
+	// snapshots, keyed by working environment or file system ID, SVM and volume
+	m.handle("GET", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/snapshots`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		if m.findVolume(args[0], args[1], args[2]) == nil {
+			m.notFound(w, "volume", args[2])
+			return
+		}
+		result := []map[string]interface{}{}
+		for _, name := range m.snapshots[strings.Join(args, "/")] {
+			result = append(result, map[string]interface{}{"name": name})
+		}
+		writeMockJSON(w, http.StatusOK, result)
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/snapshots`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		if m.findVolume(args[0], args[1], args[2]) == nil {
+			m.notFound(w, "volume", args[2])
+			return
+		}
+		key := strings.Join(args, "/")
+		m.snapshots[key] = append(m.snapshots[key], readMockJSON(r)["snapshotName"].(string))
+		m.completeTask(w, nil)
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/snapshots/([^/]+)/restore`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for _, name := range m.snapshots[strings.Join(args[:3], "/")] {
+			if name == args[3] {
+				m.restores = append(m.restores, readMockJSON(r))
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "snapshot", args[3])
+	})
+	m.handle("DELETE", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/snapshots/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		key := strings.Join(args[:3], "/")
+		for i, name := range m.snapshots[key] {
+			if name == args[3] {
+				m.snapshots[key] = append(m.snapshots[key][:i], m.snapshots[key][i+1:]...)
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "snapshot", args[3])
+	})
+
+	// iSCSI initiators, shared by the working environments, and igroups keyed by working environment and SVM
+	m.handle("GET", mockOCCMAPIRoot+`/volumes/initiator`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		initiators := m.initiators
+		if initiators == nil {
+			initiators = []map[string]interface{}{}
+		}
+		writeMockJSON(w, http.StatusOK, initiators)
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/volumes/initiator`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		params := readMockJSON(r)
+		m.initiators = append(m.initiators, map[string]interface{}{"iqn": params["iqn"], "aliasName": params["aliasName"]})
+		w.WriteHeader(http.StatusOK)
+	})
+	m.handle("PUT", mockOCCMAPIRoot+`/volumes/initiator/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for _, ini := range m.initiators {
+			if ini["iqn"] == args[0] {
+				ini["aliasName"] = readMockJSON(r)["aliasName"]
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+		m.notFound(w, "initiator", args[0])
+	})
+	m.handle("DELETE", mockOCCMAPIRoot+`/volumes/initiator/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for i, ini := range m.initiators {
+			if ini["iqn"] == args[0] {
+				m.initiators = append(m.initiators[:i], m.initiators[i+1:]...)
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+		m.notFound(w, "initiator", args[0])
+	})
+	getIgroups := func(w http.ResponseWriter, r *http.Request, args []string) {
+		igroups := m.igroups[strings.Join(args, "/")]
+		if igroups == nil {
+			igroups = []map[string]interface{}{}
+		}
+		writeMockJSON(w, http.StatusOK, igroups)
+	}
+	createIgroup := func(w http.ResponseWriter, r *http.Request, args []string) {
+		params := readMockJSON(r)
+		if params["initiators"] == nil {
+			params["initiators"] = []interface{}{}
+		}
+		params["igroupType"] = "iscsi"
+		key := strings.Join(args, "/")
+		m.igroups[key] = append(m.igroups[key], params)
+		w.WriteHeader(http.StatusOK)
+	}
+	updateIgroup := func(w http.ResponseWriter, r *http.Request, args []string) {
+		for _, ig := range m.igroups[strings.Join(args[:2], "/")] {
+			if ig["igroupName"] == args[2] {
+				for k, v := range readMockJSON(r) {
+					ig[k] = v
+				}
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+		m.notFound(w, "igroup", args[2])
+	}
+	deleteIgroup := func(w http.ResponseWriter, r *http.Request, args []string) {
+		key := strings.Join(args[:2], "/")
+		for i, ig := range m.igroups[key] {
+			if ig["igroupName"] == args[2] {
+				m.igroups[key] = append(m.igroups[key][:i], m.igroups[key][i+1:]...)
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		}
+		m.notFound(w, "igroup", args[2])
+	}
+	m.handle("GET", mockOCCMAPIRoot+`/volumes/igroups/([^/]+)/([^/]+)`, getIgroups)
+	m.handle("POST", mockOCCMAPIRoot+`/volumes/igroups/([^/]+)/([^/]+)`, createIgroup)
+	m.handle("PUT", mockOCCMAPIRoot+`/volumes/igroups/([^/]+)/([^/]+)/([^/]+)`, updateIgroup)
+	m.handle("DELETE", mockOCCMAPIRoot+`/volumes/igroups/([^/]+)/([^/]+)/([^/]+)`, deleteIgroup)
+	// the igroups of on-premises ONTAP clusters
+	m.handle("GET", `/occm/api/ontaps/working-environments/([^/]+)/volumes/([^/]+)/igroups`, getIgroups)
+	m.handle("POST", `/occm/api/ontaps/working-environments/([^/]+)/volumes/([^/]+)/igroups`, createIgroup)
+	m.handle("PUT", `/occm/api/ontaps/working-environments/([^/]+)/volumes/([^/]+)/igroups/([^/]+)`, updateIgroup)
+	m.handle("DELETE", `/occm/api/ontaps/working-environments/([^/]+)/volumes/([^/]+)/igroups/([^/]+)`, deleteIgroup)
+
+	// qtrees and quota rules, keyed by working environment, SVM and volume
+	m.handle("GET", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/qtrees`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		qtrees := m.qtrees[strings.Join(args, "/")]
+		if qtrees == nil {
+			qtrees = []map[string]interface{}{}
+		}
+		writeMockJSON(w, http.StatusOK, qtrees)
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/qtrees`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		if m.findVolume(args[0], args[1], args[2]) == nil {
+			m.notFound(w, "volume", args[2])
+			return
+		}
+		qtree := map[string]interface{}{"securityStyle": "unix", "unixPermissions": "0755", "exportPolicyName": "default"}
+		for k, v := range readMockJSON(r) {
+			qtree[k] = v
+		}
+		key := strings.Join(args, "/")
+		m.qtrees[key] = append(m.qtrees[key], qtree)
+		m.completeTask(w, nil)
+	})
+	m.handle("PUT", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/qtrees/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for _, qtree := range m.qtrees[strings.Join(args[:3], "/")] {
+			if qtree["name"] == args[3] {
+				for k, v := range readMockJSON(r) {
+					qtree[k] = v
+				}
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "qtree", args[3])
+	})
+	m.handle("DELETE", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/qtrees/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		key := strings.Join(args[:3], "/")
+		for i, qtree := range m.qtrees[key] {
+			if qtree["name"] == args[3] {
+				m.qtrees[key] = append(m.qtrees[key][:i], m.qtrees[key][i+1:]...)
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "qtree", args[3])
+	})
+	m.handle("GET", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/quota-rules`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		rules := m.quotaRules[strings.Join(args, "/")]
+		if rules == nil {
+			rules = []map[string]interface{}{}
+		}
+		writeMockJSON(w, http.StatusOK, rules)
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/quota-rules`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		if m.findVolume(args[0], args[1], args[2]) == nil {
+			m.notFound(w, "volume", args[2])
+			return
+		}
+		rule := readMockJSON(r)
+		rule["id"] = m.newID("quota-rule-")
+		key := strings.Join(args, "/")
+		m.quotaRules[key] = append(m.quotaRules[key], rule)
+		m.completeTask(w, nil)
+	})
+	m.handle("PUT", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/quota-rules/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for _, rule := range m.quotaRules[strings.Join(args[:3], "/")] {
+			if rule["id"] == args[3] {
+				for k, v := range readMockJSON(r) {
+					rule[k] = v
+				}
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "quota rule", args[3])
+	})
+	m.handle("DELETE", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/quota-rules/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		key := strings.Join(args[:3], "/")
+		for i, rule := range m.quotaRules[key] {
+			if rule["id"] == args[3] {
+				m.quotaRules[key] = append(m.quotaRules[key][:i], m.quotaRules[key][i+1:]...)
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "quota rule", args[3])
+	})
+
+	// LUNs, keyed by working environment, SVM and volume. Like ONTAP, a mapped LUN cannot be deleted.
+	findLun := func(args []string) map[string]interface{} {
+		for _, lun := range m.luns[strings.Join(args[:3], "/")] {
+			if lun["name"] == args[3] {
+				return lun
+			}
+		}
+		return nil
+	}
+	m.handle("GET", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/luns`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		luns := m.luns[strings.Join(args, "/")]
+		if luns == nil {
+			luns = []map[string]interface{}{}
+		}
+		writeMockJSON(w, http.StatusOK, luns)
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/luns`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		if m.findVolume(args[0], args[1], args[2]) == nil {
+			m.notFound(w, "volume", args[2])
+			return
+		}
+		lun := readMockJSON(r)
+		lun["path"] = fmt.Sprintf("/vol/%s/%s", args[2], lun["name"])
+		lun["serialNumber"] = m.newID("wCVg")
+		lun["mappings"] = []interface{}{}
+		key := strings.Join(args, "/")
+		m.luns[key] = append(m.luns[key], lun)
+		m.completeTask(w, nil)
+	})
+	m.handle("PUT", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/luns/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		lun := findLun(args)
+		if lun == nil {
+			m.notFound(w, "LUN", args[3])
+			return
+		}
+		for k, v := range readMockJSON(r) {
+			lun[k] = v
+		}
+		m.completeTask(w, nil)
+	})
+	m.handle("DELETE", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/luns/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		key := strings.Join(args[:3], "/")
+		for i, lun := range m.luns[key] {
+			if lun["name"] == args[3] {
+				if len(lun["mappings"].([]interface{})) > 0 {
+					writeMockJSON(w, http.StatusBadRequest, map[string]interface{}{"message": fmt.Sprintf("LUN %s is mapped", args[3])})
+					return
+				}
+				m.luns[key] = append(m.luns[key][:i], m.luns[key][i+1:]...)
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "LUN", args[3])
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/luns/([^/]+)/mappings`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		lun := findLun(args)
+		if lun == nil {
+			m.notFound(w, "LUN", args[3])
+			return
+		}
+		mapping := readMockJSON(r)
+		for _, v := range lun["mappings"].([]interface{}) {
+			if v.(map[string]interface{})["igroupName"] == mapping["igroupName"] {
+				writeMockJSON(w, http.StatusConflict, map[string]interface{}{"message": fmt.Sprintf("LUN %s is already mapped to %s", args[3], mapping["igroupName"])})
+				return
+			}
+		}
+		lun["mappings"] = append(lun["mappings"].([]interface{}), mapping)
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+	m.handle("DELETE", mockOCCMAPIRoot+`/volumes/([^/]+)/([^/]+)/([^/]+)/luns/([^/]+)/mappings/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		lun := findLun(args)
+		if lun == nil {
+			m.notFound(w, "LUN", args[3])
+			return
+		}
+		mappings := lun["mappings"].([]interface{})
+		for i, v := range mappings {
+			if v.(map[string]interface{})["igroupName"] == args[4] {
+				lun["mappings"] = append(mappings[:i], mappings[i+1:]...)
+				writeMockJSON(w, http.StatusOK, map[string]interface{}{})
+				return
+			}
+		}
+		m.notFound(w, "LUN mapping", args[4])
+	})
+
+	// aggregates
+	listAggregates := func(w http.ResponseWriter, id string) {
+		aggregates := m.aggregates[id]
+		if aggregates == nil {
+			aggregates = []map[string]interface{}{}
+		}
+		writeMockJSON(w, http.StatusOK, aggregates)
+	}
+	m.handle("GET", mockOCCMAPIRoot+`/aggregates`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		listAggregates(w, r.URL.Query().Get("workingEnvironmentId"))
+	})
+	m.handle("GET", mockOCCMAPIRoot+`/aggregates/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		listAggregates(w, args[0])
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/aggregates`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		params := readMockJSON(r)
+		id, _ := params["workingEnvironmentId"].(string)
+		m.aggregates[id] = append(m.aggregates[id], map[string]interface{}{
+			"name":          params["name"],
+			"state":         "online",
+			"numberOfDisks": params["numberOfDisks"],
+			"homeNode":      params["homeNode"],
+			"capacityTier":  params["capacityTier"],
+		})
+		m.completeTask(w, nil)
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/aggregates/([^/]+)/([^/]+)/disks`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for _, aggregate := range m.aggregates[args[0]] {
+			if aggregate["name"] == args[1] {
+				disks, _ := aggregate["numberOfDisks"].(float64)
+				added, _ := readMockJSON(r)["numberOfDisks"].(float64)
+				aggregate["numberOfDisks"] = disks + added
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "aggregate", args[1])
+	})
+	m.handle("DELETE", mockOCCMAPIRoot+`/aggregates/([^/]+)/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		aggregates := m.aggregates[args[0]]
+		for i, aggregate := range aggregates {
+			if aggregate["name"] == args[1] {
+				m.aggregates[args[0]] = append(aggregates[:i], aggregates[i+1:]...)
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "aggregate", args[1])
+	})
+
+	// CIFS
+	m.handle("GET", mockOCCMAPIRoot+`/working-environments/([^/]+)/cifs`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		configs := m.cifs[args[0]]
+		if configs == nil {
+			configs = []map[string]interface{}{}
+		}
+		writeMockJSON(w, http.StatusOK, configs)
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/working-environments/([^/]+)/cifs`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		params := readMockJSON(r)
+		delete(params, "activeDirectoryPassword")
+		m.cifs[args[0]] = append(m.cifs[args[0]], params)
+		m.completeTask(w, nil)
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/working-environments/([^/]+)/delete-cifs`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		delete(m.cifs, args[0])
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{})
+	})
+
+	// CIFS shares, keyed by working environment
+	m.handle("GET", mockOCCMAPIRoot+`/working-environments/([^/]+)/cifs-shares`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		shares := []map[string]interface{}{}
+		for _, share := range m.cifsShares[args[0]] {
+			if share["svmName"] == r.URL.Query().Get("svm") {
+				shares = append(shares, share)
+			}
+		}
+		writeMockJSON(w, http.StatusOK, shares)
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/working-environments/([^/]+)/cifs-shares`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		params := readMockJSON(r)
+		if m.findVolume(args[0], params["svmName"].(string), params["volumeName"].(string)) == nil {
+			m.notFound(w, "volume", params["volumeName"].(string))
+			return
+		}
+		m.cifsShares[args[0]] = append(m.cifsShares[args[0]], params)
+		m.completeTask(w, nil)
+	})
+	m.handle("PUT", mockOCCMAPIRoot+`/working-environments/([^/]+)/cifs-shares/([^/]+)/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for _, share := range m.cifsShares[args[0]] {
+			if share["svmName"] == args[1] && share["shareName"] == args[2] {
+				for k, v := range readMockJSON(r) {
+					share[k] = v
+				}
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "cifs share", args[2])
+	})
+	m.handle("DELETE", mockOCCMAPIRoot+`/working-environments/([^/]+)/cifs-shares/([^/]+)/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for i, share := range m.cifsShares[args[0]] {
+			if share["svmName"] == args[1] && share["shareName"] == args[2] {
+				m.cifsShares[args[0]] = append(m.cifsShares[args[0]][:i], m.cifsShares[args[0]][i+1:]...)
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "cifs share", args[2])
+	})
+
+	// export policies, keyed by working environment or file system
+	m.handle("GET", mockOCCMAPIRoot+`/working-environments/([^/]+)/export-policies`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		policies := []map[string]interface{}{}
+		for _, policy := range m.exportPolicies[args[0]] {
+			if policy["svmName"] == r.URL.Query().Get("svm") {
+				policies = append(policies, policy)
+			}
+		}
+		writeMockJSON(w, http.StatusOK, policies)
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/working-environments/([^/]+)/export-policies`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		params := readMockJSON(r)
+		for _, rule := range params["rules"].([]interface{}) {
+			rule := rule.(map[string]interface{})
+			if rule["protocols"] == nil {
+				rule["protocols"] = []interface{}{"any"}
+			}
+			if rule["superuser"] == nil {
+				rule["superuser"] = []interface{}{"none"}
+			}
+			if rule["anonymousUser"] == nil {
+				rule["anonymousUser"] = "65534"
+			}
+		}
+		m.exportPolicies[args[0]] = append(m.exportPolicies[args[0]], params)
+		m.completeTask(w, nil)
+	})
+	m.handle("PUT", mockOCCMAPIRoot+`/working-environments/([^/]+)/export-policies/([^/]+)/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for _, policy := range m.exportPolicies[args[0]] {
+			if policy["svmName"] == args[1] && policy["name"] == args[2] {
+				policy["rules"] = readMockJSON(r)["rules"]
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "export policy", args[2])
+	})
+	m.handle("DELETE", mockOCCMAPIRoot+`/working-environments/([^/]+)/export-policies/([^/]+)/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for i, policy := range m.exportPolicies[args[0]] {
+			if policy["svmName"] == args[1] && policy["name"] == args[2] {
+				m.exportPolicies[args[0]] = append(m.exportPolicies[args[0]][:i], m.exportPolicies[args[0]][i+1:]...)
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "export policy", args[2])
+	})
+
+	// SVMs of CVOs and FSx file systems, the first one being the SVM created with the working environment
+	m.handle("GET", mockOCCMAPIRoot+`/working-environments/([^/]+)/svms`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		if _, ok := m.workingEnvironments[args[0]]; !ok {
+			m.notFound(w, "working environment", args[0])
+			return
+		}
+		writeMockJSON(w, http.StatusOK, m.svms[args[0]])
+	})
+	m.handle("POST", mockOCCMAPIRoot+`/working-environments/([^/]+)/svm`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		name := readMockJSON(r)["svmName"].(string)
+		for _, svm := range m.svms[args[0]] {
+			if svm["name"] == name {
+				writeMockJSON(w, http.StatusConflict, map[string]interface{}{"message": fmt.Sprintf("SVM %s already exists", name)})
+				return
+			}
+		}
+		m.svms[args[0]] = append(m.svms[args[0]], m.newSVM(name))
+		m.completeTask(w, nil)
+	})
+	m.handle("PUT", mockOCCMAPIRoot+`/working-environments/([^/]+)/svm`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		params := readMockJSON(r)
+		for _, svm := range m.svms[args[0]] {
+			if svm["name"] == params["svmName"] {
+				svm["name"] = params["svmNewName"]
+				writeMockJSON(w, http.StatusOK, map[string]interface{}{})
+				return
+			}
+		}
+		m.notFound(w, "SVM", fmt.Sprint(params["svmName"]))
+	})
+	m.handle("DELETE", mockOCCMAPIRoot+`/working-environments/([^/]+)/svm/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for i, svm := range m.svms[args[0]] {
+			if svm["name"] == args[1] {
+				m.svms[args[0]] = append(m.svms[args[0]][:i], m.svms[args[0]][i+1:]...)
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "SVM", args[1])
+	})
+
+	// Cloud Backup, keyed by working environment. Policies and volume backups need Cloud Backup to be active.
+	const backupRoot = `/account/[^/]+/providers/cloudmanager_cbs/api/v3/backup/working-environment/([^/]+)`
+	notActive := func(w http.ResponseWriter, id string) bool {
+		if m.backups[id] == nil {
+			writeMockJSON(w, http.StatusBadRequest, map[string]interface{}{"message": fmt.Sprintf("backup is not active on %s", id)})
+			return true
+		}
+		return false
+	}
+	m.handle("GET", backupRoot, func(w http.ResponseWriter, r *http.Request, args []string) {
+		if m.backups[args[0]] == nil {
+			m.notFound(w, "backup of", args[0])
+			return
+		}
+		writeMockJSON(w, http.StatusOK, m.backups[args[0]])
+	})
+	m.handle("POST", backupRoot, func(w http.ResponseWriter, r *http.Request, args []string) {
+		if m.backups[args[0]] != nil {
+			writeMockJSON(w, http.StatusConflict, map[string]interface{}{"message": fmt.Sprintf("backup is already active on %s", args[0])})
+			return
+		}
+		activation := readMockJSON(r)
+		if activation["bucket"] == nil {
+			activation["bucket"] = "netapp-backup-" + args[0]
+		}
+		activation["status"] = "ACTIVE"
+		m.backups[args[0]] = activation
+		m.completeTask(w, nil)
+	})
+	m.handle("PUT", backupRoot, func(w http.ResponseWriter, r *http.Request, args []string) {
+		if notActive(w, args[0]) {
+			return
+		}
+		for k, v := range readMockJSON(r) {
+			m.backups[args[0]][k] = v
+		}
+		m.completeTask(w, nil)
+	})
+	m.handle("DELETE", backupRoot, func(w http.ResponseWriter, r *http.Request, args []string) {
+		if notActive(w, args[0]) {
+			return
+		}
+		delete(m.backups, args[0])
+		m.completeTask(w, nil)
+	})
+	m.handle("GET", backupRoot+`/policy`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		policies := m.backupPolicies[args[0]]
+		if policies == nil {
+			policies = []map[string]interface{}{}
+		}
+		writeMockJSON(w, http.StatusOK, policies)
+	})
+	m.handle("POST", backupRoot+`/policy`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		if notActive(w, args[0]) {
+			return
+		}
+		m.backupPolicies[args[0]] = append(m.backupPolicies[args[0]], readMockJSON(r))
+		m.completeTask(w, nil)
+	})
+	m.handle("PUT", backupRoot+`/policy/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for _, policy := range m.backupPolicies[args[0]] {
+			if policy["name"] == args[1] {
+				for k, v := range readMockJSON(r) {
+					policy[k] = v
+				}
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "backup policy", args[1])
+	})
+	m.handle("DELETE", backupRoot+`/policy/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for _, backup := range m.volumeBackups[args[0]] {
+			if backup["policyName"] == args[1] {
+				writeMockJSON(w, http.StatusBadRequest, map[string]interface{}{"message": fmt.Sprintf("backup policy %s is in use", args[1])})
+				return
+			}
+		}
+		for i, policy := range m.backupPolicies[args[0]] {
+			if policy["name"] == args[1] {
+				m.backupPolicies[args[0]] = append(m.backupPolicies[args[0]][:i], m.backupPolicies[args[0]][i+1:]...)
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "backup policy", args[1])
+	})
+	m.handle("GET", backupRoot+`/volume`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		backups := m.volumeBackups[args[0]]
+		if backups == nil {
+			backups = []map[string]interface{}{}
+		}
+		writeMockJSON(w, http.StatusOK, backups)
+	})
+	m.handle("POST", backupRoot+`/volume`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		if notActive(w, args[0]) {
+			return
+		}
+		backup := readMockJSON(r)
+		if m.findVolume(args[0], backup["svmName"].(string), backup["volumeName"].(string)) == nil {
+			m.notFound(w, "volume", backup["volumeName"].(string))
+			return
+		}
+		backup["backupStatus"] = "Active"
+		m.volumeBackups[args[0]] = append(m.volumeBackups[args[0]], backup)
+		m.completeTask(w, nil)
+	})
+	m.handle("PUT", backupRoot+`/volume/([^/]+)/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for _, backup := range m.volumeBackups[args[0]] {
+			if backup["svmName"] == args[1] && backup["volumeName"] == args[2] {
+				backup["policyName"] = readMockJSON(r)["policyName"]
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "backup of volume", args[2])
+	})
+	m.handle("DELETE", backupRoot+`/volume/([^/]+)/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for i, backup := range m.volumeBackups[args[0]] {
+			if backup["svmName"] == args[1] && backup["volumeName"] == args[2] {
+				m.volumeBackups[args[0]] = append(m.volumeBackups[args[0]][:i], m.volumeBackups[args[0]][i+1:]...)
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "backup of volume", args[2])
+	})
+
+	// NSS accounts
+	m.handle("POST", `/occm/api/accounts/nss`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		params := readMockJSON(r)
+		keys, _ := params["providerKeys"].(map[string]interface{})
+		account := map[string]interface{}{"publicId": m.newID("nss-"), "nssUserName": keys["nssUserName"]}
+		m.nssAccounts = append(m.nssAccounts, account)
+		writeMockJSON(w, http.StatusOK, account)
+	})
+	m.handle("GET", `/occm/api/accounts`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		accounts := m.nssAccounts
+		if accounts == nil {
+			accounts = []map[string]interface{}{}
+		}
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{"nssAccounts": accounts})
+	})
+	m.handle("DELETE", `/occm/api/accounts/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for i, account := range m.nssAccounts {
+			if account["publicId"] == args[0] {
+				m.nssAccounts = append(m.nssAccounts[:i], m.nssAccounts[i+1:]...)
+				writeMockJSON(w, http.StatusOK, map[string]interface{}{})
+				return
+			}
+		}
+		m.notFound(w, "account", args[0])
+	})
+
+	// SnapMirror relationships, keyed by the destination volume
+	m.handle("GET", `/occm/api/replication/status/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		result := []map[string]interface{}{}
+		for _, sm := range m.snapMirrors {
+			if sm["source"].(map[string]interface{})["workingEnvironmentId"] == args[0] {
+				result = append(result, sm)
+			}
+		}
+		writeMockJSON(w, http.StatusOK, result)
+	})
+	m.handle("PUT", `/occm/api/replication/([^/]+)/([^/]+)/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		sm := m.findSnapMirror(args[0], args[1], args[2])
+		if sm == nil {
+			m.notFound(w, "replication", args[2])
+			return
+		}
+		params := readMockJSON(r)
+		sm["policyName"] = params["policyName"]
+		sm["scheduleName"] = params["scheduleName"]
+		sm["maxTransferRate"] = map[string]interface{}{"size": params["maxTransferRate"], "unit": "KB"}
+		m.completeTask(w, nil)
+	})
+	// the relationship states follow ONTAP: break needs a quiesced relationship, resync a broken one.
+	// A reverse resync resyncs, or adds, the relationship going back from its destination to its source,
+	// the one it is run on stays broken off.
+	m.handle("POST", `/occm/api/replication/(quiesce|resume|break|resync|reverse-resync)/([^/]+)/([^/]+)/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		sm := m.findSnapMirror(args[1], args[2], args[3])
+		if sm == nil {
+			m.notFound(w, "replication", args[3])
+			return
+		}
+		invalid := func() {
+			writeMockJSON(w, http.StatusBadRequest, map[string]interface{}{"message": fmt.Sprintf("cannot %s a %s %s relationship", args[0], sm["mirrorState"], sm["relationshipStatus"])})
+		}
+		switch args[0] {
+		case "quiesce":
+			if sm["mirrorState"] != "snapmirrored" {
+				invalid()
+				return
+			}
+			sm["relationshipStatus"] = "quiesced"
+		case "resume":
+			if sm["relationshipStatus"] != "quiesced" {
+				invalid()
+				return
+			}
+			sm["relationshipStatus"] = "idle"
+		case "break":
+			if sm["relationshipStatus"] != "quiesced" {
+				invalid()
+				return
+			}
+			sm["mirrorState"] = "broken_off"
+			sm["relationshipStatus"] = "idle"
+		case "resync":
+			if sm["mirrorState"] != "broken_off" {
+				invalid()
+				return
+			}
+			sm["mirrorState"] = "snapmirrored"
+		case "reverse-resync":
+			if sm["mirrorState"] != "broken_off" {
+				invalid()
+				return
+			}
+			source := sm["source"].(map[string]interface{})
+			reversed := m.findSnapMirror(source["workingEnvironmentId"].(string), source["svmName"].(string), source["volumeName"].(string))
+			if reversed == nil {
+				reversed = map[string]interface{}{}
+				for k, v := range sm {
+					reversed[k] = v
+				}
+				reversed["source"], reversed["destination"] = sm["destination"], sm["source"]
+				m.snapMirrors = append(m.snapMirrors, reversed)
+			}
+			reversed["mirrorState"] = "snapmirrored"
+			reversed["relationshipStatus"] = "idle"
+		}
+		m.completeTask(w, nil)
+	})
+	m.handle("DELETE", `/occm/api/replication/([^/]+)/([^/]+)/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		for i, sm := range m.snapMirrors {
+			dest := sm["destination"].(map[string]interface{})
+			if dest["workingEnvironmentId"] == args[0] && dest["svmName"] == args[1] && dest["volumeName"] == args[2] {
+				m.snapMirrors = append(m.snapMirrors[:i], m.snapMirrors[i+1:]...)
+				m.completeTask(w, nil)
+				return
+			}
+		}
+		m.notFound(w, "replication", args[2])
+	})
+
+	// FSx for ONTAP, the file systems being working environments with a "fs-" prefix
+	m.handle("GET", `/fsx-ontap/working-environments/([^/]+)/([^/]+)`, func(w http.ResponseWriter, r *http.Request, args []string) {
+		we, ok := m.workingEnvironments[args[1]]
+		if !ok || we.TenantID != args[0] {
+			m.notFound(w, "file system", args[1])
+			return
+		}
+		writeMockJSON(w, http.StatusOK, map[string]interface{}{
+			"id":              we.PublicID,
+			"name":            we.Name,
+			"providerDetails": map[string]interface{}{"status": map[string]interface{}{"status": "ON", "lifecycle": "AVAILABLE"}},
+		})
+	})
+}
+
+// newSVM returns a running SVM with a management LIF and a data LIF per protocol
+func (m *mockOCCM) newSVM(name string) map[string]interface{} {
+	m.nextID++
+	return map[string]interface{}{
+		"name":          name,
+		"state":         "running",
+		"managementLif": map[string]interface{}{"name": name + "_mgmt", "ip": fmt.Sprintf("10.0.0.%d", m.nextID)},
+		"dataLifs": []interface{}{
+			map[string]interface{}{"name": name + "_data", "ip": fmt.Sprintf("10.0.1.%d", m.nextID), "nodeName": "node-01", "dataProtocols": []interface{}{"nfs", "cifs"}},
+			map[string]interface{}{"name": name + "_iscsi", "ip": fmt.Sprintf("10.0.2.%d", m.nextID), "nodeName": "node-01", "dataProtocols": []interface{}{"iscsi"}},
+		},
+	}
+}
+
+func (m *mockOCCM) findVolume(id string, svm string, name string) map[string]interface{} {
+	for _, vol := range m.volumes[id] {
+		if vol["svmName"] == svm && vol["name"] == name {
+			return vol
+		}
+	}
+	return nil
+}
+
+func (m *mockOCCM) findSnapMirror(destinationID string, destinationSvm string, destinationVolume string) map[string]interface{} {
+	for _, sm := range m.snapMirrors {
+		dest := sm["destination"].(map[string]interface{})
+		if dest["workingEnvironmentId"] == destinationID && dest["svmName"] == destinationSvm && dest["volumeName"] == destinationVolume {
+			return sm
+		}
+	}
+	return nil
+}
+
+// testResourceDataUpdate returns the ResourceData to update the resource whose state is in d to the raw configuration
+func testResourceDataUpdate(t *testing.T, r *schema.Resource, d *schema.ResourceData, raw map[string]interface{}, meta interface{}) *schema.ResourceData {
+	state := d.State()
+	diff, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(raw), meta)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	updated, err := schema.InternalMap(r.Schema).Data(state, diff)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	return updated
+}
@@ -1,6 +1,7 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -18,26 +19,21 @@ type nssAccountCredentials struct {
 	Password string `structs:"nssPassword"`
 }
 
-func (c *Client) createNssAccount(acc nssAccountRequest, clientID string) (map[string]interface{}, error) {
+func (c *Client) createNssAccount(ctx context.Context, acc nssAccountRequest, clientID string) (map[string]interface{}, error) {
 	hostType := "CloudManagerHost"
 	baseURL := fmt.Sprint("/occm/api/accounts/nss")
 	param := structs.Map(acc)
-	if c.Token == "" {
-		accesTokenResult, err := c.getAccessToken()
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			return nil, err
 		}
-		c.Token = accesTokenResult.Token
 	}
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, param, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "POST", baseURL, param, true, hostType, clientID)
 	if err != nil {
 		log.Print("createNssAccount request failed ", statusCode)
 		return nil, err
 	}
-	responseError := apiResponseChecker(statusCode, response, "createNssAccount")
-	if responseError != nil {
-		return nil, responseError
-	}
 	var res map[string]interface{}
 	if err := json.Unmarshal(response, &res); err != nil {
 		return nil, err
@@ -45,26 +41,20 @@ func (c *Client) createNssAccount(acc nssAccountRequest, clientID string) (map[s
 	return res, nil
 }
 
-func (c *Client) getNssAccount(nssUserName string, clientID string) (map[string]interface{}, error) {
+func (c *Client) getNssAccount(ctx context.Context, nssUserName string, clientID string) (map[string]interface{}, error) {
 	hostType := "CloudManagerHost"
 	baseURL := fmt.Sprint("/occm/api/accounts")
-	if c.Token == "" {
-		accesTokenResult, err := c.getAccessToken()
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			return nil, err
 		}
-		c.Token = accesTokenResult.Token
 	}
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getNssAccount request failed ", statusCode)
 		return nil, err
 	}
-	responseError := apiResponseChecker(statusCode, response, "getNssAccount")
-
-	if responseError != nil {
-		return nil, responseError
-	}
 	var allAccounts map[string]interface{}
 	if err := json.Unmarshal(response, &allAccounts); err != nil {
 		return nil, err
@@ -79,24 +69,19 @@ func (c *Client) getNssAccount(nssUserName string, clientID string) (map[string]
 	return nil, nil
 }
 
-func (c *Client) deleteNssAccount(id string, clientID string) error {
+func (c *Client) deleteNssAccount(ctx context.Context, id string, clientID string) error {
 	hostType := "CloudManagerHost"
 	baseURL := fmt.Sprintf("/occm/api/accounts/%s", id)
-	if c.Token == "" {
-		accesTokenResult, err := c.getAccessToken()
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			return err
 		}
-		c.Token = accesTokenResult.Token
 	}
-	statusCode, response, _, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("deleteNssAccount request failed ", statusCode)
 		return err
 	}
-	responseError := apiResponseChecker(statusCode, response, "deleteNssAccount")
-	if responseError != nil {
-		return responseError
-	}
 	return nil
 }
@@ -1,6 +1,7 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -102,7 +103,8 @@ type accesTokenRequest struct {
 
 // accesTokenResult to get token for the AUTH
 type accesTokenResult struct {
-	Token string `json:"access_token"`
+	Token     string `json:"access_token"`
+	ExpiresIn int    `json:"expires_in"`
 }
 
 // registerAgentTOServiceRequest input to register agent
@@ -161,15 +163,14 @@ type occmAgent struct {
 	AgentID string `json:"agentId"`
 }
 
-func (c *Client) getUserData(registerAgentTOService registerAgentTOServiceRequest, proxyCertificates []string, clientID string) (string, string, error) {
-	accesTokenResult, err := c.getAccessToken()
+func (c *Client) getUserData(ctx context.Context, registerAgentTOService registerAgentTOServiceRequest, proxyCertificates []string, clientID string) (string, string, error) {
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		return "", "", err
 	}
-	c.Token = accesTokenResult.Token
 
 	if c.AccountID == "" {
-		accountID, err := c.getAccount(clientID)
+		accountID, err := c.getAccount(ctx, clientID)
 		if err != nil {
 			return "", "", err
 		}
@@ -179,7 +180,7 @@ func (c *Client) getUserData(registerAgentTOService registerAgentTOServiceReques
 		registerAgentTOService.AccountID = c.AccountID
 	}
 
-	userDataRespone, err := c.registerAgentTOService(registerAgentTOService, clientID)
+	userDataRespone, err := c.registerAgentTOService(ctx, registerAgentTOService, clientID)
 	if err != nil {
 		return "", "", err
 	}
@@ -194,7 +195,8 @@ func (c *Client) getUserData(registerAgentTOService registerAgentTOServiceReques
 	return userData, newClientID, nil
 }
 
-func (c *Client) getAccessToken() (accesTokenResult, error) {
+// requestAccessToken asks the auth host for a new access token, bypassing the cache
+func (c *Client) requestAccessToken(ctx context.Context) (accesTokenResult, error) {
 
 	log.Print("getAccessToken")
 	var hostType string
@@ -217,17 +219,12 @@ func (c *Client) getAccessToken() (accesTokenResult, error) {
 	accesTokenRequest.Audience = c.Audience
 
 	params := structs.Map(accesTokenRequest)
-	statusCode, response, _, err := c.CallAPIMethod("POST", "", params, "", hostType, "")
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "POST", "", params, false, hostType, "")
 	if err != nil {
 		log.Print("getAccessToken request failed ", statusCode)
 		return accesTokenResult{}, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "getAccessToken")
-	if responseError != nil {
-		return accesTokenResult{}, responseError
-	}
-
 	var result accesTokenResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getAccessToken ", err)
@@ -237,12 +234,12 @@ func (c *Client) getAccessToken() (accesTokenResult, error) {
 	return result, nil
 }
 
-func (c *Client) registerAgentTOService(registerAgentTOServiceRequest registerAgentTOServiceRequest, clientID string) (createUserData, error) {
+func (c *Client) registerAgentTOService(ctx context.Context, registerAgentTOServiceRequest registerAgentTOServiceRequest, clientID string) (createUserData, error) {
 
 	baseURL := "/agents-mgmt/connector-setup"
 	hostType := "CloudManagerHost"
 
-	vpcID, err := c.CallVPCGet(registerAgentTOServiceRequest.Placement.Subnet, registerAgentTOServiceRequest.Placement.Region)
+	vpcID, err := c.CallVPCGet(ctx, registerAgentTOServiceRequest.Placement.Subnet, registerAgentTOServiceRequest.Placement.Region)
 	if err != nil {
 		log.Print("CallVPCGet request failed")
 		return createUserData{}, err
@@ -252,17 +249,12 @@ func (c *Client) registerAgentTOService(registerAgentTOServiceRequest registerAg
 	registerAgentTOServiceRequest.Placement.Provider = "AWS"
 
 	params := structs.Map(registerAgentTOServiceRequest)
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
 	if err != nil {
 		log.Print("registerAgentTOService request failed ", statusCode)
 		return createUserData{}, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "registerAgentTOService")
-	if responseError != nil {
-		return createUserData{}, responseError
-	}
-
 	var result createUserData
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from registerAgentTOService ", err)
@@ -272,7 +264,7 @@ func (c *Client) registerAgentTOService(registerAgentTOServiceRequest registerAg
 	return result, nil
 }
 
-func (c *Client) getAccount(clientID string) (string, error) {
+func (c *Client) getAccount(ctx context.Context, clientID string) (string, error) {
 
 	log.Print("getAccount")
 
@@ -280,17 +272,12 @@ func (c *Client) getAccount(clientID string) (string, error) {
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getAccount request failed ", statusCode)
 		return "", err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "getAccount")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	var result []accountIDResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getAccount ", err)
@@ -299,7 +286,7 @@ func (c *Client) getAccount(clientID string) (string, error) {
 
 	// when no account exists, create
 	if len(result) == 0 {
-		accountID, err := c.createAccount(clientID)
+		accountID, err := c.createAccount(ctx, clientID)
 		if err != nil {
 			log.Print("createAccount request failed")
 			return "", err
@@ -310,7 +297,7 @@ func (c *Client) getAccount(clientID string) (string, error) {
 	return result[0].AccountID, nil
 }
 
-func (c *Client) createAccount(clientID string) (string, error) {
+func (c *Client) createAccount(ctx context.Context, clientID string) (string, error) {
 
 	log.Print("createAccount")
 
@@ -318,17 +305,12 @@ func (c *Client) createAccount(clientID string) (string, error) {
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "POST", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("createAccount request failed ", statusCode)
 		return "", err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "createAccount")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	var result accountIDResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from createAccount ", err)
@@ -338,19 +320,21 @@ func (c *Client) createAccount(clientID string) (string, error) {
 	return result.AccountID, nil
 }
 
-func (c *Client) createAWSInstance(occmDetails createOCCMDetails, clientID string) (string, error) {
+func (c *Client) createAWSInstance(ctx context.Context, occmDetails createOCCMDetails, clientID string) (string, error) {
 
-	instanceID, err := c.CallAWSInstanceCreate(occmDetails)
+	instanceID, err := c.CallAWSInstanceCreate(ctx, occmDetails)
 	if err != nil {
 		return "", err
 	}
 
 	log.Print("Sleep for 2 minutes")
-	time.Sleep(time.Duration(120) * time.Second)
+	if err := sleepWithContext(ctx, time.Duration(120)*time.Second); err != nil {
+		return "", err
+	}
 
 	retries := 26
 	for {
-		occmResp, err := c.checkOCCMStatus(clientID)
+		occmResp, err := c.checkOCCMStatus(ctx, clientID)
 		if err != nil {
 			return "", err
 		}
@@ -361,7 +345,9 @@ func (c *Client) createAWSInstance(occmDetails createOCCMDetails, clientID strin
 				log.Print("Taking too long for status to be active")
 				return "", fmt.Errorf("Taking too long for OCCM agent to be active or not properly setup")
 			}
-			time.Sleep(time.Duration(30) * time.Second)
+			if err := sleepWithContext(ctx, time.Duration(30)*time.Second); err != nil {
+				return "", err
+			}
 			retries--
 		}
 	}
@@ -369,11 +355,11 @@ func (c *Client) createAWSInstance(occmDetails createOCCMDetails, clientID strin
 	return instanceID, nil
 }
 
-func (c *Client) getAWSInstance(occmDetails createOCCMDetails, id string) (ec2.Instance, error) {
+func (c *Client) getAWSInstance(ctx context.Context, occmDetails createOCCMDetails, id string) (ec2.Instance, error) {
 
 	log.Print("getAWSInstance")
 
-	res, err := c.CallAWSInstanceGet(occmDetails)
+	res, err := c.CallAWSInstanceGet(ctx, occmDetails)
 	returnOCCM := createOCCMDetails{}
 	if err != nil {
 		return ec2.Instance{}, err
@@ -390,11 +376,11 @@ func (c *Client) getAWSInstance(occmDetails createOCCMDetails, id string) (ec2.I
 	return ec2.Instance{}, nil
 }
 
-func (c *Client) createOCCM(occmDetails createOCCMDetails, proxyCertificates []string, clientID string) (OCCMMResult, error) {
+func (c *Client) createOCCM(ctx context.Context, occmDetails createOCCMDetails, proxyCertificates []string, clientID string) (OCCMMResult, error) {
 	log.Printf("createOCCM %s %s", occmDetails.Name, clientID)
 	if occmDetails.AMI == "" {
 
-		ami, err := c.CallAMIGet(occmDetails)
+		ami, err := c.CallAMIGet(ctx, occmDetails)
 		if err != nil {
 			return OCCMMResult{}, err
 		}
@@ -418,7 +404,7 @@ func (c *Client) createOCCM(occmDetails createOCCMDetails, proxyCertificates []s
 		registerAgentTOService.Extra.Proxy.ProxyPassword = occmDetails.ProxyPassword
 	}
 
-	userData, newClientID, err := c.getUserData(registerAgentTOService, proxyCertificates, clientID)
+	userData, newClientID, err := c.getUserData(ctx, registerAgentTOService, proxyCertificates, clientID)
 	if err != nil {
 		return OCCMMResult{}, err
 	}
@@ -426,7 +412,7 @@ func (c *Client) createOCCM(occmDetails createOCCMDetails, proxyCertificates []s
 	var result OCCMMResult
 	result.ClientID = newClientID
 	result.AccountID = c.AccountID
-	instanceID, err := c.createAWSInstance(occmDetails, newClientID)
+	instanceID, err := c.createAWSInstance(ctx, occmDetails, newClientID)
 	if err != nil {
 		return OCCMMResult{}, err
 	}
@@ -436,21 +422,16 @@ func (c *Client) createOCCM(occmDetails createOCCMDetails, proxyCertificates []s
 	return result, nil
 }
 
-func (c *Client) checkOCCMStatus(clientID string) (occmAgent, error) {
+func (c *Client) checkOCCMStatus(ctx context.Context, clientID string) (occmAgent, error) {
 	log.Print("checkOCCMStatus client id: ", clientID)
 	baseURL := fmt.Sprintf("/agents-mgmt/agent/%sclients", clientID)
 
 	hostType := "CloudManagerHost"
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("checkOCCMStatus request failed ", statusCode)
 		return occmAgent{}, err
 	}
-	responseError := apiResponseChecker(statusCode, response, "checkOCCMStatus")
-	if responseError != nil {
-		return occmAgent{}, responseError
-	}
-
 	var result listOCCMResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from checkOCCMStatus ", err)
@@ -460,45 +441,41 @@ func (c *Client) checkOCCMStatus(clientID string) (occmAgent, error) {
 	return result.Agent, nil
 }
 
-func (c *Client) callOCCMDelete(clientID string) error {
+func (c *Client) callOCCMDelete(ctx context.Context, clientID string) error {
 
 	baseURL := fmt.Sprintf("/agents-mgmt/agent/%sclients", clientID)
 
 	hostType := "CloudManagerHost"
 
-	statusCode, response, _, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("callOCCMDelete request failed ", statusCode)
 		return err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "callOCCMDelete")
-	if responseError != nil {
-		return responseError
-	}
-
 	return nil
 }
 
-func (c *Client) deleteOCCM(request deleteOCCMDetails, clientID string) error {
+func (c *Client) deleteOCCM(ctx context.Context, request deleteOCCMDetails, clientID string) error {
 
-	err := c.CallAWSInstanceTerminate(request)
+	err := c.CallAWSInstanceTerminate(ctx, request)
 	if err != nil {
 		return err
 	}
 
 	log.Print("Sleep for 30 seconds")
-	time.Sleep(time.Duration(30) * time.Second)
+	if err := sleepWithContext(ctx, time.Duration(30)*time.Second); err != nil {
+		return err
+	}
 
-	accesTokenResult, err := c.getAccessToken()
-	c.Token = accesTokenResult.Token
+	_, err = c.getAccessToken(ctx)
 	if err != nil {
 		return err
 	}
 
 	retries := 30
 	for {
-		occmResp, err := c.checkOCCMStatus(clientID)
+		occmResp, err := c.checkOCCMStatus(ctx, clientID)
 		if err != nil {
 			return err
 		}
@@ -509,12 +486,14 @@ func (c *Client) deleteOCCM(request deleteOCCMDetails, clientID string) error {
 				log.Print("Taking too long for instance to finish terminating")
 				return fmt.Errorf("Taking too long for instance to finish terminating")
 			}
-			time.Sleep(time.Duration(10) * time.Second)
+			if err := sleepWithContext(ctx, time.Duration(10)*time.Second); err != nil {
+				return err
+			}
 			retries--
 		}
 	}
 
-	if err := c.callOCCMDelete(clientID); err != nil {
+	if err := c.callOCCMDelete(ctx, clientID); err != nil {
 		return err
 	}
 
@@ -522,12 +501,12 @@ func (c *Client) deleteOCCM(request deleteOCCMDetails, clientID string) error {
 }
 
 // only tags can be updated. Other update functionalities to be added.
-func (c *Client) updateOCCM(occmDetails createOCCMDetails, proxyCertificates []string, deleteTags []userTags, addModifyTags []userTags, clientID string) error {
+func (c *Client) updateOCCM(ctx context.Context, occmDetails createOCCMDetails, proxyCertificates []string, deleteTags []userTags, addModifyTags []userTags, clientID string) error {
 
 	log.Print("updating OCCM")
 	if occmDetails.AMI == "" {
 
-		ami, err := c.CallAMIGet(occmDetails)
+		ami, err := c.CallAMIGet(ctx, occmDetails)
 		if err != nil {
 			return err
 		}
@@ -551,21 +530,21 @@ func (c *Client) updateOCCM(occmDetails createOCCMDetails, proxyCertificates []s
 		registerAgentTOService.Extra.Proxy.ProxyPassword = occmDetails.ProxyPassword
 	}
 
-	userData, _, err := c.getUserData(registerAgentTOService, proxyCertificates, clientID)
+	userData, _, err := c.getUserData(ctx, registerAgentTOService, proxyCertificates, clientID)
 	if err != nil {
 		return err
 	}
 	c.UserData = userData
 	if len(addModifyTags) > 0 {
 		occmDetails.AwsTags = addModifyTags
-		err = c.CallAWSTagCreate(occmDetails)
+		err = c.CallAWSTagCreate(ctx, occmDetails)
 		if err != nil {
 			return err
 		}
 	}
 	if len(deleteTags) > 0 {
 		occmDetails.AwsTags = deleteTags
-		err = c.CallAWSTagDelete(occmDetails)
+		err = c.CallAWSTagDelete(ctx, occmDetails)
 		if err != nil {
 			return err
 		}
@@ -574,25 +553,20 @@ func (c *Client) updateOCCM(occmDetails createOCCMDetails, proxyCertificates []s
 	return nil
 }
 
-func (c *Client) getCompany(clientID string) (string, error) {
-	if c.Token == "" {
-		accesTokenResult, err := c.getAccessToken()
+func (c *Client) getCompany(ctx context.Context, clientID string) (string, error) {
+	if !c.isAuthenticated() {
+		_, err := c.getAccessToken(ctx)
 		if err != nil {
 			return "", err
 		}
-		c.Token = accesTokenResult.Token
 	}
 	hostType := "CloudManagerHost"
 	baseURL := "/occm/api/occm/system/about"
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getCompany request failed ", statusCode)
 		return "", err
 	}
-	responseError := apiResponseChecker(statusCode, response, "getCompany")
-	if responseError != nil {
-		return "", responseError
-	}
 	var f interface{}
 	json.Unmarshal(response, &f)
 	m := f.(map[string]interface{})
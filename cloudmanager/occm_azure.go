@@ -1,6 +1,7 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -10,16 +11,14 @@ import (
 	"github.com/fatih/structs"
 )
 
-func (c *Client) getCustomData(registerAgentTOService registerAgentTOServiceRequest, proxyCertificates []string, clientID string) (string, string, error) {
-	accesTokenResult, err := c.getAccessToken()
+func (c *Client) getCustomData(ctx context.Context, registerAgentTOService registerAgentTOServiceRequest, proxyCertificates []string, clientID string) (string, string, error) {
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		return "", "", err
 	}
-	log.Print("getAccessToken  ", accesTokenResult.Token)
-	c.Token = accesTokenResult.Token
 
 	if c.AccountID == "" {
-		accountID, err := c.getAccount(clientID)
+		accountID, err := c.getAccount(ctx, clientID)
 		if err != nil {
 			return "", "", err
 		}
@@ -29,7 +28,7 @@ func (c *Client) getCustomData(registerAgentTOService registerAgentTOServiceRequ
 		registerAgentTOService.AccountID = c.AccountID
 	}
 
-	userDataRespone, err := c.registerAgentTOServiceForAzure(registerAgentTOService, clientID)
+	userDataRespone, err := c.registerAgentTOServiceForAzure(ctx, registerAgentTOService, clientID)
 	if err != nil {
 		return "", "", err
 	}
@@ -45,7 +44,7 @@ func (c *Client) getCustomData(registerAgentTOService registerAgentTOServiceRequ
 	return userData, newClientID, nil
 }
 
-func (c *Client) registerAgentTOServiceForAzure(registerAgentTOServiceRequest registerAgentTOServiceRequest, clientID string) (createUserData, error) {
+func (c *Client) registerAgentTOServiceForAzure(ctx context.Context, registerAgentTOServiceRequest registerAgentTOServiceRequest, clientID string) (createUserData, error) {
 
 	baseURL := "/agents-mgmt/connector-setup"
 	hostType := "CloudManagerHost"
@@ -53,17 +52,12 @@ func (c *Client) registerAgentTOServiceForAzure(registerAgentTOServiceRequest re
 	registerAgentTOServiceRequest.Placement.Provider = "AZURE"
 
 	params := structs.Map(registerAgentTOServiceRequest)
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
 	if err != nil {
 		log.Print("registerAgentTOService request failed ", statusCode)
 		return createUserData{}, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "registerAgentTOService")
-	if responseError != nil {
-		return createUserData{}, responseError
-	}
-
 	var result createUserData
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from registerAgentTOService ", err)
@@ -73,7 +67,7 @@ func (c *Client) registerAgentTOServiceForAzure(registerAgentTOServiceRequest re
 	return result, nil
 }
 
-func (c *Client) createOCCMAzure(occmDetails createOCCMDetails, proxyCertificates []string, clientID string) (OCCMMResult, error) {
+func (c *Client) createOCCMAzure(ctx context.Context, occmDetails createOCCMDetails, proxyCertificates []string, clientID string) (OCCMMResult, error) {
 	log.Print("createOCCMAzure")
 	var registerAgentTOService registerAgentTOServiceRequest
 	registerAgentTOService.Name = occmDetails.Name
@@ -111,7 +105,7 @@ func (c *Client) createOCCMAzure(occmDetails createOCCMDetails, proxyCertificate
 	}
 	log.Print("createOCCMAzure SubnetID: ")
 	log.Print(registerAgentTOService.Placement.Subnet)
-	userData, newClientID, err := c.getCustomData(registerAgentTOService, proxyCertificates, clientID)
+	userData, newClientID, err := c.getCustomData(ctx, registerAgentTOService, proxyCertificates, clientID)
 	if err != nil {
 		return OCCMMResult{}, err
 	}
@@ -124,18 +118,20 @@ func (c *Client) createOCCMAzure(occmDetails createOCCMDetails, proxyCertificate
 	result.ClientID = newClientID
 	result.AccountID = c.AccountID
 
-	principalID, err := c.CallDeployAzureVM(occmDetails)
+	principalID, err := c.CallDeployAzureVM(ctx, occmDetails)
 	if err != nil {
 		return OCCMMResult{}, err
 	}
 
 	result.PrincipalID = principalID
 	log.Print("Sleep for 2 minutes")
-	time.Sleep(time.Duration(120) * time.Second)
+	if err := sleepWithContext(ctx, time.Duration(120)*time.Second); err != nil {
+		return OCCMMResult{}, err
+	}
 
 	retries := 26
 	for {
-		occmResp, err := c.checkOCCMStatus(newClientID)
+		occmResp, err := c.checkOCCMStatus(ctx, newClientID)
 		if err != nil {
 			return OCCMMResult{}, err
 		}
@@ -146,7 +142,9 @@ func (c *Client) createOCCMAzure(occmDetails createOCCMDetails, proxyCertificate
 				log.Print("Taking too long for status to be active")
 				return OCCMMResult{}, fmt.Errorf("Taking too long for OCCM agent to be active or not properly setup")
 			}
-			time.Sleep(time.Duration(30) * time.Second)
+			if err := sleepWithContext(ctx, time.Duration(30)*time.Second); err != nil {
+				return OCCMMResult{}, err
+			}
 			retries--
 		}
 	}
@@ -154,11 +152,11 @@ func (c *Client) createOCCMAzure(occmDetails createOCCMDetails, proxyCertificate
 	return result, nil
 }
 
-func (c *Client) getdeployAzureVM(occmDetails createOCCMDetails, id string) (string, error) {
+func (c *Client) getdeployAzureVM(ctx context.Context, occmDetails createOCCMDetails, id string) (string, error) {
 
 	log.Print("getdeployAzureVM")
 
-	res, err := c.CallGetAzureVM(occmDetails)
+	res, err := c.CallGetAzureVM(ctx, occmDetails)
 	if err != nil {
 		return "", err
 	}
@@ -170,25 +168,26 @@ func (c *Client) getdeployAzureVM(occmDetails createOCCMDetails, id string) (str
 	return "", nil
 }
 
-func (c *Client) deleteOCCMAzure(request deleteOCCMDetails, clientID string) error {
+func (c *Client) deleteOCCMAzure(ctx context.Context, request deleteOCCMDetails, clientID string) error {
 
-	err := c.CallDeleteAzureVM(request)
+	err := c.CallDeleteAzureVM(ctx, request)
 	if err != nil {
 		return err
 	}
 
 	log.Print("Sleep for 30 seconds")
-	time.Sleep(time.Duration(30) * time.Second)
+	if err := sleepWithContext(ctx, time.Duration(30)*time.Second); err != nil {
+		return err
+	}
 
-	accesTokenResult, err := c.getAccessToken()
-	c.Token = accesTokenResult.Token
+	_, err = c.getAccessToken(ctx)
 	if err != nil {
 		return err
 	}
 
 	retries := 30
 	for {
-		occmResp, err := c.checkOCCMStatus(clientID)
+		occmResp, err := c.checkOCCMStatus(ctx, clientID)
 		if err != nil {
 			return err
 		}
@@ -199,12 +198,14 @@ func (c *Client) deleteOCCMAzure(request deleteOCCMDetails, clientID string) err
 				log.Print("Taking too long for instance to finish terminating")
 				return fmt.Errorf("Taking too long for instance to finish terminating")
 			}
-			time.Sleep(time.Duration(10) * time.Second)
+			if err := sleepWithContext(ctx, time.Duration(10)*time.Second); err != nil {
+				return err
+			}
 			retries--
 		}
 	}
 
-	if err := c.callOCCMDelete(clientID); err != nil {
+	if err := c.callOCCMDelete(ctx, clientID); err != nil {
 		return err
 	}
 
@@ -1,6 +1,7 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/base64"
 	"encoding/json"
 	"fmt"
@@ -93,16 +94,14 @@ type serviceAccount struct {
 	Scopes []string `yaml:"scopes"`
 }
 
-func (c *Client) getCustomDataForGCP(registerAgentTOService registerAgentTOServiceRequest, proxyCertificates []string, clientID string) (string, string, error) {
-	accesTokenResult, err := c.getAccessToken()
+func (c *Client) getCustomDataForGCP(ctx context.Context, registerAgentTOService registerAgentTOServiceRequest, proxyCertificates []string, clientID string) (string, string, error) {
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		return "", "", err
 	}
-	log.Print("getAccessToken  ", accesTokenResult.Token)
-	c.Token = accesTokenResult.Token
 
 	if c.AccountID == "" {
-		accountID, err := c.getAccount(clientID)
+		accountID, err := c.getAccount(ctx, clientID)
 		if err != nil {
 			return "", "", err
 		}
@@ -112,7 +111,7 @@ func (c *Client) getCustomDataForGCP(registerAgentTOService registerAgentTOServi
 		registerAgentTOService.AccountID = c.AccountID
 	}
 
-	userDataRespone, err := c.registerAgentTOServiceForGCP(registerAgentTOService, clientID)
+	userDataRespone, err := c.registerAgentTOServiceForGCP(ctx, registerAgentTOService, clientID)
 	if err != nil {
 		return "", "", err
 	}
@@ -128,26 +127,20 @@ func (c *Client) getCustomDataForGCP(registerAgentTOService registerAgentTOServi
 	return userData, newClientID, nil
 }
 
-func (c *Client) registerAgentTOServiceForGCP(registerAgentTOServiceRequest registerAgentTOServiceRequest, clientID string) (createUserData, error) {
+func (c *Client) registerAgentTOServiceForGCP(ctx context.Context, registerAgentTOServiceRequest registerAgentTOServiceRequest, clientID string) (createUserData, error) {
 
 	baseURL := "/agents-mgmt/connector-setup"
 	hostType := "CloudManagerHost"
 
 	registerAgentTOServiceRequest.Placement.Provider = "GCP"
-	log.Print(c.Token)
 
 	params := structs.Map(registerAgentTOServiceRequest)
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
 	if err != nil {
 		log.Print("registerAgentTOService request failed ", statusCode)
 		return createUserData{}, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "registerAgentTOService")
-	if responseError != nil {
-		return createUserData{}, responseError
-	}
-
 	var result createUserData
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from registerAgentTOService ", err)
@@ -157,7 +150,7 @@ func (c *Client) registerAgentTOServiceForGCP(registerAgentTOServiceRequest regi
 	return result, nil
 }
 
-func (c *Client) deployGCPVM(occmDetails createOCCMDetails, proxyCertificates []string, clientID string) (OCCMMResult, error) {
+func (c *Client) deployGCPVM(ctx context.Context, occmDetails createOCCMDetails, proxyCertificates []string, clientID string) (OCCMMResult, error) {
 	var registerAgentTOService registerAgentTOServiceRequest
 	registerAgentTOService.Name = occmDetails.Name
 	registerAgentTOService.Placement.Region = occmDetails.Region
@@ -176,7 +169,7 @@ func (c *Client) deployGCPVM(occmDetails createOCCMDetails, proxyCertificates []
 
 	registerAgentTOService.Placement.Subnet = occmDetails.SubnetID
 
-	userData, newClientID, err := c.getCustomDataForGCP(registerAgentTOService, proxyCertificates, clientID)
+	userData, newClientID, err := c.getCustomDataForGCP(ctx, registerAgentTOService, proxyCertificates, clientID)
 	if err != nil {
 		return OCCMMResult{}, err
 	}
@@ -281,23 +274,20 @@ func (c *Client) deployGCPVM(occmDetails createOCCMDetails, proxyCertificates []
 
 	log.Print("POST")
 	log.Printf("deployGCPVM: call depolyments api base client=%s", newClientID)
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, nil, "", hostType, newClientID)
+	_, _, _, err = c.CallAPIMethod(ctx, "POST", baseURL, nil, false, hostType, newClientID)
 	if err != nil {
 		log.Print("deployGCPVM request failed")
 		return OCCMMResult{}, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "deployGCPVM")
-	if responseError != nil {
-		return OCCMMResult{}, responseError
-	}
-
 	log.Print("Sleep for 2 minutes")
-	time.Sleep(time.Duration(120) * time.Second)
+	if err := sleepWithContext(ctx, time.Duration(120)*time.Second); err != nil {
+		return OCCMMResult{}, err
+	}
 
 	retries := 26
 	for {
-		occmResp, err := c.checkOCCMStatus(newClientID)
+		occmResp, err := c.checkOCCMStatus(ctx, newClientID)
 		if err != nil {
 			return OCCMMResult{}, err
 		}
@@ -308,7 +298,9 @@ func (c *Client) deployGCPVM(occmDetails createOCCMDetails, proxyCertificates []
 				log.Print("Taking too long for status to be active")
 				return OCCMMResult{}, fmt.Errorf("Taking too long for OCCM agent to be active or not properly setup")
 			}
-			time.Sleep(time.Duration(30) * time.Second)
+			if err := sleepWithContext(ctx, time.Duration(30)*time.Second); err != nil {
+				return OCCMMResult{}, err
+			}
 			retries--
 		}
 	}
@@ -316,7 +308,7 @@ func (c *Client) deployGCPVM(occmDetails createOCCMDetails, proxyCertificates []
 	return result, nil
 }
 
-func (c *Client) getdeployGCPVM(occmDetails createOCCMDetails, id string, clientID string) (string, error) {
+func (c *Client) getdeployGCPVM(ctx context.Context, occmDetails createOCCMDetails, id string, clientID string) (string, error) {
 
 	log.Print("getdeployGCPVM")
 
@@ -324,17 +316,12 @@ func (c *Client) getdeployGCPVM(occmDetails createOCCMDetails, id string, client
 	hostType := "GCPDeploymentManager"
 
 	log.Print("GET")
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, "", hostType, clientID)
+	_, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, false, hostType, clientID)
 	if err != nil {
 		log.Print("getdeployGCPVM request failed")
 		return "", err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "getdeployGCPVM")
-	if responseError != nil {
-		return "", responseError
-	}
-
 	var result OCCMMGCPResult
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getVolumeByID")
@@ -348,20 +335,15 @@ func (c *Client) getdeployGCPVM(occmDetails createOCCMDetails, id string, client
 	return "", nil
 }
 
-func (c *Client) getDisk(occmDetails createOCCMDetails, clientID string) (map[string]interface{}, error) {
+func (c *Client) getDisk(ctx context.Context, occmDetails createOCCMDetails, clientID string) (map[string]interface{}, error) {
 	hostType := "GCPCompute"
 	baseURL := fmt.Sprintf("/compute/v1/projects/%s/zones/%s/disks/%s-vm-disk-boot", occmDetails.GCPProject, occmDetails.Zone, occmDetails.Name)
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, "", hostType, clientID)
+	_, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, false, hostType, clientID)
 	if err != nil {
 		log.Printf("getDisk request failed: %s", err.Error())
 		return nil, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "getDisk")
-	if responseError != nil {
-		return nil, responseError
-	}
-
 	var result map[string]interface{}
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getDisk")
@@ -370,7 +352,7 @@ func (c *Client) getDisk(occmDetails createOCCMDetails, clientID string) (map[st
 	return result, nil
 }
 
-func (c *Client) getVMInstance(occmDetails createOCCMDetails, clientID string) (map[string]interface{}, error) {
+func (c *Client) getVMInstance(ctx context.Context, occmDetails createOCCMDetails, clientID string) (map[string]interface{}, error) {
 
 	log.Print("getVMInstance")
 
@@ -378,17 +360,12 @@ func (c *Client) getVMInstance(occmDetails createOCCMDetails, clientID string) (
 	hostType := "GCPCompute"
 
 	log.Print("GET")
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, "", hostType, clientID)
+	_, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, false, hostType, clientID)
 	if err != nil {
 		log.Printf("getVMInstance request failed: %s", err.Error())
 		return nil, err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "getVMInstance")
-	if responseError != nil {
-		return nil, responseError
-	}
-
 	var result map[string]interface{}
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getVMInstance")
@@ -400,60 +377,47 @@ func (c *Client) getVMInstance(occmDetails createOCCMDetails, clientID string) (
 
 // This function is not used because can't get the update instance API from GCP working. Receive the following error: Boot disk must be the first disk attached to the instance.
 // Although there is only one disk exists all the time, I can't figure it out to make it work.
-func (c *Client) updateVMInstance(occmDetails createOCCMDetails, clientID string, updatePropertities map[string]interface{}) error {
+func (c *Client) updateVMInstance(ctx context.Context, occmDetails createOCCMDetails, clientID string, updatePropertities map[string]interface{}) error {
 	baseURL := fmt.Sprintf("/compute/v1/projects/%s/zones/%s/instances/%s-vm", occmDetails.GCPProject, occmDetails.Zone, occmDetails.Name)
 	hostType := "GCPCompute"
-	statusCode, response, _, err := c.CallAPIMethod("PUT", baseURL, updatePropertities, "", hostType, clientID)
+	_, _, _, err := c.CallAPIMethod(ctx, "PUT", baseURL, updatePropertities, false, hostType, clientID)
 
 	if err != nil {
 		log.Print("updateVMInstance request failed")
 		return err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "getVMInstance")
-	if responseError != nil {
-		return responseError
-	}
-
 	return nil
 
 }
 
-func (c *Client) setVMLabels(occmDetails createOCCMDetails, labels map[string]interface{}, clientID string) error {
+func (c *Client) setVMLabels(ctx context.Context, occmDetails createOCCMDetails, labels map[string]interface{}, clientID string) error {
 	log.Print("setVMLabels")
 
 	baseURL := fmt.Sprintf("/compute/v1/projects/%s/zones/%s/instances/%s-vm/setLabels", occmDetails.GCPProject, occmDetails.Zone, occmDetails.Name)
 	hostType := "GCPCompute"
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, labels, "", hostType, clientID)
+	_, _, _, err := c.CallAPIMethod(ctx, "POST", baseURL, labels, false, hostType, clientID)
 	if err != nil {
 		log.Printf("setVMLabels request failed: %s", err.Error())
 		return err
 	}
-	responseError := apiResponseChecker(statusCode, response, "setVMLabels")
-	if responseError != nil {
-		return responseError
-	}
 	return nil
 }
 
-func (c *Client) setDiskLabels(occmDetails createOCCMDetails, labels map[string]interface{}, clientID string) error {
+func (c *Client) setDiskLabels(ctx context.Context, occmDetails createOCCMDetails, labels map[string]interface{}, clientID string) error {
 	log.Print("setDiskLabels")
 
 	baseURL := fmt.Sprintf("/compute/v1/projects/%s/zones/%s/disks/%s-vm-disk-boot/setLabels", occmDetails.GCPProject, occmDetails.Zone, occmDetails.Name)
 	hostType := "GCPCompute"
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, labels, "", hostType, clientID)
+	_, _, _, err := c.CallAPIMethod(ctx, "POST", baseURL, labels, false, hostType, clientID)
 	if err != nil {
 		log.Printf("setDiskLabels request failed: %s", err.Error())
 		return err
 	}
-	responseError := apiResponseChecker(statusCode, response, "setDiskLabels")
-	if responseError != nil {
-		return responseError
-	}
 	return nil
 }
 
-func (c *Client) setVMInstaceTags(occmDetails createOCCMDetails, fingerprint string, clientID string) error {
+func (c *Client) setVMInstaceTags(ctx context.Context, occmDetails createOCCMDetails, fingerprint string, clientID string) error {
 	log.Print("setVMInstaceTags")
 
 	baseURL := fmt.Sprintf("/compute/v1/projects/%s/zones/%s/instances/%s-vm/setTags", occmDetails.GCPProject, occmDetails.Region, occmDetails.Name)
@@ -461,19 +425,15 @@ func (c *Client) setVMInstaceTags(occmDetails createOCCMDetails, fingerprint str
 	body := make(map[string]interface{})
 	body["items"] = occmDetails.Tags
 	body["fingerprint"] = fingerprint
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, body, "", hostType, clientID)
+	_, _, _, err := c.CallAPIMethod(ctx, "POST", baseURL, body, false, hostType, clientID)
 	if err != nil {
 		log.Print("setVMInstaceTags request failed")
 		return err
 	}
-	responseError := apiResponseChecker(statusCode, response, "setVMInstaceTags")
-	if responseError != nil {
-		return responseError
-	}
 	return nil
 }
 
-func (c *Client) deleteOCCMGCP(request deleteOCCMDetails, clientID string) error {
+func (c *Client) deleteOCCMGCP(ctx context.Context, request deleteOCCMDetails, clientID string) error {
 
 	log.Printf("deleteOCCMGCP %s client %s", request.Name, clientID)
 
@@ -481,29 +441,25 @@ func (c *Client) deleteOCCMGCP(request deleteOCCMDetails, clientID string) error
 	hostType := "GCPDeploymentManager"
 
 	log.Print("DELETE")
-	statusCode, response, _, err := c.CallAPIMethod("DELETE", baseURL, nil, "", hostType, clientID)
+	_, _, _, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, false, hostType, clientID)
 	if err != nil {
 		log.Print("deleteOCCMGCP request failed")
 		return err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "deleteOCCMGCP")
-	if responseError != nil {
-		return responseError
-	}
-
 	log.Print("Sleep for 30 seconds")
-	time.Sleep(time.Duration(30) * time.Second)
+	if err := sleepWithContext(ctx, time.Duration(30)*time.Second); err != nil {
+		return err
+	}
 
-	accesTokenResult, err := c.getAccessToken()
-	c.Token = accesTokenResult.Token
+	_, err = c.getAccessToken(ctx)
 	if err != nil {
 		return err
 	}
 
 	retries := 30
 	for {
-		occmResp, err := c.checkOCCMStatus(clientID)
+		occmResp, err := c.checkOCCMStatus(ctx, clientID)
 		if err != nil {
 			return err
 		}
@@ -514,12 +470,14 @@ func (c *Client) deleteOCCMGCP(request deleteOCCMDetails, clientID string) error
 				log.Print("Taking too long for instance to finish terminating")
 				return fmt.Errorf("Taking too long for instance to finish terminating")
 			}
-			time.Sleep(time.Duration(10) * time.Second)
+			if err := sleepWithContext(ctx, time.Duration(10)*time.Second); err != nil {
+				return err
+			}
 			retries--
 		}
 	}
 
-	if err := c.callOCCMDelete(clientID); err != nil {
+	if err := c.callOCCMDelete(ctx, clientID); err != nil {
 		return err
 	}
 
@@ -1,15 +1,19 @@
 package cloudmanager
 
 import (
+	"context"
 	"fmt"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/netapp/terraform-provider-netapp-cloudmanager/cloudmanager/cloudmanager/restapi"
 )
 
 // Provider is the main method for NetApp CloudManager Terraform provider
-func Provider() terraform.ResourceProvider {
-	return &schema.Provider{
+func Provider() *schema.Provider {
+	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"refresh_token": {
 				Type:        schema.TypeString,
@@ -67,25 +71,104 @@ func Provider() terraform.ResourceProvider {
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("AZURE_AUTH_METHODS", nil),
 			},
+			"max_retries": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CLOUDMANAGER_MAX_RETRIES", restapi.DefaultMaxRetries),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum number of retries for idempotent API requests failing on connection errors, 429, 502, 503 or 504.",
+			},
+			"retry_wait_min": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CLOUDMANAGER_RETRY_WAIT_MIN", int(restapi.DefaultRetryWaitMin.Seconds())),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Minimum time in seconds to wait between two retries, doubled on every retry.",
+			},
+			"retry_wait_max": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CLOUDMANAGER_RETRY_WAIT_MAX", int(restapi.DefaultRetryWaitMax.Seconds())),
+				ValidateFunc: validation.IntAtLeast(0),
+				Description:  "Maximum time in seconds to wait between two retries, including the time requested by a Retry-After header.",
+			},
+			"http_record_mode": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("CLOUDMANAGER_HTTP_RECORD_MODE", ""),
+				ValidateFunc: validation.StringInSlice([]string{"", restapi.RecordModeRecord, restapi.RecordModeReplay}, false),
+				Description:  "Set to record to save the API interactions to http_cassette, or to replay to serve them from it without network access.",
+			},
+			"http_cassette": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDMANAGER_HTTP_CASSETTE", ""),
+				Description: "Path of the file the API interactions are recorded to or replayed from, with tokens and passwords redacted.",
+			},
+			"ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDMANAGER_CA_BUNDLE", ""),
+				Description: "PEM encoded CA certificates, or the path of a file containing them, trusted in addition to the system CAs, e.g. for a Connector with a self-signed certificate.",
+			},
+			"client_certificate": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDMANAGER_CLIENT_CERTIFICATE", ""),
+				Description: "PEM encoded client certificate, or the path of a file containing it, for mutual TLS.",
+			},
+			"client_key": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Sensitive:   true,
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDMANAGER_CLIENT_KEY", ""),
+				Description: "PEM encoded private key of client_certificate, or the path of a file containing it.",
+			},
+			"insecure_skip_verify": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDMANAGER_INSECURE_SKIP_VERIFY", false),
+				Description: "Do not verify the server certificates. For lab use only.",
+			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("CLOUDMANAGER_PROXY_URL", ""),
+				Description: "HTTP(S) proxy used for the requests to BlueXP, the Connector and CVS. HTTPS_PROXY and NO_PROXY are honored when not set.",
+			},
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"netapp-cloudmanager_connector_aws":   resourceOCCMAWS(),
-			"netapp-cloudmanager_connector_azure": resourceOCCMAzure(),
-			"netapp-cloudmanager_connector_gcp":   resourceOCCMGCP(),
-			"netapp-cloudmanager_cvo_aws":         resourceCVOAWS(),
-			"netapp-cloudmanager_cvo_azure":       resourceCVOAzure(),
-			"netapp-cloudmanager_cvo_gcp":         resourceCVOGCP(),
-			"netapp-cloudmanager_aggregate":       resourceAggregate(),
-			"netapp-cloudmanager_volume":          resourceCVOVolume(),
-			"netapp-cloudmanager_cifs_server":     resourceCVOCIFS(),
-			"netapp-cloudmanager_snapmirror":      resourceCVOSnapMirror(),
-			"netapp-cloudmanager_nss_account":     resourceCVONssAccount(),
-			"netapp-cloudmanager_anf_volume":      resourceCVSANFVolume(),
-			"netapp-cloudmanager_cvs_gcp_volume":  resourceCVSGCPVolume(),
-			"netapp-cloudmanager_aws_fsx":         resourceAWSFSX(),
-			"netapp-cloudmanager_aws_fsx_volume":  resourceFsxVolume(),
-			"netapp-cloudmanager_cvo_onprem":      resourceCVOOnPrem(),
+			"netapp-cloudmanager_connector_aws":     resourceOCCMAWS(),
+			"netapp-cloudmanager_connector_azure":   resourceOCCMAzure(),
+			"netapp-cloudmanager_connector_gcp":     resourceOCCMGCP(),
+			"netapp-cloudmanager_cvo_aws":           resourceCVOAWS(),
+			"netapp-cloudmanager_cvo_azure":         resourceCVOAzure(),
+			"netapp-cloudmanager_cvo_gcp":           resourceCVOGCP(),
+			"netapp-cloudmanager_aggregate":         resourceAggregate(),
+			"netapp-cloudmanager_volume":            resourceCVOVolume(),
+			"netapp-cloudmanager_cifs_server":       resourceCVOCIFS(),
+			"netapp-cloudmanager_snapmirror":        resourceCVOSnapMirror(),
+			"netapp-cloudmanager_nss_account":       resourceCVONssAccount(),
+			"netapp-cloudmanager_anf_volume":        resourceCVSANFVolume(),
+			"netapp-cloudmanager_cvs_gcp_volume":    resourceCVSGCPVolume(),
+			"netapp-cloudmanager_aws_fsx":           resourceAWSFSX(),
+			"netapp-cloudmanager_aws_fsx_volume":    resourceFsxVolume(),
+			"netapp-cloudmanager_cvo_onprem":        resourceCVOOnPrem(),
+			"netapp-cloudmanager_snapshot":          resourceSnapshot(),
+			"netapp-cloudmanager_snapshot_policy":   resourceSnapshotPolicy(),
+			"netapp-cloudmanager_volume_restore":    resourceVolumeRestore(),
+			"netapp-cloudmanager_igroup":            resourceIgroup(),
+			"netapp-cloudmanager_iscsi_initiator":   resourceIscsiInitiator(),
+			"netapp-cloudmanager_cifs_share":        resourceCifsShare(),
+			"netapp-cloudmanager_export_policy":     resourceExportPolicy(),
+			"netapp-cloudmanager_qtree":             resourceQtree(),
+			"netapp-cloudmanager_quota_rule":        resourceQuotaRule(),
+			"netapp-cloudmanager_svm":               resourceSVM(),
+			"netapp-cloudmanager_lun":               resourceLun(),
+			"netapp-cloudmanager_backup_activation": resourceBackupActivation(),
+			"netapp-cloudmanager_backup_policy":     resourceBackupPolicy(),
+			"netapp-cloudmanager_volume_backup":     resourceVolumeBackup(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
 			"netapp-cloudmanager_cifs_server": dataSourceCVOCIFS(),
@@ -94,18 +177,47 @@ func Provider() terraform.ResourceProvider {
 			"netapp-cloudmanager_aws_fsx":     dataSourceAWSFSX(),
 			"netapp-cloudmanager_cvo_aws":     dataSourceCVOAWS(),
 		},
+	}
 
-		ConfigureFunc: providerConfigure,
+	provider.ConfigureContextFunc = func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		client, err := providerConfigure(d)
+		if err != nil {
+			return client, diag.FromErr(err)
+		}
+		var diags diag.Diagnostics
+		if d.Get("insecure_skip_verify").(bool) {
+			diags = append(diags, diag.Diagnostic{
+				Severity:      diag.Warning,
+				Summary:       "TLS certificate verification is disabled",
+				Detail:        "insecure_skip_verify accepts any certificate from BlueXP, the Connector and CVS. Use ca_bundle to trust a private certificate instead, and only skip the verification in a lab.",
+				AttributePath: cty.GetAttrPath("insecure_skip_verify"),
+			})
+		}
+		return client, diags
 	}
+
+	return provider
 }
 
-func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+func providerConfigure(d *schema.ResourceData) (*Client, error) {
 	config := configStruct{
-		RefreshToken: d.Get("refresh_token").(string),
-		Environment:  d.Get("environment").(string),
-		SaSecretKey:  d.Get("sa_secret_key").(string),
-		SaClientID:   d.Get("sa_client_id").(string),
-		Simulator:    d.Get("simulator").(bool),
+		RefreshToken:   d.Get("refresh_token").(string),
+		Environment:    d.Get("environment").(string),
+		SaSecretKey:    d.Get("sa_secret_key").(string),
+		SaClientID:     d.Get("sa_client_id").(string),
+		Simulator:      d.Get("simulator").(bool),
+		MaxRetries:     d.Get("max_retries").(int),
+		RetryWaitMin:   d.Get("retry_wait_min").(int),
+		RetryWaitMax:   d.Get("retry_wait_max").(int),
+		HTTPRecordMode: d.Get("http_record_mode").(string),
+		HTTPCassette:   d.Get("http_cassette").(string),
+		Transport: restapi.TransportConfig{
+			CABundle:           d.Get("ca_bundle").(string),
+			ClientCertificate:  d.Get("client_certificate").(string),
+			ClientKey:          d.Get("client_key").(string),
+			InsecureSkipVerify: d.Get("insecure_skip_verify").(bool),
+			ProxyURL:           d.Get("proxy_url").(string),
+		},
 	}
 
 	if v, ok := d.GetOk("aws_profile"); ok {
@@ -116,7 +228,7 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	}
 	if v, ok := d.GetOk("connector_host"); ok {
 		config.ConnectorHost = v.(string)
-	}	
+	}
 	if v, ok := d.GetOk("azure_auth_methods"); ok {
 		// a bit complicated, as the type is only known at runtime
 		intMethods := v.([]interface{})
@@ -132,5 +244,8 @@ func providerConfigure(d *schema.ResourceData) (interface{}, error) {
 	} else {
 		config.AzureAuthMethods = []string{"cli", "env"}
 	}
+	if config.RetryWaitMin > config.RetryWaitMax {
+		return &Client{}, fmt.Errorf("expecting retry_wait_min (%d) to be less than or equal to retry_wait_max (%d)", config.RetryWaitMin, config.RetryWaitMax)
+	}
 	return config.clientFun()
 }
@@ -0,0 +1,114 @@
+package cloudmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+type qtreeRequest struct {
+	Name             string `structs:"name,omitempty"`
+	SecurityStyle    string `structs:"securityStyle,omitempty"`
+	UnixPermissions  string `structs:"unixPermissions,omitempty"`
+	ExportPolicyName string `structs:"exportPolicyName,omitempty"`
+}
+
+type qtreeResponse struct {
+	Name             string `json:"name"`
+	SecurityStyle    string `json:"securityStyle"`
+	UnixPermissions  string `json:"unixPermissions"`
+	ExportPolicyName string `json:"exportPolicyName"`
+}
+
+func (c *Client) createQtree(ctx context.Context, vol volumeRequest, qtree qtreeRequest, clientID string) error {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL += "/qtrees"
+	hostType := "CloudManagerHost"
+	params := structs.Map(qtree)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("createQtree request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "qtree", "create", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getQtree returns the qtree of the volume, or errNotFound
+func (c *Client) getQtree(ctx context.Context, vol volumeRequest, name string, clientID string) (qtreeResponse, error) {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return qtreeResponse{}, err
+	}
+	baseURL += "/qtrees"
+	hostType := "CloudManagerHost"
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("getQtree request failed ", statusCode)
+		return qtreeResponse{}, err
+	}
+	var result []qtreeResponse
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getQtree ", err)
+		return qtreeResponse{}, err
+	}
+	for _, qtree := range result {
+		if qtree.Name == name {
+			return qtree, nil
+		}
+	}
+	return qtreeResponse{}, fmt.Errorf("qtree %s: %w", name, errNotFound)
+}
+
+// updateQtree changes the security style, UNIX permissions and export policy of the qtree
+func (c *Client) updateQtree(ctx context.Context, vol volumeRequest, qtree qtreeRequest, clientID string) error {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/qtrees/%s", baseURL, qtree.Name)
+	qtree.Name = ""
+	hostType := "CloudManagerHost"
+	params := structs.Map(qtree)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "PUT", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("updateQtree request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "qtree", "update", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) deleteQtree(ctx context.Context, vol volumeRequest, name string, clientID string) error {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/qtrees/%s", baseURL, name)
+	hostType := "CloudManagerHost"
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("deleteQtree request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "qtree", "delete", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
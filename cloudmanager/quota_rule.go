@@ -0,0 +1,147 @@
+package cloudmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+// quotaRuleRequest limits the space and files of a qtree, or of a user or group in a qtree or the volume.
+// A limit of 0 leaves it unlimited.
+type quotaRuleRequest struct {
+	Type          string `structs:"type,omitempty"`
+	Target        string `structs:"target,omitempty"`
+	QtreeName     string `structs:"qtreeName,omitempty"`
+	DiskLimit     size   `structs:"diskLimit"`
+	SoftDiskLimit size   `structs:"softDiskLimit"`
+	FileLimit     int    `structs:"fileLimit"`
+	SoftFileLimit int    `structs:"softFileLimit"`
+}
+
+type quotaRuleResponse struct {
+	ID            string `json:"id"`
+	Type          string `json:"type"`
+	Target        string `json:"target"`
+	QtreeName     string `json:"qtreeName"`
+	DiskLimit     size   `json:"diskLimit"`
+	SoftDiskLimit size   `json:"softDiskLimit"`
+	FileLimit     int    `json:"fileLimit"`
+	SoftFileLimit int    `json:"softFileLimit"`
+}
+
+func (c *Client) createQuotaRule(ctx context.Context, vol volumeRequest, rule quotaRuleRequest, clientID string) error {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL += "/quota-rules"
+	hostType := "CloudManagerHost"
+	params := structs.Map(rule)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("createQuotaRule request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "quota rule", "create", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) getQuotaRules(ctx context.Context, vol volumeRequest, clientID string) ([]quotaRuleResponse, error) {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return nil, err
+	}
+	baseURL += "/quota-rules"
+	hostType := "CloudManagerHost"
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("getQuotaRules request failed ", statusCode)
+		return nil, err
+	}
+	var result []quotaRuleResponse
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getQuotaRules ", err)
+		return nil, err
+	}
+	return result, nil
+}
+
+// getQuotaRule returns the quota rule of the volume with the ID, or errNotFound
+func (c *Client) getQuotaRule(ctx context.Context, vol volumeRequest, id string, clientID string) (quotaRuleResponse, error) {
+	rules, err := c.getQuotaRules(ctx, vol, clientID)
+	if err != nil {
+		return quotaRuleResponse{}, err
+	}
+	for _, rule := range rules {
+		if rule.ID == id {
+			return rule, nil
+		}
+	}
+	return quotaRuleResponse{}, fmt.Errorf("quota rule %s: %w", id, errNotFound)
+}
+
+// findQuotaRule returns the quota rule of the volume with the type, target and qtree of the request, or errNotFound
+func (c *Client) findQuotaRule(ctx context.Context, vol volumeRequest, request quotaRuleRequest, clientID string) (quotaRuleResponse, error) {
+	rules, err := c.getQuotaRules(ctx, vol, clientID)
+	if err != nil {
+		return quotaRuleResponse{}, err
+	}
+	for _, rule := range rules {
+		if rule.Type == request.Type && rule.Target == request.Target && rule.QtreeName == request.QtreeName {
+			return rule, nil
+		}
+	}
+	return quotaRuleResponse{}, fmt.Errorf("%s quota rule %q: %w", request.Type, request.Target, errNotFound)
+}
+
+// updateQuotaRule changes the limits of the quota rule
+func (c *Client) updateQuotaRule(ctx context.Context, vol volumeRequest, id string, rule quotaRuleRequest, clientID string) error {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/quota-rules/%s", baseURL, id)
+	rule.Type = ""
+	rule.Target = ""
+	rule.QtreeName = ""
+	hostType := "CloudManagerHost"
+	params := structs.Map(rule)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "PUT", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("updateQuotaRule request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "quota rule", "update", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (c *Client) deleteQuotaRule(ctx context.Context, vol volumeRequest, id string, clientID string) error {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/quota-rules/%s", baseURL, id)
+	hostType := "CloudManagerHost"
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("deleteQuotaRule request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "quota rule", "delete", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -1,24 +1,30 @@
 package cloudmanager
 
 import (
-	"fmt"
+	"context"
 	"log"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceAggregate() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAggregateCreate,
-		Read:   resourceAggregateRead,
-		Delete: resourceAggregateDelete,
-		Exists: resourceAggregateExists,
-		Update: resourceAggregateUpdate,
+		CreateContext: resourceAggregateCreate,
+		ReadContext:   resourceAggregateRead,
+		DeleteContext: resourceAggregateDelete,
+		UpdateContext: resourceAggregateUpdate,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(75 * time.Minute),
+			Update: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -88,7 +94,7 @@ func resourceAggregate() *schema.Resource {
 	}
 }
 
-func resourceAggregateCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceAggregateCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating Aggregate: %#v", d)
 
 	client := meta.(*Client)
@@ -96,9 +102,9 @@ func resourceAggregateCreate(d *schema.ResourceData, meta interface{}) error {
 	clientID := d.Get("client_id").(string)
 	aggregate := createAggregateRequest{}
 
-	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID)
+	workingEnv, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
 	if err != nil {
-		return fmt.Errorf("Cannot find working environment")
+		return diag.Errorf("Cannot find working environment")
 	}
 	aggregate.WorkingEnvironmentID = workingEnv.PublicID
 
@@ -149,80 +155,86 @@ func resourceAggregateCreate(d *schema.ResourceData, meta interface{}) error {
 		aggregate.CapacityTier = "cloudStorage"
 	}
 
-	res, err := client.createAggregate(&aggregate, clientID)
+	res, err := client.createAggregate(ctx, &aggregate, clientID)
 	if err != nil {
 		log.Print("Error creating aggregate")
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(res.Name)
 
 	log.Printf("Created aggregate: %v", res)
 
-	return resourceAggregateRead(d, meta)
+	return resourceAggregateRead(ctx, d, meta)
 }
 
 // read the specific aggregate with working environemnt Id and aggregate name
-func resourceAggregateRead(d *schema.ResourceData, meta interface{}) error {
+func resourceAggregateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Reading Aggregate: %#v", d)
 	client := meta.(*Client)
 
 	clientID := d.Get("client_id").(string)
 	aggregate := aggregateRequest{}
 
-	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID)
+	workingEnv, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "aggregate")
+	}
 	if err != nil {
-		return fmt.Errorf("Cannot find working environment")
+		return diag.Errorf("Cannot find working environment: %s", err)
 	}
 	aggregate.WorkingEnvironmentID = workingEnv.PublicID
 
 	id := d.Id()
 
-	aggr, err := client.getAggregate(aggregate, id, workingEnv.WorkingEnvironmentType, clientID)
+	aggr, err := client.getAggregate(ctx, aggregate, id, workingEnv.WorkingEnvironmentType, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "aggregate")
+	}
 	if err != nil {
 		log.Printf("Error getting aggregate. id = %v", id)
-		return err
+		return diag.FromErr(err)
 	}
 
 	if aggr.Name != id {
-		return fmt.Errorf("Expected aggregate name %v, Response could not find", aggr.Name)
+		return removeFromState(d, "aggregate")
 	}
 
 	return nil
 }
 
-func resourceAggregateDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceAggregateDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting Aggregate: %#v", d)
 
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
 	request := deleteAggregateRequest{}
 
-	workingEnvDetail, err := client.getWorkingEnvironmentDetail(d, clientID)
+	workingEnvDetail, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
 	if err != nil {
-		return fmt.Errorf("Cannot find working environment")
+		return diag.Errorf("Cannot find working environment")
 	}
 	request.WorkingEnvironmentID = workingEnvDetail.PublicID
 
 	request.Name = d.Get("name").(string)
 
-	deleteErr := client.deleteAggregate(request, clientID)
+	deleteErr := client.deleteAggregate(ctx, request, clientID)
 	if deleteErr != nil {
-		return deleteErr
+		return diag.FromErr(deleteErr)
 	}
 
 	return nil
 }
 
-func resourceAggregateUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceAggregateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Updating Aggregate: %#v", d)
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
 	request := updateAggregateRequest{}
 
-	workingEnvDetail, err := client.getWorkingEnvironmentDetail(d, clientID)
+	workingEnvDetail, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
 	if err != nil {
-		return fmt.Errorf("Cannot find working environment")
+		return diag.Errorf("Cannot find working environment")
 	}
 	request.WorkingEnvironmentID = workingEnvDetail.PublicID
 
@@ -234,43 +246,15 @@ func resourceAggregateUpdate(d *schema.ResourceData, meta interface{}) error {
 			request.NumberOfDisks = expectNumber.(int) - currentNumber.(int)
 		} else {
 			d.Set("number_of_disks", currentNumber)
-			return fmt.Errorf("Aggregate: number_of_disks cannot be reduced")
+			return attributeErrorf("number_of_disks", "Aggregate: number_of_disks cannot be reduced")
 		}
 	}
-	updateErr := client.updateAggregate(request, clientID)
+	updateErr := client.updateAggregate(ctx, request, clientID)
 	if updateErr != nil {
-		return updateErr
+		return diag.FromErr(updateErr)
 	}
 
 	log.Printf("Updated aggregate; %v", request.Name)
 
-	return resourceAggregateRead(d, meta)
-}
-
-func resourceAggregateExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	log.Printf("Checking existence of Aggregate: %#v", d)
-	client := meta.(*Client)
-
-	clientID := d.Get("client_id").(string)
-	aggregate := aggregateRequest{}
-
-	workingEnv, err := client.getWorkingEnvironmentDetail(d, clientID)
-	if err != nil {
-		return false, fmt.Errorf("Cannot find working environment")
-	}
-	aggregate.WorkingEnvironmentID = workingEnv.PublicID
-	id := d.Id()
-	res, err := client.getAggregate(aggregate, id, workingEnv.WorkingEnvironmentType, clientID)
-	if err != nil {
-		log.Print("Error getting aggregate")
-		d.SetId("")
-		return false, err
-	}
-
-	if res.Name != id {
-		d.SetId("")
-		return false, nil
-	}
-
-	return true, nil
+	return resourceAggregateRead(ctx, d, meta)
 }
@@ -1,20 +1,22 @@
 package cloudmanager
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/resource"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccAggregate_basic(t *testing.T) {
 
 	var aggregate aggregateResult
 	resource.Test(t, resource.TestCase{
-		PreCheck:     func() { testAccPreCheck(t) },
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckAggregateDestroy,
+		PreCheck:          func() { testAccPreCheck(t) },
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckAggregateDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccAggregateConfigCreateByWorkingEnvironmentID(),
@@ -41,6 +43,7 @@ func TestAccAggregate_basic(t *testing.T) {
 
 func testAccCheckAggregateDestroy(state *terraform.State) error {
 	client := testAccProvider.Meta().(*Client)
+	ctx := context.Background()
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "netapp-cloudmanager_aggregate" {
@@ -52,17 +55,17 @@ func testAccCheckAggregateDestroy(state *terraform.State) error {
 		if aggr, ok := rs.Primary.Attributes["working_environment_id"]; ok {
 			aggregate.WorkingEnvironmentID = aggr
 		} else if name, ok := rs.Primary.Attributes["working_environment_name"]; ok {
-			info, err := client.findWorkingEnvironmentByName(name, clientID)
+			info, err := client.findWorkingEnvironmentByName(ctx, name, clientID)
 			if err != nil {
 				aggregate.WorkingEnvironmentID = info.PublicID
 			}
 		}
 
-		workingEnvDetail, err := client.getWorkingEnvironmentInfo(aggregate.WorkingEnvironmentID, clientID)
+		workingEnvDetail, err := client.getWorkingEnvironmentInfo(ctx, aggregate.WorkingEnvironmentID, clientID)
 		if err != nil {
 			return err
 		}
-		response, err := client.getAggregate(aggregate, id, workingEnvDetail.WorkingEnvironmentType, clientID)
+		response, err := client.getAggregate(ctx, aggregate, id, workingEnvDetail.WorkingEnvironmentType, clientID)
 		if err == nil {
 			if response.Name != "" {
 				return fmt.Errorf("aggregate (%s) still exists", id)
@@ -75,6 +78,7 @@ func testAccCheckAggregateDestroy(state *terraform.State) error {
 func testAccCheckAggregateExists(name string, aggregate *aggregateResult) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		client := testAccProvider.Meta().(*Client)
+		ctx := context.Background()
 		rs, ok := s.RootModule().Resources[name]
 		if !ok {
 			return fmt.Errorf("Not found: %s", name)
@@ -91,7 +95,7 @@ func testAccCheckAggregateExists(name string, aggregate *aggregateResult) resour
 		if a, ok := rs.Primary.Attributes["working_environment_id"]; ok {
 			aggr.WorkingEnvironmentID = a
 		} else if a, ok := rs.Primary.Attributes["working_environment_name"]; ok {
-			info, err := client.findWorkingEnvironmentByName(a, clientID)
+			info, err := client.findWorkingEnvironmentByName(ctx, a, clientID)
 			if err != nil {
 				return err
 			}
@@ -100,11 +104,11 @@ func testAccCheckAggregateExists(name string, aggregate *aggregateResult) resour
 			return fmt.Errorf("Cannot find working environment")
 		}
 
-		workingEnvDetail, err := client.getWorkingEnvironmentInfo(aggr.WorkingEnvironmentID, clientID)
+		workingEnvDetail, err := client.getWorkingEnvironmentInfo(ctx, aggr.WorkingEnvironmentID, clientID)
 		if err != nil {
 			return err
 		}
-		response, err := client.getAggregate(aggr, id, workingEnvDetail.WorkingEnvironmentType, clientID)
+		response, err := client.getAggregate(ctx, aggr, id, workingEnvDetail.WorkingEnvironmentType, clientID)
 		if err != nil {
 			return err
 		}
@@ -163,3 +167,41 @@ func testAccAggregateConfigUpdateAggregate() string {
 	}
   `)
 }
+
+func TestAggregateCRUD_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	r := resourceAggregate()
+
+	config := map[string]interface{}{
+		"name":                   "aggr2",
+		"working_environment_id": "VsaWorkingEnvironment-mock",
+		"number_of_disks":        1,
+		"client_id":              "mock",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "aggr2" {
+		t.Fatalf("create: expected ID aggr2, got %q", d.Id())
+	}
+
+	config["number_of_disks"] = 3
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if disks := mock.aggregates["VsaWorkingEnvironment-mock"][0]["numberOfDisks"]; disks != float64(3) {
+		t.Fatalf("update: expected 3 disks, got %v", disks)
+	}
+
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	// refreshing an aggregate deleted outside of Terraform removes it from the state
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted aggregate to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
@@ -1,22 +1,29 @@
 package cloudmanager
 
 import (
+	"context"
 	"log"
 	"math"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceCVSANFVolume() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCVSAzureVolumeCreate,
-		Read:   resourceCVSAzureVolumeRead,
-		Delete: resourceCVSAzureVolumeDelete,
+		CreateContext: resourceCVSAzureVolumeCreate,
+		ReadContext:   resourceCVSAzureVolumeRead,
+		DeleteContext: resourceCVSAzureVolumeDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		CustomizeDiff: resourceVolumeCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -158,7 +165,7 @@ func resourceCVSANFVolume() *schema.Resource {
 	}
 }
 
-func resourceCVSAzureVolumeCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceCVSAzureVolumeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating volume: %#v", d)
 
 	client := meta.(*Client)
@@ -205,17 +212,17 @@ func resourceCVSAzureVolumeCreate(d *schema.ResourceData, meta interface{}) erro
 	info.ResourceGroupsName = d.Get("resource_groups").(string)
 	info.NetAppAccountName = d.Get("netapp_account").(string)
 	info.CapacityPools = d.Get("capacity_pool").(string)
-	err := client.createANFVolume(volume, info, clientID)
+	err := client.createANFVolume(ctx, volume, info, clientID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	// volume Id is not returned, so use name.
 	d.SetId(volume.Name)
 
-	return resourceCVSAzureVolumeRead(d, meta)
+	return resourceCVSAzureVolumeRead(ctx, d, meta)
 }
 
-func resourceCVSAzureVolumeRead(d *schema.ResourceData, meta interface{}) error {
+func resourceCVSAzureVolumeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
 	volume := anfVolumeRequest{}
@@ -230,10 +237,13 @@ func resourceCVSAzureVolumeRead(d *schema.ResourceData, meta interface{}) error
 	info.ResourceGroupsName = d.Get("resource_groups").(string)
 	info.NetAppAccountName = d.Get("netapp_account").(string)
 	info.CapacityPools = d.Get("capacity_pool").(string)
-	result, err := client.getANFVolume(volume, info, clientID)
+	result, err := client.getANFVolume(ctx, volume, info, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "ANF volume")
+	}
 	if err != nil {
 		log.Print("Error reading volume")
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("size", math.Round(convertSizeUnit(result.Size, "B", d.Get("size_unit").(string))*10)/10)
 	d.Set("volume_path", result.VolumePath)
@@ -264,7 +274,7 @@ func resourceCVSAzureVolumeRead(d *schema.ResourceData, meta interface{}) error
 	return nil
 }
 
-func resourceCVSAzureVolumeDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceCVSAzureVolumeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
 	info := cvsInfo{}
@@ -278,9 +288,9 @@ func resourceCVSAzureVolumeDelete(d *schema.ResourceData, meta interface{}) erro
 	info.ResourceGroupsName = d.Get("resource_groups").(string)
 	info.NetAppAccountName = d.Get("netapp_account").(string)
 	info.CapacityPools = d.Get("capacity_pool").(string)
-	err := client.deleteANFVolume(volume, info, clientID)
+	err := client.deleteANFVolume(ctx, volume, info, clientID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -1,24 +1,29 @@
 package cloudmanager
 
 import (
-	"fmt"
+	"context"
 	"log"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceAWSFSX() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceAWSFSXCreate,
-		Read:   resourceAWSFSXRead,
-		Delete: resourceAWSFSXDelete,
-		Exists: resourceAWSFSXExists,
+		CreateContext: resourceAWSFSXCreate,
+		ReadContext:   resourceAWSFSXRead,
+		DeleteContext: resourceAWSFSXDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		CustomizeDiff: resourceAWSFSXCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -144,7 +149,7 @@ func resourceAWSFSX() *schema.Resource {
 	}
 }
 
-func resourceAWSFSXCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceAWSFSXCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating AWS FSX: %#v", d)
 
 	client := meta.(*Client)
@@ -161,20 +166,20 @@ func resourceAWSFSXCreate(d *schema.ResourceData, meta interface{}) error {
 
 	if d.Get("import_file_system").(bool) == true {
 		if d.Get("file_system_id").(string) == "" {
-			return fmt.Errorf("need file_system_id when importing file system")
+			return attributeErrorf("file_system_id", "need file_system_id when importing file system")
 		}
 		fileSystemID := d.Get("file_system_id").(string)
-		fsxID, err := client.importAWSFSX(fsxDetails, fileSystemID)
+		fsxID, err := client.importAWSFSX(ctx, fsxDetails, fileSystemID)
 		if err != nil {
 			log.Print("Error importing AWS FSX")
-			return err
+			return diag.FromErr(err)
 		}
 
 		d.SetId(fsxID)
 
 		log.Printf("Created AWS FSX: %v", fsxID)
 
-		return resourceAWSFSXRead(d, meta)
+		return resourceAWSFSXRead(ctx, d, meta)
 	}
 
 	addNameTag := true
@@ -223,20 +228,20 @@ func resourceAWSFSXCreate(d *schema.ResourceData, meta interface{}) error {
 		fsxDetails.RouteTableIds = append(fsxDetails.RouteTableIds, routeTableID.(string))
 	}
 
-	res, err := client.createAWSFSX(fsxDetails)
+	res, err := client.createAWSFSX(ctx, fsxDetails)
 	if err != nil {
 		log.Print("Error creating AWS FSX")
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(res.ID)
 
 	log.Printf("Created AWS FSX: %v", res)
 
-	return resourceAWSFSXRead(d, meta)
+	return resourceAWSFSXRead(ctx, d, meta)
 }
 
-func resourceAWSFSXRead(d *schema.ResourceData, meta interface{}) error {
+func resourceAWSFSXRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Reading AWS FSX: %#v", d)
 	client := meta.(*Client)
 
@@ -244,16 +249,20 @@ func resourceAWSFSXRead(d *schema.ResourceData, meta interface{}) error {
 
 	tenantID := d.Get("tenant_id").(string)
 
-	_, err := client.getAWSFSX(id, tenantID)
+	resID, err := client.getAWSFSX(ctx, id, tenantID)
 	if err != nil {
 		log.Print("Error getting AWS FSX")
-		return err
+		return diag.FromErr(err)
+	}
+
+	if resID != id {
+		return removeFromState(d, "AWS FSX")
 	}
 
 	return nil
 }
 
-func resourceAWSFSXDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceAWSFSXDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting AWS FSX: %#v", d)
 
 	client := meta.(*Client)
@@ -262,41 +271,19 @@ func resourceAWSFSXDelete(d *schema.ResourceData, meta interface{}) error {
 
 	tenantID := d.Get("tenant_id").(string)
 
-	deleteErr := client.deleteAWSFSX(id, tenantID)
+	deleteErr := client.deleteAWSFSX(ctx, id, tenantID)
 	if deleteErr != nil {
 		log.Print("Error deleting AWS FSX")
-		return deleteErr
+		return diag.FromErr(deleteErr)
 	}
 
 	return nil
 }
 
-func resourceAWSFSXCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+func resourceAWSFSXCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
 	respErr := checkUserTagDiff(diff, "tags", "tag_key")
 	if respErr != nil {
 		return respErr
 	}
 	return nil
 }
-
-func resourceAWSFSXExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	log.Printf("Checking existence of AWS FSX: %#v", d)
-	client := meta.(*Client)
-
-	id := d.Id()
-
-	tenantID := d.Get("tenant_id").(string)
-
-	resID, err := client.getAWSFSX(id, tenantID)
-	if err != nil {
-		log.Print("Error getting AWS FSX")
-		return false, err
-	}
-
-	if resID != id {
-		d.SetId("")
-		return false, nil
-	}
-
-	return true, nil
-}
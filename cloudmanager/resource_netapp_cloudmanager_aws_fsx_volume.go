@@ -1,23 +1,31 @@
 package cloudmanager
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceFsxVolume() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceFSXVolumeCreate,
-		Read:   resourceFSXVolumeRead,
-		Delete: resourceFSXVolumeDelete,
-		Update: resourceFSXVolumeUpdate,
+		CreateContext: resourceFSXVolumeCreate,
+		ReadContext:   resourceFSXVolumeRead,
+		DeleteContext: resourceFSXVolumeDelete,
+		UpdateContext: resourceFSXVolumeUpdate,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		CustomizeDiff: resourceFSXVolumeCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Update: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -44,6 +52,10 @@ func resourceFsxVolume() *schema.Resource {
 				Type:     schema.TypeString,
 				Required: true,
 			},
+			"export_policy_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
 			"export_policy_type": {
 				Type:     schema.TypeString,
 				Optional: true,
@@ -103,7 +115,7 @@ func resourceFsxVolume() *schema.Resource {
 	}
 }
 
-func resourceFSXVolumeCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceFSXVolumeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating volume: %#v", d)
 
 	client := meta.(*Client)
@@ -111,10 +123,10 @@ func resourceFSXVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 	var svm string
 	volume := volumeRequest{}
 
-	weInfo, err := client.getWorkingEnvironmentDetail(d, clientID)
+	weInfo, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
 	if err != nil {
 		log.Printf("Cannot find working environment: %#v", err)
-		return fmt.Errorf("cannot find working environment: %#v", err)
+		return diag.Errorf("cannot find working environment: %#v", err)
 	}
 	if svm == "" {
 		svm = weInfo.SvmName
@@ -126,20 +138,20 @@ func resourceFSXVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 	volume.EnableCompression = false
 	volume.EnableThinProvisioning = true
 	volume.EnableStorageEfficiency = d.Get("enable_storage_efficiency").(bool)
-	err = client.setCommonAttributes(weInfo.WorkingEnvironmentType, d, &volume, clientID)
+	err = client.setCommonAttributes(ctx, weInfo.WorkingEnvironmentType, d, &volume, clientID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = client.createVolume(volume, false, clientID)
+	err = client.createVolume(ctx, volume, false, clientID)
 	if err != nil {
 		log.Printf("Error creating volume: %#v", err)
-		return err
+		return diag.FromErr(err)
 	}
-	res, err := client.getVolume(volume, clientID)
+	res, err := client.getVolume(ctx, volume, clientID)
 	if err != nil {
 		log.Printf("Error reading volume after creation: %#v", err)
-		return err
+		return diag.FromErr(err)
 	}
 	for _, volume := range res {
 		if volume.SvmName == svm && volume.Name == d.Get("name") {
@@ -147,10 +159,10 @@ func resourceFSXVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 			break
 		}
 	}
-	return resourceFSXVolumeRead(d, meta)
+	return resourceFSXVolumeRead(ctx, d, meta)
 }
 
-func resourceFSXVolumeRead(d *schema.ResourceData, meta interface{}) error {
+func resourceFSXVolumeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Fetching volume: %#v", d)
 
 	client := meta.(*Client)
@@ -160,10 +172,13 @@ func resourceFSXVolumeRead(d *schema.ResourceData, meta interface{}) error {
 	if v, ok := d.GetOk("svm_name"); ok {
 		svm = v.(string)
 	} else {
-		weInfo, err := client.getWorkingEnvironmentDetail(d, clientID)
+		weInfo, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+		if isNotFound(err) {
+			return removeFromState(d, "volume")
+		}
 		if err != nil {
 			log.Printf("Cannot find working environment: %#v", err)
-			return fmt.Errorf("Cannot find working environment: %#v", err)
+			return diag.Errorf("Cannot find working environment: %#v", err)
 		}
 		if svm == "" {
 			svm = weInfo.SvmName
@@ -172,10 +187,13 @@ func resourceFSXVolumeRead(d *schema.ResourceData, meta interface{}) error {
 	volume.SvmName = svm
 	volume.Name = d.Get("name").(string)
 	volume.FileSystemID = d.Get("file_system_id").(string)
-	res, err := client.getVolume(volume, clientID)
+	res, err := client.getVolume(ctx, volume, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "volume")
+	}
 	if err != nil {
 		log.Printf("Error reading volume: %#v", err)
-		return err
+		return diag.FromErr(err)
 	}
 	for _, volume := range res {
 		if volume.ID == d.Id() {
@@ -200,6 +218,9 @@ func resourceFSXVolumeRead(d *schema.ResourceData, meta interface{}) error {
 			if _, ok := d.GetOk("export_policy_type"); ok {
 				d.Set("export_policy_type", volume.ExportPolicyInfo.PolicyType)
 			}
+			if _, ok := d.GetOk("export_policy_name"); ok {
+				d.Set("export_policy_name", volume.ExportPolicyInfo.Name)
+			}
 			if d.Get("unit") != "GB" {
 				d.Set("size", convertSizeUnit(volume.Size.Size, volume.Size.Unit, d.Get("unit").(string)))
 				d.Set("unit", d.Get("unit").(string))
@@ -232,10 +253,10 @@ func resourceFSXVolumeRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	return fmt.Errorf("Error reading volume: volume doesn't exist")
+	return removeFromState(d, "volume")
 }
 
-func resourceFSXVolumeDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceFSXVolumeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting volume: %#v", d)
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
@@ -244,10 +265,10 @@ func resourceFSXVolumeDelete(d *schema.ResourceData, meta interface{}) error {
 	if v, ok := d.GetOk("svm_name"); ok {
 		svm = v.(string)
 	} else {
-		weInfo, err := client.getWorkingEnvironmentDetail(d, clientID)
+		weInfo, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
 		if err != nil {
 			log.Printf("Cannot find working environment: %#v", err)
-			return fmt.Errorf("Cannot find working environment: %#v", err)
+			return diag.Errorf("Cannot find working environment: %#v", err)
 		}
 		svm = weInfo.SvmName
 	}
@@ -256,15 +277,15 @@ func resourceFSXVolumeDelete(d *schema.ResourceData, meta interface{}) error {
 
 	volume.Name = d.Get("name").(string)
 
-	err := client.deleteVolume(volume, clientID)
+	err := client.deleteVolume(ctx, volume, clientID)
 	if err != nil {
 		log.Printf("Error deleting volume: %#v", err)
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceFSXVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceFSXVolumeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Updating volume: %#v", d)
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
@@ -280,15 +301,18 @@ func resourceFSXVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 		volume.ExportPolicyInfo.Ips = ips
 	}
 
-	weInfo, err := client.getWorkingEnvironmentDetail(d, clientID)
+	weInfo, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
 	if err != nil {
 		log.Printf("Cannot find working environment: %#v", err)
-		return fmt.Errorf("Cannot find working environment: %#v", err)
+		return diag.Errorf("Cannot find working environment: %#v", err)
 	}
 	if svm == "" {
 		svm = weInfo.SvmName
 	}
 	volume.SvmName = svm
+	if d.HasChange("export_policy_name") {
+		volume.ExportPolicyInfo.Name = d.Get("export_policy_name").(string)
+	}
 	if v, ok := d.GetOk("export_policy_nfs_version"); ok {
 		nfs := make([]string, 0, v.(*schema.Set).Len())
 		for _, x := range v.(*schema.Set).List() {
@@ -306,28 +330,31 @@ func resourceFSXVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 		volume.ShareInfoUpdate.AccessControlList[0].Users = users
 	}
-	err = client.updateVolume(volume, clientID)
+	err = client.updateVolume(ctx, volume, clientID)
 	if err != nil {
 		log.Printf("Error updating volume: %#v", err)
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceFSXVolumeRead(d, meta)
+	return resourceFSXVolumeRead(ctx, d, meta)
 }
 
-func resourceFSXVolumeCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+func resourceFSXVolumeCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
 	if diff.HasChange("volume_protocol") {
 		currentVolumeType, expectedVolumeType := diff.GetChange("volume_protocol")
 		if currentVolumeType.(string) == "" {
 			if expectedVolumeType.(string) == "nfs" {
-				if _, ok := diff.GetOk("export_policy_type"); !ok {
-					return fmt.Errorf("export_policy_type is required when volume type is nfs")
-				}
-				if _, ok := diff.GetOk("export_policy_ip"); !ok {
-					return fmt.Errorf("export_policy_ip is required when volume type is nfs")
-				}
-				if _, ok := diff.GetOk("export_policy_nfs_version"); !ok {
-					return fmt.Errorf("export_policy_nfs_version is required when volume type is nfs")
+				// an existing export policy, such as a netapp-cloudmanager_export_policy, is referenced by its name alone
+				if _, ok := diff.GetOk("export_policy_name"); !ok {
+					if _, ok := diff.GetOk("export_policy_type"); !ok {
+						return fmt.Errorf("export_policy_type is required when volume type is nfs")
+					}
+					if _, ok := diff.GetOk("export_policy_ip"); !ok {
+						return fmt.Errorf("export_policy_ip is required when volume type is nfs")
+					}
+					if _, ok := diff.GetOk("export_policy_nfs_version"); !ok {
+						return fmt.Errorf("export_policy_nfs_version is required when volume type is nfs")
+					}
 				}
 			} else if expectedVolumeType.(string) == "cifs" {
 				if _, ok := diff.GetOk("share_name"); !ok {
@@ -1,11 +1,13 @@
 package cloudmanager
 
 import (
+	"context"
 	"fmt"
 	"testing"
 
-	"github.com/hashicorp/terraform/helper/resource"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccFSXVolume_basic(t *testing.T) {
@@ -16,8 +18,8 @@ func TestAccFSXVolume_basic(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		Providers:    testAccProviders,
-		CheckDestroy: testAccCheckFSXVolumeDestroy,
+		ProviderFactories: testAccProviderFactories,
+		CheckDestroy:      testAccCheckFSXVolumeDestroy,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccFSXVolumeConfigCreate(clientID, fileSystemID),
@@ -31,6 +33,7 @@ func TestAccFSXVolume_basic(t *testing.T) {
 
 func testAccCheckFSXVolumeDestroy(state *terraform.State) error {
 	client := testAccProvider.Meta().(*Client)
+	ctx := context.Background()
 	for _, rs := range state.RootModule().Resources {
 		fmt.Println(rs.Type)
 		if rs.Type != "netapp-cloudmanager_aws_fsx_volume" {
@@ -44,7 +47,7 @@ func testAccCheckFSXVolumeDestroy(state *terraform.State) error {
 		if v, ok := rs.Primary.Attributes["svm_name"]; ok {
 			svm = v
 		} else {
-			weInfo, err := client.getFSXWorkingEnvironmentInfo(rs.Primary.Attributes["tenant_id"], vol.FileSystemID, rs.Primary.Attributes["client_id"])
+			weInfo, err := client.getFSXWorkingEnvironmentInfo(ctx, rs.Primary.Attributes["tenant_id"], vol.FileSystemID, rs.Primary.Attributes["client_id"])
 			if err != nil {
 				return fmt.Errorf("Cannot find working environment")
 			}
@@ -52,7 +55,7 @@ func testAccCheckFSXVolumeDestroy(state *terraform.State) error {
 
 		}
 		vol.SvmName = svm
-		response, err := client.getVolumeByID(vol, rs.Primary.Attributes["client_id"])
+		response, err := client.getVolumeByID(ctx, vol, rs.Primary.Attributes["client_id"])
 		if err == nil {
 			if response.ID != "" {
 				return fmt.Errorf("volume (%s) still exists", response.ID)
@@ -67,6 +70,7 @@ func testAccCheckFSXVolumeExists(name string, volume *volumeResponse) resource.T
 	return func(s *terraform.State) error {
 		// time.Sleep(20 * time.Second)
 		client := testAccProvider.Meta().(*Client)
+		ctx := context.Background()
 		rs, ok := s.RootModule().Resources[name]
 		if !ok {
 			return fmt.Errorf("Not found: %s", name)
@@ -83,7 +87,7 @@ func testAccCheckFSXVolumeExists(name string, volume *volumeResponse) resource.T
 			if v2, ok := rs.Primary.Attributes["svm_name"]; ok {
 				svm = v2
 			} else {
-				weInfo, err := client.getFSXWorkingEnvironmentInfo(rs.Primary.Attributes["tenant_id"], v, rs.Primary.Attributes["client_id"])
+				weInfo, err := client.getFSXWorkingEnvironmentInfo(ctx, rs.Primary.Attributes["tenant_id"], v, rs.Primary.Attributes["client_id"])
 				if err != nil {
 					return fmt.Errorf("Cannot find working environment")
 				}
@@ -93,7 +97,7 @@ func testAccCheckFSXVolumeExists(name string, volume *volumeResponse) resource.T
 
 			volume.SvmName = svm
 		}
-		response, err := client.getVolumeByID(vol, rs.Primary.Attributes["client_id"])
+		response, err := client.getVolumeByID(ctx, vol, rs.Primary.Attributes["client_id"])
 		if err != nil {
 			return err
 		}
@@ -125,3 +129,38 @@ func testAccFSXVolumeConfigCreate(clientID string, fileSystemID string) string {
 		tenant_id = "account-j3aZttuL"
 	}`, clientID, fileSystemID)
 }
+
+func TestFSXVolumeCRUD_mock(t *testing.T) {
+	mock := newMockOCCM(t)
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "fs-mock", Name: "mockfsx", ProviderName: "Amazon", WorkingEnvironmentType: "AWS_FSX", SvmName: "svm_fsx", TenantID: "workspace-mock"})
+	client := mock.client()
+	r := resourceFsxVolume()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":               "fsxvol1",
+		"size":               10,
+		"unit":               "GB",
+		"volume_protocol":    "nfs",
+		"export_policy_type": "custom",
+		"export_policy_ip":   []interface{}{"0.0.0.0/0"},
+		"file_system_id":     "fs-mock",
+		"tenant_id":          "workspace-mock",
+		"client_id":          "mock",
+	})
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() == "" || mock.findVolume("fs-mock", "svm_fsx", "fsxvol1") == nil {
+		t.Fatal("create: expected the volume to be created on the file system SVM")
+	}
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if mock.findVolume("fs-mock", "svm_fsx", "fsxvol1") != nil {
+		t.Fatal("delete: expected the volume to be deleted")
+	}
+}
@@ -0,0 +1,264 @@
+package cloudmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBackupActivation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBackupActivationCreate,
+		ReadContext:   resourceBackupActivationRead,
+		UpdateContext: resourceBackupActivationUpdate,
+		DeleteContext: resourceBackupActivationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBackupActivationImport,
+		},
+		CustomizeDiff: resourceBackupActivationCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"provider_name": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"AWS", "AZURE", "GCP"}, false),
+			},
+			"region": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"bucket": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"azure_resource_group": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"azure_storage_account": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"gcp_project_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"encryption_key_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"ip_space": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Default",
+				ForceNew: true,
+			},
+			"policy_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"auto_backup_enabled": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceBackupActivationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Activating backup: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	accountID := d.Get("account_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	activation := backupActivation{}
+	activation.Provider = d.Get("provider_name").(string)
+	activation.Region = d.Get("region").(string)
+	activation.Bucket = d.Get("bucket").(string)
+	activation.ResourceGroup = d.Get("azure_resource_group").(string)
+	activation.StorageAccount = d.Get("azure_storage_account").(string)
+	activation.ProjectID = d.Get("gcp_project_id").(string)
+	activation.EncryptionKeyID = d.Get("encryption_key_id").(string)
+	activation.IPSpace = d.Get("ip_space").(string)
+	activation.PolicyName = d.Get("policy_name").(string)
+	activation.AutoBackupEnabled = d.Get("auto_backup_enabled").(bool)
+
+	err = client.activateBackup(ctx, accountID, workingEnv.PublicID, activation, clientID)
+	if err != nil {
+		log.Print("Error activating backup")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(workingEnv.PublicID)
+	d.Set("working_environment_id", workingEnv.PublicID)
+
+	log.Printf("Activated backup of %v", workingEnv.PublicID)
+
+	return resourceBackupActivationRead(ctx, d, meta)
+}
+
+func resourceBackupActivationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Reading backup activation: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	accountID := d.Get("account_id").(string)
+
+	res, err := client.getBackupActivation(ctx, accountID, d.Id(), clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "backup activation")
+	}
+	if err != nil {
+		log.Printf("Error getting backup activation. id = %v", d.Id())
+		return diag.FromErr(err)
+	}
+
+	// the provider is validated in upper case, which the API doesn't always report it in
+	if res.Provider != "" {
+		d.Set("provider_name", strings.ToUpper(res.Provider))
+	}
+	d.Set("region", res.Region)
+	d.Set("bucket", res.Bucket)
+	if res.ResourceGroup != "" {
+		d.Set("azure_resource_group", res.ResourceGroup)
+	}
+	if res.StorageAccount != "" {
+		d.Set("azure_storage_account", res.StorageAccount)
+	}
+	if res.ProjectID != "" {
+		d.Set("gcp_project_id", res.ProjectID)
+	}
+	if res.EncryptionKeyID != "" {
+		d.Set("encryption_key_id", res.EncryptionKeyID)
+	}
+	if res.IPSpace != "" {
+		d.Set("ip_space", res.IPSpace)
+	}
+	d.Set("policy_name", res.PolicyName)
+	d.Set("auto_backup_enabled", res.AutoBackupEnabled)
+	d.Set("status", res.Status)
+
+	return nil
+}
+
+func resourceBackupActivationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating backup activation: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	accountID := d.Get("account_id").(string)
+
+	request := backupActivationUpdateRequest{}
+	request.PolicyName = d.Get("policy_name").(string)
+	request.AutoBackupEnabled = d.Get("auto_backup_enabled").(bool)
+
+	err := client.updateBackupActivation(ctx, accountID, d.Id(), request, clientID)
+	if err != nil {
+		log.Print("Error updating backup activation")
+		return diag.FromErr(err)
+	}
+
+	log.Printf("Updated backup activation of %v", d.Id())
+
+	return resourceBackupActivationRead(ctx, d, meta)
+}
+
+func resourceBackupActivationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Deactivating backup: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	accountID := d.Get("account_id").(string)
+
+	err := client.deactivateBackup(ctx, accountID, d.Id(), clientID)
+	if err != nil {
+		log.Print("Error deactivating backup")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceBackupActivationImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 3 {
+		return []*schema.ResourceData{}, fmt.Errorf("Wrong format of resource: %s. Please follow 'client_id:account_id:working_environment_id'", d.Id())
+	}
+
+	d.SetId(parts[2])
+	d.Set("client_id", parts[0])
+	d.Set("account_id", parts[1])
+	d.Set("working_environment_id", parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceBackupActivationCustomizeDiff checks the object storage arguments required by the provider
+func resourceBackupActivationCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	var required []string
+	switch diff.Get("provider_name").(string) {
+	case "AZURE":
+		required = []string{"azure_resource_group", "azure_storage_account"}
+	case "GCP":
+		required = []string{"gcp_project_id"}
+	}
+	for _, key := range required {
+		// a value coming from another resource is not known until apply
+		if diff.NewValueKnown(key) && diff.Get(key).(string) == "" {
+			return fmt.Errorf("%s is required when provider_name is %s", key, diff.Get("provider_name").(string))
+		}
+	}
+	return nil
+}
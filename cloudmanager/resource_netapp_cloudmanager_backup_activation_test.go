@@ -0,0 +1,125 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestBackupActivationCRUD_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	r := resourceBackupActivation()
+
+	config := map[string]interface{}{
+		"account_id":               "account-mock",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+		"provider_name":            "AWS",
+		"region":                   "us-east-1",
+		"encryption_key_id":        "arn:aws:kms:us-east-1:123456789012:key/mock",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "VsaWorkingEnvironment-mock" || d.Get("status").(string) != "ACTIVE" {
+		t.Fatalf("create: unexpected state %v", d.State())
+	}
+	if d.Get("bucket").(string) != "netapp-backup-VsaWorkingEnvironment-mock" || d.Get("ip_space").(string) != "Default" {
+		t.Fatalf("create: expected the defaults to be read, got %v", d.State())
+	}
+	if got := mock.backups["VsaWorkingEnvironment-mock"]["encryptionKeyId"]; got != "arn:aws:kms:us-east-1:123456789012:key/mock" {
+		t.Fatalf("create: expected the encryption key to be sent, got %v", got)
+	}
+
+	config["policy_name"] = "gold"
+	config["auto_backup_enabled"] = true
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if d.Get("policy_name").(string) != "gold" || !d.Get("auto_backup_enabled").(bool) {
+		t.Fatalf("update: unexpected state %v", d.State())
+	}
+
+	// import
+	imported := r.Data(nil)
+	imported.SetId("mock:account-mock:VsaWorkingEnvironment-mock")
+	states, err := r.Importer.StateContext(context.Background(), imported, client)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), states[0], client); diags.HasError() {
+		t.Fatalf("import read: %v", diags)
+	}
+	if states[0].Get("provider_name").(string) != "AWS" || states[0].Get("region").(string) != "us-east-1" {
+		t.Fatalf("import: unexpected state %v", states[0].State())
+	}
+
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deactivated backup to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
+
+func TestBackupActivationRead_mock(t *testing.T) {
+	mock := newMockOCCM(t)
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "VsaWorkingEnvironment-mock", Name: "mockvsa", ProviderName: "Azure"})
+	client := mock.client()
+	r := resourceBackupActivation()
+
+	config := map[string]interface{}{
+		"account_id":               "account-mock",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+		"provider_name":            "AZURE",
+		"azure_resource_group":     "rg-backup",
+		"azure_storage_account":    "netappbackup",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+
+	// the provider is reported in another case, and the storage account was replaced
+	mock.backups["VsaWorkingEnvironment-mock"]["provider"] = "Azure"
+	mock.backups["VsaWorkingEnvironment-mock"]["storageAccount"] = "netappbackup2"
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if got := d.Get("provider_name").(string); got != "AZURE" {
+		t.Fatalf("read: expected the provider in upper case, got %q", got)
+	}
+	if d.Get("azure_resource_group").(string) != "rg-backup" || d.Get("azure_storage_account").(string) != "netappbackup2" {
+		t.Fatalf("read: expected the storage account to be read back, got %v", d.State())
+	}
+
+	// a provider missing from the response is kept
+	delete(mock.backups["VsaWorkingEnvironment-mock"], "provider")
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if got := d.Get("provider_name").(string); got != "AZURE" {
+		t.Fatalf("read: expected the provider to be kept, got %q", got)
+	}
+}
+
+func TestBackupActivationCustomizeDiff(t *testing.T) {
+	r := resourceBackupActivation()
+	for name, raw := range map[string]map[string]interface{}{
+		"azure storage account": {"provider_name": "AZURE", "azure_resource_group": "rg1"},
+		"gcp project":           {"provider_name": "GCP"},
+	} {
+		raw["account_id"] = "account-mock"
+		raw["client_id"] = "mock"
+		if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package cloudmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBackupPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceBackupPolicyCreate,
+		ReadContext:   resourceBackupPolicyRead,
+		UpdateContext: resourceBackupPolicyUpdate,
+		DeleteContext: resourceBackupPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceBackupPolicyImport,
+		},
+		CustomizeDiff: resourceBackupPolicyCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"daily_retention": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"weekly_retention": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"monthly_retention": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"archive_after_days": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      0,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"archive_storage_class": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"GLACIER", "DEEP_ARCHIVE", "ARCHIVE"}, false),
+			},
+		},
+	}
+}
+
+func expandBackupPolicy(d *schema.ResourceData) backupPolicyRequest {
+	policy := backupPolicyRequest{}
+	policy.Name = d.Get("name").(string)
+	policy.Rules = expandBackupPolicyRules(map[string]int{
+		"daily":   d.Get("daily_retention").(int),
+		"weekly":  d.Get("weekly_retention").(int),
+		"monthly": d.Get("monthly_retention").(int),
+	})
+	policy.ArchivePolicy.ArchiveAfterDays = d.Get("archive_after_days").(int)
+	policy.ArchivePolicy.StorageClass = d.Get("archive_storage_class").(string)
+	return policy
+}
+
+func resourceBackupPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Creating backup policy: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	accountID := d.Get("account_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	policy := expandBackupPolicy(d)
+	err = client.createBackupPolicy(ctx, accountID, workingEnv.PublicID, policy, clientID)
+	if err != nil {
+		log.Print("Error creating backup policy")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(policy.Name)
+	d.Set("working_environment_id", workingEnv.PublicID)
+
+	log.Printf("Created backup policy: %v", policy.Name)
+
+	return resourceBackupPolicyRead(ctx, d, meta)
+}
+
+func resourceBackupPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Reading backup policy: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	accountID := d.Get("account_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "backup policy")
+	}
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	res, err := client.getBackupPolicy(ctx, accountID, workingEnv.PublicID, d.Id(), clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "backup policy")
+	}
+	if err != nil {
+		log.Printf("Error getting backup policy. id = %v", d.Id())
+		return diag.FromErr(err)
+	}
+
+	retentions := flattenBackupPolicyRules(res.Rules)
+	d.Set("daily_retention", retentions["daily"])
+	d.Set("weekly_retention", retentions["weekly"])
+	d.Set("monthly_retention", retentions["monthly"])
+	d.Set("archive_after_days", res.ArchivePolicy.ArchiveAfterDays)
+	d.Set("archive_storage_class", res.ArchivePolicy.StorageClass)
+
+	return nil
+}
+
+func resourceBackupPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating backup policy: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	accountID := d.Get("account_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	policy := expandBackupPolicy(d)
+	err = client.updateBackupPolicy(ctx, accountID, workingEnv.PublicID, policy, clientID)
+	if err != nil {
+		log.Print("Error updating backup policy")
+		return diag.FromErr(err)
+	}
+
+	log.Printf("Updated backup policy: %v", d.Id())
+
+	return resourceBackupPolicyRead(ctx, d, meta)
+}
+
+func resourceBackupPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Deleting backup policy: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	accountID := d.Get("account_id").(string)
+
+	workingEnv, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.deleteBackupPolicy(ctx, accountID, workingEnv.PublicID, d.Id(), clientID)
+	if err != nil {
+		log.Print("Error deleting backup policy")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceBackupPolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 4 {
+		return []*schema.ResourceData{}, fmt.Errorf("Wrong format of resource: %s. Please follow 'client_id:account_id:working_environment_id:policy_name'", d.Id())
+	}
+
+	d.SetId(parts[3])
+	d.Set("client_id", parts[0])
+	d.Set("account_id", parts[1])
+	d.Set("working_environment_id", parts[2])
+	d.Set("name", parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceBackupPolicyCustomizeDiff rejects a policy keeping no backup, or archiving them without a storage class
+func resourceBackupPolicyCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	if diff.Get("daily_retention").(int) == 0 && diff.Get("weekly_retention").(int) == 0 && diff.Get("monthly_retention").(int) == 0 {
+		return fmt.Errorf("at least one of daily_retention, weekly_retention and monthly_retention is required")
+	}
+	if diff.Get("archive_after_days").(int) > 0 && diff.Get("archive_storage_class").(string) == "" {
+		return fmt.Errorf("archive_storage_class is required when archive_after_days is set")
+	}
+	return nil
+}
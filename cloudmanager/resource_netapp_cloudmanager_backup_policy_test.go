@@ -0,0 +1,91 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestBackupPolicyCRUD_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	r := resourceBackupPolicy()
+
+	config := map[string]interface{}{
+		"name":                     "gold",
+		"account_id":               "account-mock",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+		"daily_retention":          7,
+		"weekly_retention":         4,
+	}
+
+	// Cloud Backup has to be active first
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); !diags.HasError() {
+		t.Fatal("create: expected an error when backup is not active")
+	}
+	mock.backups["VsaWorkingEnvironment-mock"] = map[string]interface{}{"provider": "AWS", "status": "ACTIVE"}
+
+	d = schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "gold" || d.Get("daily_retention").(int) != 7 || d.Get("monthly_retention").(int) != 0 {
+		t.Fatalf("create: unexpected state %v", d.State())
+	}
+	if got := len(mock.backupPolicies["VsaWorkingEnvironment-mock"][0]["rules"].([]interface{})); got != 2 {
+		t.Fatalf("create: expected a rule per retention, got %d", got)
+	}
+
+	config["daily_retention"] = 0
+	config["monthly_retention"] = 12
+	config["archive_after_days"] = 90
+	config["archive_storage_class"] = "DEEP_ARCHIVE"
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if d.Get("daily_retention").(int) != 0 || d.Get("monthly_retention").(int) != 12 || d.Get("archive_storage_class").(string) != "DEEP_ARCHIVE" {
+		t.Fatalf("update: unexpected state %v", d.State())
+	}
+
+	// import
+	imported := r.Data(nil)
+	imported.SetId("mock:account-mock:VsaWorkingEnvironment-mock:gold")
+	states, err := r.Importer.StateContext(context.Background(), imported, client)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), states[0], client); diags.HasError() {
+		t.Fatalf("import read: %v", diags)
+	}
+	if states[0].Get("weekly_retention").(int) != 4 || states[0].Get("archive_after_days").(int) != 90 {
+		t.Fatalf("import: unexpected state %v", states[0].State())
+	}
+
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted policy to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
+
+func TestBackupPolicyCustomizeDiff(t *testing.T) {
+	r := resourceBackupPolicy()
+	for name, raw := range map[string]map[string]interface{}{
+		"no retention":          {},
+		"archive storage class": {"daily_retention": 7, "archive_after_days": 30},
+	} {
+		raw["name"] = "gold"
+		raw["account_id"] = "account-mock"
+		raw["client_id"] = "mock"
+		if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -1,23 +1,29 @@
 package cloudmanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCVOCIFS() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCVOCIFSCreate,
-		Read:   resourceCVOCIFSRead,
-		Delete: resourceCVOCIFSDelete,
-		Exists: resourceCVOCIFSExists,
+		CreateContext: resourceCVOCIFSCreate,
+		ReadContext:   resourceCVOCIFSRead,
+		DeleteContext: resourceCVOCIFSDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"domain": {
 				Type:     schema.TypeString,
@@ -120,7 +126,7 @@ func resourceCVOCIFS() *schema.Resource {
 	}
 }
 
-func resourceCVOCIFSCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOCIFSCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating cifs: %#v", d)
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
@@ -139,9 +145,9 @@ func resourceCVOCIFSCreate(d *schema.ResourceData, meta interface{}) error {
 	cifs.NetBIOS = d.Get("netbios").(string)
 	cifs.OrganizationalUnit = d.Get("organizational_unit").(string)
 
-	workingEnvDetail, err := client.getWorkingEnvironmentDetail(d, clientID)
+	workingEnvDetail, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	cifs.WorkingEnvironmentID = workingEnvDetail.PublicID
 
@@ -151,16 +157,16 @@ func resourceCVOCIFSCreate(d *schema.ResourceData, meta interface{}) error {
 		cifs.SvmName = workingEnvDetail.SvmName
 	}
 
-	err = client.createCIFS(cifs, clientID)
+	err = client.createCIFS(ctx, cifs, clientID)
 	if err != nil {
 		log.Print("Error creating cifs")
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(cifs.SvmName)
-	return resourceCVOCIFSRead(d, meta)
+	return resourceCVOCIFSRead(ctx, d, meta)
 }
 
-func resourceCVOCIFSRead(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOCIFSRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Fetching volume: %#v", d)
 
 	client := meta.(*Client)
@@ -170,9 +176,12 @@ func resourceCVOCIFSRead(d *schema.ResourceData, meta interface{}) error {
 	if v, ok := d.GetOk("working_environment_id"); ok {
 		cifs.WorkingEnvironmentID = v.(string)
 	} else {
-		workingEnvDetail, err := client.getWorkingEnvironmentDetail(d, clientID)
+		workingEnvDetail, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+		if isNotFound(err) {
+			return removeFromState(d, "cifs")
+		}
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		cifs.WorkingEnvironmentID = workingEnvDetail.PublicID
 	}
@@ -180,10 +189,13 @@ func resourceCVOCIFSRead(d *schema.ResourceData, meta interface{}) error {
 	if v, ok := d.GetOk("svm_name"); ok {
 		cifs.SvmName = v.(string)
 	}
-	res, err := client.getCIFS(cifs, clientID)
+	res, err := client.getCIFS(ctx, cifs, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "cifs")
+	}
 	if err != nil {
 		log.Print("Error reading cifs")
-		return err
+		return diag.FromErr(err)
 	}
 	for _, cifsConfig := range res {
 		log.Printf("cifs config: %v", cifsConfig)
@@ -193,10 +205,10 @@ func resourceCVOCIFSRead(d *schema.ResourceData, meta interface{}) error {
 			return nil
 		}
 	}
-	return fmt.Errorf("error reading cifs: cifs doesn't exist")
+	return removeFromState(d, "cifs")
 }
 
-func resourceCVOCIFSDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOCIFSDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting cifs: %#v", d)
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
@@ -206,9 +218,9 @@ func resourceCVOCIFSDelete(d *schema.ResourceData, meta interface{}) error {
 	if v, ok := d.GetOk("working_environment_id"); ok {
 		workingEnvironmentID = v.(string)
 	} else {
-		workingEnvDetail, err := client.getWorkingEnvironmentDetail(d, clientID)
+		workingEnvDetail, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		workingEnvironmentID = workingEnvDetail.PublicID
 	}
@@ -217,41 +229,14 @@ func resourceCVOCIFSDelete(d *schema.ResourceData, meta interface{}) error {
 	if v, ok := d.GetOk("svm_name"); ok {
 		cifs.SvmName = v.(string)
 	}
-	err := client.deleteCIFS(cifs, workingEnvironmentID, clientID)
+	err := client.deleteCIFS(ctx, cifs, workingEnvironmentID, clientID)
 	if err != nil {
 		log.Print("Error deleting cifs")
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceCVOCIFSExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	log.Printf("Fetching cifs: %#v", d)
-	client := meta.(*Client)
-	clientID := d.Get("client_id").(string)
-	cifs := cifsRequest{}
-
-	workingEnvDetail, err := client.getWorkingEnvironmentDetail(d, clientID)
-	if err != nil {
-		return false, nil
-	}
-	cifs.WorkingEnvironmentID = workingEnvDetail.PublicID
-	if v, ok := d.GetOk("svm_name"); ok {
-		cifs.SvmName = v.(string)
-	}
-	res, err := client.getCIFS(cifs, clientID)
-	if err != nil {
-		log.Print("Error reading cifs")
-		return false, err
-	}
-	if len(res) == 0 {
-		d.SetId("")
-		return false, fmt.Errorf("cifs doesn't exist")
-	}
-
-	return true, nil
-}
-
 func resourceCVOCIFSUpdate(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
@@ -0,0 +1,39 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestCIFSServerCRUD_mock(t *testing.T) {
+	_, client := newMockVsa(t)
+	r := resourceCVOCIFS()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"domain":                   "test.com",
+		"username":                 "admin",
+		"password":                 "password",
+		"dns_domain":               "test.com",
+		"ip_addresses":             []interface{}{"10.10.10.1"},
+		"netbios":                  "cvoserver",
+		"organizational_unit":      "CN=Computers",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+	})
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "svm_mockvsa" {
+		t.Fatalf("create: expected ID svm_mockvsa, got %q", d.Id())
+	}
+
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId("svm_mockvsa")
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted CIFS server to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
@@ -0,0 +1,255 @@
+package cloudmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceCifsShare() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceCifsShareCreate,
+		ReadContext:   resourceCifsShareRead,
+		UpdateContext: resourceCifsShareUpdate,
+		DeleteContext: resourceCifsShareDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceCifsShareImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"comment": {
+				Type:     schema.TypeString,
+				Optional: true,
+			},
+			"access_control": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"permission": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringInSlice([]string{"full_control", "change", "read", "no_access"}, false),
+						},
+						"users": {
+							Type:     schema.TypeSet,
+							Required: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+			"oplocks": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"continuously_available": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+			"access_based_enumeration": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+// getCifsShareRequest returns the request for the share of the resource, with the working environment ID
+func getCifsShareRequest(ctx context.Context, client *Client, d *schema.ResourceData, clientID string) (string, cifsShareRequest, error) {
+	share := cifsShareRequest{}
+	workingEnv, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return "", cifsShareRequest{}, err
+	}
+	share.SvmName = workingEnv.SvmName
+	if v, ok := d.GetOk("svm_name"); ok {
+		share.SvmName = v.(string)
+	}
+	share.ShareName = d.Get("name").(string)
+	share.VolumeName = d.Get("volume_name").(string)
+	share.Path = d.Get("path").(string)
+	if share.Path == "" {
+		share.Path = "/" + share.VolumeName
+	}
+	share.Comment = d.Get("comment").(string)
+	share.AccessControlList = expandCifsShareAccessControl(d.Get("access_control").(*schema.Set))
+	share.Oplocks = d.Get("oplocks").(bool)
+	share.ContinuouslyAvailable = d.Get("continuously_available").(bool)
+	share.AccessBasedEnumeration = d.Get("access_based_enumeration").(bool)
+	return workingEnv.PublicID, share, nil
+}
+
+func resourceCifsShareCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Creating CIFS share: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnvironmentID, share, err := getCifsShareRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.createCifsShare(ctx, workingEnvironmentID, share, clientID)
+	if err != nil {
+		log.Print("Error creating CIFS share")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(share.ShareName)
+	d.Set("working_environment_id", workingEnvironmentID)
+	d.Set("svm_name", share.SvmName)
+
+	log.Printf("Created CIFS share: %v", share.ShareName)
+
+	return resourceCifsShareRead(ctx, d, meta)
+}
+
+func resourceCifsShareRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Reading CIFS share: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnvironmentID, share, err := getCifsShareRequest(ctx, client, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "CIFS share")
+	}
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	res, err := client.getCifsShare(ctx, workingEnvironmentID, share.SvmName, d.Id(), clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "CIFS share")
+	}
+	if err != nil {
+		log.Printf("Error getting CIFS share. id = %v", d.Id())
+		return diag.FromErr(err)
+	}
+
+	if res.VolumeName != "" {
+		d.Set("volume_name", res.VolumeName)
+	}
+	d.Set("path", res.Path)
+	d.Set("comment", res.Comment)
+	if err := d.Set("access_control", flattenCifsShareAccessControl(res.AccessControlList)); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("oplocks", res.Oplocks)
+	d.Set("continuously_available", res.ContinuouslyAvailable)
+	d.Set("access_based_enumeration", res.AccessBasedEnumeration)
+
+	return nil
+}
+
+func resourceCifsShareUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating CIFS share: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnvironmentID, share, err := getCifsShareRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.updateCifsShare(ctx, workingEnvironmentID, share, clientID)
+	if err != nil {
+		log.Print("Error updating CIFS share")
+		return diag.FromErr(err)
+	}
+
+	log.Printf("Updated CIFS share: %v", share.ShareName)
+
+	return resourceCifsShareRead(ctx, d, meta)
+}
+
+func resourceCifsShareDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Deleting CIFS share: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnvironmentID, share, err := getCifsShareRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.deleteCifsShare(ctx, workingEnvironmentID, share.SvmName, d.Id(), clientID)
+	if err != nil {
+		log.Print("Error deleting CIFS share")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceCifsShareImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 4 {
+		return []*schema.ResourceData{}, fmt.Errorf("Wrong format of resource: %s. Please follow 'client_id:working_environment_id:svm_name:share_name'", d.Id())
+	}
+
+	d.SetId(parts[3])
+	d.Set("client_id", parts[0])
+	d.Set("working_environment_id", parts[1])
+	d.Set("svm_name", parts[2])
+	d.Set("name", parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
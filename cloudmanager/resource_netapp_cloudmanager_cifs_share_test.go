@@ -0,0 +1,99 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestCifsShareCRUD_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	mock.addVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "vol1")
+	r := resourceCifsShare()
+
+	// two shares of the same volume
+	engineering := map[string]interface{}{
+		"name":                     "engineering",
+		"volume_name":              "vol1",
+		"path":                     "/vol1/engineering",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+		"access_control": []interface{}{
+			map[string]interface{}{"permission": "full_control", "users": []interface{}{"DOMAIN\\admins"}},
+			map[string]interface{}{"permission": "read", "users": []interface{}{"DOMAIN\\engineers", "DOMAIN\\qa"}},
+		},
+		"continuously_available": true,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, engineering)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "engineering" || d.Get("svm_name").(string) != "svm_mockvsa" {
+		t.Fatalf("create: unexpected state %v", d.State())
+	}
+	if got := d.Get("access_control").(*schema.Set).Len(); got != 2 {
+		t.Fatalf("create: expected 2 access control entries, got %d", got)
+	}
+	if !d.Get("continuously_available").(bool) || !d.Get("oplocks").(bool) || d.Get("access_based_enumeration").(bool) {
+		t.Fatalf("create: unexpected share properties %v", d.State())
+	}
+
+	public := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                     "public",
+		"volume_name":              "vol1",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+	})
+	if diags := r.CreateContext(context.Background(), public, client); diags.HasError() {
+		t.Fatalf("create public: %v", diags)
+	}
+	if got := public.Get("path").(string); got != "/vol1" {
+		t.Fatalf("create public: expected the path to default to the volume, got %q", got)
+	}
+	if got := len(mock.cifsShares["VsaWorkingEnvironment-mock"]); got != 2 {
+		t.Fatalf("create public: expected 2 shares, got %d", got)
+	}
+
+	engineering["access_control"] = []interface{}{
+		map[string]interface{}{"permission": "change", "users": []interface{}{"DOMAIN\\engineers"}},
+	}
+	engineering["access_based_enumeration"] = true
+	engineering["oplocks"] = false
+	d = testResourceDataUpdate(t, r, d, engineering, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if got := d.Get("access_control").(*schema.Set).Len(); got != 1 {
+		t.Fatalf("update: expected 1 access control entry, got %d", got)
+	}
+	if !d.Get("access_based_enumeration").(bool) || d.Get("oplocks").(bool) {
+		t.Fatalf("update: unexpected share properties %v", d.State())
+	}
+
+	// import
+	imported := r.Data(nil)
+	imported.SetId("mock:VsaWorkingEnvironment-mock:svm_mockvsa:engineering")
+	states, err := r.Importer.StateContext(context.Background(), imported, client)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), states[0], client); diags.HasError() {
+		t.Fatalf("import read: %v", diags)
+	}
+	if states[0].Get("volume_name").(string) != "vol1" || states[0].Get("path").(string) != "/vol1/engineering" {
+		t.Fatalf("import: unexpected state %v", states[0].State())
+	}
+
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted share to be removed from state, got %q %v", d.Id(), diags)
+	}
+	if got := len(mock.cifsShares["VsaWorkingEnvironment-mock"]); got != 1 {
+		t.Fatalf("delete: expected the other share to be kept, got %d shares", got)
+	}
+}
@@ -1,26 +1,33 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceOCCMAWS() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOCCMAWSCreate,
-		Read:   resourceOCCMAWSRead,
-		Delete: resourceOCCMAWSDelete,
-		Exists: resourceOCCMAWSExists,
-		Update: resourceOCCMAWSUpdate,
+		CreateContext: resourceOCCMAWSCreate,
+		ReadContext:   resourceOCCMAWSRead,
+		DeleteContext: resourceOCCMAWSDelete,
+		UpdateContext: resourceOCCMAWSUpdate,
 		Importer: &schema.ResourceImporter{
-			State: resourceOCCMAWSImport,
+			StateContext: resourceOCCMAWSImport,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -147,7 +154,7 @@ func resourceOCCMAWS() *schema.Resource {
 	}
 }
 
-func resourceOCCMAWSCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceOCCMAWSCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating OCCM: %#v", d)
 
 	client := meta.(*Client)
@@ -170,7 +177,7 @@ func resourceOCCMAWSCreate(d *schema.ResourceData, meta interface{}) error {
 		if occmDetails.ProxyURL != "" {
 			occmDetails.ProxyUserName = o.(string)
 		} else {
-			return fmt.Errorf("Missing proxy_url")
+			return attributeErrorf("proxy_user_name", "Missing proxy_url")
 		}
 	}
 
@@ -178,7 +185,7 @@ func resourceOCCMAWSCreate(d *schema.ResourceData, meta interface{}) error {
 		if occmDetails.ProxyURL != "" {
 			occmDetails.ProxyPassword = o.(string)
 		} else {
-			return fmt.Errorf("Missing proxy_url")
+			return attributeErrorf("proxy_password", "Missing proxy_url")
 		}
 	}
 
@@ -188,7 +195,7 @@ func resourceOCCMAWSCreate(d *schema.ResourceData, meta interface{}) error {
 			// read file
 			b, err := ioutil.ReadFile(cFile.(string))
 			if err != nil {
-				return fmt.Errorf("Cannot read certificate file: %s", err)
+				return attributeErrorf("proxy_certificates", "Cannot read certificate file: %s", err)
 			}
 			// endcode certificate
 			encodedCertificate := base64.StdEncoding.EncodeToString(b)
@@ -222,28 +229,28 @@ func resourceOCCMAWSCreate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	res, err := client.createOCCM(occmDetails, proxyCertificates, "")
+	res, err := client.createOCCM(ctx, occmDetails, proxyCertificates, "")
 
 	if err != nil {
 		log.Print("Error creating instance")
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(res.InstanceID)
 	if err := d.Set("client_id", res.ClientID); err != nil {
-		return fmt.Errorf("Error reading occm client_id: %s", err)
+		return diag.Errorf("Error reading occm client_id: %s", err)
 	}
 
 	if err := d.Set("account_id", res.AccountID); err != nil {
-		return fmt.Errorf("Error reading occm account_id: %s", err)
+		return diag.Errorf("Error reading occm account_id: %s", err)
 	}
 
 	log.Printf("Created occm: %v", res)
 
-	return resourceOCCMAWSRead(d, meta)
+	return resourceOCCMAWSRead(ctx, d, meta)
 }
 
-func resourceOCCMAWSRead(d *schema.ResourceData, meta interface{}) error {
+func resourceOCCMAWSRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Reading OCCM: %#v", d)
 	client := meta.(*Client)
 	var clientID string
@@ -268,18 +275,14 @@ func resourceOCCMAWSRead(d *schema.ResourceData, meta interface{}) error {
 
 	id := d.Id()
 
-	res, err := client.getAWSInstance(occmDetails, id)
+	res, err := client.getAWSInstance(ctx, occmDetails, id)
 	if err != nil {
 		log.Print("Error getting occm")
-		return err
-	}
-
-	if res.InstanceId == nil {
-		return fmt.Errorf("Connector %s not found", occmDetails.Name)
+		return diag.FromErr(err)
 	}
 
-	if *res.InstanceId != id {
-		return fmt.Errorf("Expected occm ID %s, Response could not find", id)
+	if res.InstanceId == nil || *res.InstanceId != id {
+		return removeFromState(d, "connector")
 	}
 
 	if occmDetails.Region == "" {
@@ -288,9 +291,9 @@ func resourceOCCMAWSRead(d *schema.ResourceData, meta interface{}) error {
 		d.Set("region", occmDetails.Region)
 	}
 	occmDetails.InstanceID = *res.InstanceId
-	disableAPITermination, err := client.CallAWSDescribeInstanceAttribute(occmDetails)
+	disableAPITermination, err := client.CallAWSDescribeInstanceAttribute(ctx, occmDetails)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("enable_termination_protection", disableAPITermination)
 	d.Set("instance_type", res.InstanceType)
@@ -354,10 +357,10 @@ func resourceOCCMAWSRead(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if _, ok := d.GetOk("company"); !ok {
-		company, err := client.getCompany(clientID)
+		company, err := client.getCompany(ctx, clientID)
 		if err != nil {
 			log.Printf("Error when reading system info from cloudmanager.")
-			return err
+			return diag.FromErr(err)
 		}
 		d.Set("company", company)
 	}
@@ -365,7 +368,7 @@ func resourceOCCMAWSRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceOCCMAWSDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOCCMAWSDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting OCCM: %#v", d)
 
 	client := meta.(*Client)
@@ -378,55 +381,16 @@ func resourceOCCMAWSDelete(d *schema.ResourceData, meta interface{}) error {
 	clientID := d.Get("client_id").(string)
 	client.AccountID = d.Get("account_id").(string)
 
-	deleteErr := client.deleteOCCM(occmDetails, clientID)
+	deleteErr := client.deleteOCCM(ctx, occmDetails, clientID)
 	if deleteErr != nil {
-		return deleteErr
+		return diag.FromErr(deleteErr)
 	}
 
 	return nil
 }
 
-func resourceOCCMAWSExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	log.Printf("Checking existence of OCCM: %#v", d)
-	client := meta.(*Client)
-
-	id := d.Id()
-	occmDetails := createOCCMDetails{}
-
-	occmDetails.Name = d.Get("name").(string)
-	occmDetails.Region = d.Get("region").(string)
-	occmDetails.InstanceType = d.Get("instance_type").(string)
-	occmDetails.SubnetID = d.Get("subnet_id").(string)
-	occmDetails.SecurityGroupID = d.Get("security_group_id").(string)
-	occmDetails.KeyName = d.Get("key_name").(string)
-	occmDetails.IamInstanceProfileName = d.Get("iam_instance_profile_name").(string)
-	occmDetails.Company = d.Get("company").(string)
-
-	if o, ok := d.GetOk("ami"); ok {
-		occmDetails.AMI = o.(string)
-	}
-
-	res, err := client.getAWSInstance(occmDetails, id)
-	if err != nil {
-		log.Print("Error getting occm")
-		return false, err
-	}
-
-	if res.InstanceId == nil {
-		d.SetId("")
-		return false, nil
-	}
-
-	if *res.InstanceId != id {
-		d.SetId("")
-		return false, nil
-	}
-
-	return true, nil
-}
-
 // resourceOCCMAWSUpdate updates occm. Currently only tags can be updated.
-func resourceOCCMAWSUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceOCCMAWSUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 
 	occmDetails := createOCCMDetails{}
@@ -459,16 +423,16 @@ func resourceOCCMAWSUpdate(d *schema.ResourceData, meta interface{}) error {
 	occmDetails.Company = d.Get("company").(string)
 	occmDetails.InstanceID = d.Id()
 
-	err := client.updateOCCM(occmDetails, nil, deleteAwsTags, addModifyAwsTags, clientID)
+	err := client.updateOCCM(ctx, occmDetails, nil, deleteAwsTags, addModifyAwsTags, clientID)
 
 	if err != nil {
 		log.Print("Error updating instance")
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceOCCMAWSImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceOCCMAWSImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	parts := strings.Split(d.Id(), ":")
 	if len(parts) != 2 {
 		return []*schema.ResourceData{}, fmt.Errorf("Wrong format of resource: %s. Please follow 'client_id/connector_id'", d.Id())
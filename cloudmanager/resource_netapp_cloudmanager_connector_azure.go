@@ -1,25 +1,31 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceOCCMAzure() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOCCMAzureCreate,
-		Read:   resourceOCCMAzureRead,
-		Delete: resourceOCCMAzureDelete,
-		Exists: resourceOCCMAzureExists,
+		CreateContext: resourceOCCMAzureCreate,
+		ReadContext:   resourceOCCMAzureRead,
+		DeleteContext: resourceOCCMAzureDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -146,7 +152,7 @@ func resourceOCCMAzure() *schema.Resource {
 	}
 }
 
-func resourceOCCMAzureCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceOCCMAzureCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating OCCM: %#v", d)
 
 	client := meta.(*Client)
@@ -179,7 +185,7 @@ func resourceOCCMAzureCreate(d *schema.ResourceData, meta interface{}) error {
 		if occmDetails.ProxyURL != "" {
 			occmDetails.ProxyUserName = o.(string)
 		} else {
-			return fmt.Errorf("missing proxy_url")
+			return attributeErrorf("proxy_user_name", "missing proxy_url")
 		}
 	}
 
@@ -187,7 +193,7 @@ func resourceOCCMAzureCreate(d *schema.ResourceData, meta interface{}) error {
 		if occmDetails.ProxyURL != "" {
 			occmDetails.ProxyPassword = o.(string)
 		} else {
-			return fmt.Errorf("missing proxy_url")
+			return attributeErrorf("proxy_password", "missing proxy_url")
 		}
 	}
 
@@ -197,7 +203,7 @@ func resourceOCCMAzureCreate(d *schema.ResourceData, meta interface{}) error {
 			// read file
 			b, err := ioutil.ReadFile(cFile.(string))
 			if err != nil {
-				return fmt.Errorf("cannot read certificate file: %s", err)
+				return attributeErrorf("proxy_certificates", "cannot read certificate file: %s", err)
 			}
 			// endcode certificate
 			encodedCertificate := base64.StdEncoding.EncodeToString(b)
@@ -223,33 +229,33 @@ func resourceOCCMAzureCreate(d *schema.ResourceData, meta interface{}) error {
 		occmDetails.StorageAccount = o.(string)
 	}
 
-	res, err := client.createOCCMAzure(occmDetails, proxyCertificates, "")
+	res, err := client.createOCCMAzure(ctx, occmDetails, proxyCertificates, "")
 	if err != nil {
 		log.Print("Error creating instance")
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(occmDetails.Name)
 	log.Print("Set ID: ", occmDetails.Name)
 
 	if err := d.Set("principal_id", res.PrincipalID); err != nil {
-		return fmt.Errorf("error reading occm principal_id: %s", err)
+		return diag.Errorf("error reading occm principal_id: %s", err)
 	}
 
 	if err := d.Set("client_id", res.ClientID); err != nil {
-		return fmt.Errorf("error reading occm client_id: %s", err)
+		return diag.Errorf("error reading occm client_id: %s", err)
 	}
 
 	if err := d.Set("account_id", res.AccountID); err != nil {
-		return fmt.Errorf("error reading occm account_id: %s", err)
+		return diag.Errorf("error reading occm account_id: %s", err)
 	}
 
 	log.Printf("Created occm: %v", res)
 
-	return resourceOCCMAzureRead(d, meta)
+	return resourceOCCMAzureRead(ctx, d, meta)
 }
 
-func resourceOCCMAzureRead(d *schema.ResourceData, meta interface{}) error {
+func resourceOCCMAzureRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Reading OCCM: %#v", d)
 	client := meta.(*Client)
 	occmDetails := createOCCMDetails{}
@@ -275,20 +281,20 @@ func resourceOCCMAzureRead(d *schema.ResourceData, meta interface{}) error {
 
 	id := d.Id()
 
-	resID, err := client.getdeployAzureVM(occmDetails, id)
+	resID, err := client.getdeployAzureVM(ctx, occmDetails, id)
 	if err != nil {
 		log.Print("Error getting occm")
-		return err
+		return diag.FromErr(err)
 	}
 
 	if resID != id {
-		return fmt.Errorf("expected occm ID %v, response could not find", id)
+		return removeFromState(d, "connector")
 	}
 
 	return nil
 }
 
-func resourceOCCMAzureDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOCCMAzureDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting OCCM: %#v", d)
 
 	client := meta.(*Client)
@@ -309,50 +315,14 @@ func resourceOCCMAzureDelete(d *schema.ResourceData, meta interface{}) error {
 	clientID := d.Get("client_id").(string)
 	client.AccountID = d.Get("account_id").(string)
 
-	deleteErr := client.deleteOCCMAzure(occmDetails, clientID)
+	deleteErr := client.deleteOCCMAzure(ctx, occmDetails, clientID)
 	if deleteErr != nil {
-		return deleteErr
+		return diag.FromErr(deleteErr)
 	}
 
 	return nil
 }
 
-func resourceOCCMAzureExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	log.Printf("Checking existence of OCCM: %#v", d)
-	client := meta.(*Client)
-
-	id := d.Id()
-	occmDetails := createOCCMDetails{}
-
-	occmDetails.Name = d.Get("name").(string)
-	occmDetails.Location = d.Get("location").(string)
-	occmDetails.SubnetID = d.Get("subnet_id").(string)
-	occmDetails.VnetID = d.Get("vnet_id").(string)
-	occmDetails.SubscriptionID = d.Get("subscription_id").(string)
-	occmDetails.Company = d.Get("company").(string)
-
-	if o, ok := d.GetOk("vnet_resource_group"); ok {
-		occmDetails.VnetResourceGroup = o.(string)
-	}
-
-	if o, ok := d.GetOk("resource_group"); ok {
-		occmDetails.ResourceGroup = o.(string)
-	}
-
-	resID, err := client.getdeployAzureVM(occmDetails, id)
-	if err != nil {
-		log.Print("Error getting occm")
-		return false, err
-	}
-
-	if resID != id {
-		d.SetId("")
-		return false, nil
-	}
-
-	return true, nil
-}
-
 func validateAzureSubnet() schema.SchemaValidateFunc {
 	return func(i interface{}, k string) (s []string, es []error) {
 		v, ok := i.(string)
@@ -1,6 +1,7 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"io/ioutil"
@@ -10,21 +11,27 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceOCCMGCP() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOCCMGCPCreate,
-		Read:   resourceOCCMGCPRead,
-		Delete: resourceOCCMGCPDelete,
-		Exists: resourceOCCMGCPExists,
-		Update: resourceOCCMGCPUpdate,
+		CreateContext: resourceOCCMGCPCreate,
+		ReadContext:   resourceOCCMGCPRead,
+		DeleteContext: resourceOCCMGCPDelete,
+		UpdateContext: resourceOCCMGCPUpdate,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -159,7 +166,7 @@ func resourceOCCMGCP() *schema.Resource {
 	}
 }
 
-func resourceOCCMGCPCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceOCCMGCPCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating OCCM: %#v", d)
 
 	client := meta.(*Client)
@@ -177,7 +184,7 @@ func resourceOCCMGCPCreate(d *schema.ResourceData, meta interface{}) error {
 	var err error
 	client.GCPServiceAccountKey, err = getGCPServiceAccountKey(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	occmDetails.FirewallTags = d.Get("firewall_tags").(bool)
@@ -196,7 +203,7 @@ func resourceOCCMGCPCreate(d *schema.ResourceData, meta interface{}) error {
 		if occmDetails.ProxyURL != "" {
 			occmDetails.ProxyUserName = o.(string)
 		} else {
-			return fmt.Errorf("Missing proxy_url")
+			return attributeErrorf("proxy_user_name", "Missing proxy_url")
 		}
 	}
 
@@ -204,7 +211,7 @@ func resourceOCCMGCPCreate(d *schema.ResourceData, meta interface{}) error {
 		if occmDetails.ProxyURL != "" {
 			occmDetails.ProxyPassword = o.(string)
 		} else {
-			return fmt.Errorf("Missing proxy_url")
+			return attributeErrorf("proxy_password", "Missing proxy_url")
 		}
 	}
 
@@ -222,7 +229,7 @@ func resourceOCCMGCPCreate(d *schema.ResourceData, meta interface{}) error {
 			// read file
 			b, err := ioutil.ReadFile(cFile.(string))
 			if err != nil {
-				return fmt.Errorf("Cannot read certificate file: %s", err)
+				return attributeErrorf("proxy_certificates", "Cannot read certificate file: %s", err)
 			}
 			// endcode certificate
 			encodedCertificate := base64.StdEncoding.EncodeToString(b)
@@ -244,27 +251,27 @@ func resourceOCCMGCPCreate(d *schema.ResourceData, meta interface{}) error {
 		occmDetails.Tags = tags
 	}
 
-	res, err := client.deployGCPVM(occmDetails, proxyCertificates, "")
+	res, err := client.deployGCPVM(ctx, occmDetails, proxyCertificates, "")
 	if err != nil {
 		log.Print("Error creating instance")
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(occmDetails.Name)
 	if err := d.Set("client_id", res.ClientID); err != nil {
-		return fmt.Errorf("Error reading occm client_id: %s", err)
+		return diag.Errorf("Error reading occm client_id: %s", err)
 	}
 
 	if err := d.Set("account_id", res.AccountID); err != nil {
-		return fmt.Errorf("Error reading occm account_id: %s", err)
+		return diag.Errorf("Error reading occm account_id: %s", err)
 	}
 
 	log.Printf("Created occm: %v", res)
 
-	return resourceOCCMGCPRead(d, meta)
+	return resourceOCCMGCPRead(ctx, d, meta)
 }
 
-func resourceOCCMGCPRead(d *schema.ResourceData, meta interface{}) error {
+func resourceOCCMGCPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Reading OCCM: %#v", d)
 	client := meta.(*Client)
 
@@ -280,29 +287,29 @@ func resourceOCCMGCPRead(d *schema.ResourceData, meta interface{}) error {
 	var err error
 	client.GCPServiceAccountKey, err = getGCPServiceAccountKey(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	occmDetails.Company = d.Get("company").(string)
 	clientID := d.Get("client_id").(string)
 
 	id := d.Id() + "-vm-boot-deployment"
 
-	resID, err := client.getdeployGCPVM(occmDetails, id, clientID)
+	resID, err := client.getdeployGCPVM(ctx, occmDetails, id, clientID)
 	if err != nil {
 		log.Print("Error getting occm")
-		return err
+		return diag.FromErr(err)
 	}
 
 	if resID != id {
-		return fmt.Errorf("Expected occm ID %v, Response could not find", id)
+		return removeFromState(d, "connector")
 	}
 
 	if _, ok := d.GetOk("tags"); ok {
-		instance, err := client.getVMInstance(occmDetails, clientID)
+		instance, err := client.getVMInstance(ctx, occmDetails, clientID)
 		tagItems := instance["tags"].(map[string]interface{})
 		tags := tagItems["items"].([]interface{})
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		var current []string
 		if d.Get("firewall_tags").(bool) {
@@ -319,8 +326,8 @@ func resourceOCCMGCPRead(d *schema.ResourceData, meta interface{}) error {
 
 	if _, ok := d.GetOk("labels"); ok {
 	}
-	disk, err := client.getDisk(occmDetails, clientID)
-	vmInstance, err := client.getVMInstance(occmDetails, clientID)
+	disk, err := client.getDisk(ctx, occmDetails, clientID)
+	vmInstance, err := client.getVMInstance(ctx, occmDetails, clientID)
 	vmLabels := make(map[string]interface{})
 	diskLabels := make(map[string]interface{})
 	if val, ok := vmInstance["labels"]; ok {
@@ -363,7 +370,7 @@ func resourceOCCMGCPRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceOCCMGCPDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOCCMGCPDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting OCCM: %#v", d)
 
 	client := meta.(*Client)
@@ -378,56 +385,21 @@ func resourceOCCMGCPDelete(d *schema.ResourceData, meta interface{}) error {
 	var err error
 	client.GCPServiceAccountKey, err = getGCPServiceAccountKey(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	occmDetails.Region = d.Get("zone").(string)
 	clientID := d.Get("client_id").(string)
 	client.AccountID = d.Get("account_id").(string)
 
-	deleteErr := client.deleteOCCMGCP(occmDetails, clientID)
+	deleteErr := client.deleteOCCMGCP(ctx, occmDetails, clientID)
 	if deleteErr != nil {
-		return deleteErr
+		return diag.FromErr(deleteErr)
 	}
 
 	return nil
 }
 
-func resourceOCCMGCPExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	log.Printf("Checking existence of OCCM: %#v", d)
-	client := meta.(*Client)
-
-	occmDetails := createOCCMDetails{}
-
-	occmDetails.GCPCommonSuffixName = "-vm-boot-deployment"
-	occmDetails.Name = d.Get("name").(string)
-	occmDetails.GCPProject = d.Get("project_id").(string)
-	occmDetails.Region = d.Get("zone").(string)
-	occmDetails.SubnetID = d.Get("subnet_id").(string)
-	var err error
-	client.GCPServiceAccountKey, err = getGCPServiceAccountKey(d)
-	if err != nil {
-		return false, err
-	}
-	occmDetails.Company = d.Get("company").(string)
-	clientID := d.Get("client_id").(string)
-
-	id := d.Id() + occmDetails.GCPCommonSuffixName
-
-	resID, err := client.getdeployGCPVM(occmDetails, id, clientID)
-	if err != nil {
-		log.Print("Error getting occm")
-		return false, err
-	}
-
-	if resID != id {
-		d.SetId("")
-		return false, nil
-	}
-
-	return true, nil
-}
-
-func resourceOCCMGCPUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceOCCMGCPUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Updating OCCM: %#v", d)
 	client := meta.(*Client)
 
@@ -442,14 +414,14 @@ func resourceOCCMGCPUpdate(d *schema.ResourceData, meta interface{}) error {
 	var err error
 	client.GCPServiceAccountKey, err = getGCPServiceAccountKey(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	occmDetails.Company = d.Get("company").(string)
 	clientID := d.Get("client_id").(string)
 
-	instance, err := client.getVMInstance(occmDetails, clientID)
+	instance, err := client.getVMInstance(ctx, occmDetails, clientID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if d.HasChange("tags") {
@@ -464,9 +436,9 @@ func resourceOCCMGCPUpdate(d *schema.ResourceData, meta interface{}) error {
 			tags = append(tags, []string{"firewall-tag-bvsu", "http-server", "https-server"}...)
 		}
 		occmDetails.Tags = tags
-		err = client.setVMInstaceTags(occmDetails, fingerprint, clientID)
+		err = client.setVMInstaceTags(ctx, occmDetails, fingerprint, clientID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -479,22 +451,22 @@ func resourceOCCMGCPUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 		updateLabels["labels"] = labels
 		updateLabels["labelFingerprint"] = instance["labelFingerprint"].(string)
-		err := client.setVMLabels(occmDetails, updateLabels, clientID)
+		err := client.setVMLabels(ctx, occmDetails, updateLabels, clientID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
-		disk, err := client.getDisk(occmDetails, clientID)
+		disk, err := client.getDisk(ctx, occmDetails, clientID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		updateLabels["labelFingerprint"] = disk["labelFingerprint"].(string)
-		err = client.setDiskLabels(occmDetails, updateLabels, clientID)
+		err = client.setDiskLabels(ctx, occmDetails, updateLabels, clientID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return resourceOCCMGCPRead(d, meta)
+	return resourceOCCMGCPRead(ctx, d, meta)
 }
 
 func getGCPServiceAccountKey(d *schema.ResourceData) (string, error) {
@@ -1,25 +1,32 @@
 package cloudmanager
 
 import (
+	"context"
 	"log"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCVOAWS() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCVOAWSCreate,
-		Read:   resourceCVOAWSRead,
-		Delete: resourceCVOAWSDelete,
-		Update: resourceCVOAWSUpdate,
-		Exists: resourceCVOAWSExists,
+		CreateWithoutTimeout: resourceCVOAWSCreate,
+		ReadContext:          resourceCVOAWSRead,
+		DeleteContext:        resourceCVOAWSDelete,
+		UpdateContext:        resourceCVOAWSUpdate,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		CustomizeDiff: resourceCVOAWSCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(90 * time.Minute),
+			Update: schema.DefaultTimeout(150 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -342,21 +349,23 @@ func resourceCVOAWS() *schema.Resource {
 				ForceNew: true,
 			},
 			"retries": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				ForceNew: true,
-				Default:  60,
+				Type:       schema.TypeInt,
+				Optional:   true,
+				ForceNew:   true,
+				Default:    60,
+				Deprecated: "use the create timeout in the timeouts block instead",
 			},
 		},
 	}
 }
 
-func resourceCVOAWSCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOAWSCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating CVO: %#v", d)
 
 	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(ctx, cvoCreateTimeout(d))
+	defer cancel()
 	clientID := d.Get("client_id").(string)
-	client.Retries = d.Get("retries").(int)
 
 	cvoDetails := createCVOAWSDetails{}
 
@@ -522,13 +531,13 @@ func resourceCVOAWSCreate(d *schema.ResourceData, meta interface{}) error {
 	err := validateCVOParams(cvoDetails)
 	if err != nil {
 		log.Print("Error validating parameters")
-		return err
+		return diag.FromErr(err)
 	}
 
-	res, err := client.createCVOAWS(cvoDetails, clientID)
+	res, err := client.createCVOAWS(ctx, cvoDetails, clientID)
 	if err != nil {
 		log.Print("Error creating instance")
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(res.PublicID)
@@ -536,10 +545,10 @@ func resourceCVOAWSCreate(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("Created cvo: %v", res)
 
-	return resourceCVOAWSRead(d, meta)
+	return resourceCVOAWSRead(ctx, d, meta)
 }
 
-func resourceCVOAWSRead(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOAWSRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Reading CVO: %#v", d)
 	client := meta.(*Client)
 
@@ -547,10 +556,13 @@ func resourceCVOAWSRead(d *schema.ResourceData, meta interface{}) error {
 
 	clientID := d.Get("client_id").(string)
 
-	resp, err := client.getCVOProperties(id, clientID)
+	resp, err := client.getCVOProperties(ctx, id, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "CVO")
+	}
 	if err != nil {
 		log.Print("Error reading cvo")
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("svm_name", resp.SvmName)
 	if c, ok := d.GetOk("writing_speed_state"); ok {
@@ -567,7 +579,7 @@ func resourceCVOAWSRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceCVOAWSDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOAWSDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting CVO: %#v", d)
 
 	client := meta.(*Client)
@@ -577,16 +589,16 @@ func resourceCVOAWSDelete(d *schema.ResourceData, meta interface{}) error {
 
 	isHA := d.Get("is_ha").(bool)
 
-	deleteErr := client.deleteCVO(id, isHA, clientID)
+	deleteErr := client.deleteCVO(ctx, id, isHA, clientID)
 	if deleteErr != nil {
 		log.Print("Error deleting cvo")
-		return deleteErr
+		return diag.FromErr(deleteErr)
 	}
 
 	return nil
 }
 
-func resourceCVOAWSUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOAWSUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Updating CVO: %#v", d)
 
 	client := meta.(*Client)
@@ -594,44 +606,44 @@ func resourceCVOAWSUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	// check if svm_password is changed
 	if d.HasChange("svm_password") {
-		respErr := updateCVOSVMPassword(d, meta, clientID)
+		respErr := updateCVOSVMPassword(ctx, d, meta, clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 	}
 
 	//  check if svm_name is changed
 	if d.HasChange("svm_name") {
 		svmName, svmNewName := d.GetChange("svm_name")
-		respErr := client.updateCVOSVMName(d, clientID, svmName.(string), svmNewName.(string))
+		respErr := client.updateCVOSVMName(ctx, d, clientID, svmName.(string), svmNewName.(string))
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 	}
 
 	// check if license_type and instance type are changed
 	if d.HasChange("instance_type") || d.HasChange("license_type") {
-		respErr := updateCVOLicenseInstanceType(d, meta, clientID)
+		respErr := updateCVOLicenseInstanceType(ctx, d, meta, clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 	}
 
 	// check if tier_level is changed
 	if d.HasChange("tier_level") && d.Get("capacity_tier").(string) == "S3" {
-		respErr := updateCVOTierLevel(d, meta, clientID)
+		respErr := updateCVOTierLevel(ctx, d, meta, clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 	}
 
 	// check if aws_tag has changes
 	if d.HasChange("aws_tag") {
-		respErr := updateCVOUserTags(d, meta, "aws_tag", clientID)
+		respErr := updateCVOUserTags(ctx, d, meta, "aws_tag", clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
-		return resourceCVOAWSRead(d, meta)
+		return resourceCVOAWSRead(ctx, d, meta)
 	}
 
 	// check if writing_speed_state is changed
@@ -642,9 +654,9 @@ func resourceCVOAWSUpdate(d *schema.ResourceData, meta interface{}) error {
 			log.Print("writing_speed_state: default value is NORMAL. No change call is needed.")
 			return nil
 		}
-		respErr := updateCVOWritingSpeedState(d, meta, clientID)
+		respErr := updateCVOWritingSpeedState(ctx, d, meta, clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 
 		return nil
@@ -652,39 +664,18 @@ func resourceCVOAWSUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	// upgrade ontap version
 	// only when the upgrade_ontap_version is true and the ontap_version is not "latest"
-	upgradeErr := client.checkAndDoUpgradeOntapVersion(d, clientID)
+	upgradeErr := client.checkAndDoUpgradeOntapVersion(ctx, d, clientID)
 	if upgradeErr != nil {
-		return upgradeErr
+		return diag.FromErr(upgradeErr)
 	}
 
 	return nil
 }
 
-func resourceCVOAWSCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+func resourceCVOAWSCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
 	respErr := checkUserTagDiff(diff, "aws_tag", "tag_key")
 	if respErr != nil {
 		return respErr
 	}
 	return nil
 }
-
-func resourceCVOAWSExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	log.Printf("Checking existence of CVO: %#v", d)
-	client := meta.(*Client)
-
-	id := d.Id()
-	clientID := d.Get("client_id").(string)
-
-	resID, err := client.getCVOAWS(id, clientID)
-	if err != nil {
-		log.Print("Error getting cvo")
-		return false, err
-	}
-
-	if resID != id {
-		d.SetId("")
-		return false, nil
-	}
-
-	return true, nil
-}
@@ -1,26 +1,33 @@
 package cloudmanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCVOAzure() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCVOAzureCreate,
-		Read:   resourceCVOAzureRead,
-		Delete: resourceCVOAzureDelete,
-		Update: resourceCVOAzureUpdate,
-		Exists: resourceCVOAzureExists,
+		CreateWithoutTimeout: resourceCVOAzureCreate,
+		ReadContext:          resourceCVOAzureRead,
+		DeleteContext:        resourceCVOAzureDelete,
+		UpdateContext:        resourceCVOAzureUpdate,
 		Importer: &schema.ResourceImporter{
-			State: resourceCVOAzureImport,
+			StateContext: resourceCVOAzureImport,
 		},
 		CustomizeDiff: resourceCVOAzureCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(90 * time.Minute),
+			Update: schema.DefaultTimeout(150 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -38,7 +45,7 @@ func resourceCVOAzure() *schema.Resource {
 				ForceNew: true,
 			},
 			"saas_subscription_id": {
-				Type: 	schema.TypeString,
+				Type:     schema.TypeString,
 				Optional: true,
 				ForceNew: true,
 			},
@@ -302,20 +309,22 @@ func resourceCVOAzure() *schema.Resource {
 				Default:  false,
 			},
 			"retries": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				ForceNew: true,
-				Default:  60,
+				Type:       schema.TypeInt,
+				Optional:   true,
+				ForceNew:   true,
+				Default:    60,
+				Deprecated: "use the create timeout in the timeouts block instead",
 			},
 		},
 	}
 }
 
-func resourceCVOAzureCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOAzureCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating CVO Azure: %#v", d)
 
 	client := meta.(*Client)
-	client.Retries = d.Get("retries").(int)
+	ctx, cancel := context.WithTimeout(ctx, cvoCreateTimeout(d))
+	defer cancel()
 
 	cvoDetails := createCVOAzureDetails{}
 
@@ -442,7 +451,7 @@ func resourceCVOAzureCreate(d *schema.ResourceData, meta interface{}) error {
 	err := validateCVOAzureParams(cvoDetails)
 	if err != nil {
 		log.Print("Error validating parameters")
-		return err
+		return diag.FromErr(err)
 	}
 
 	cvoDetails.VnetForInternal = d.Get("vnet_id").(string)
@@ -471,20 +480,20 @@ func resourceCVOAzureCreate(d *schema.ResourceData, meta interface{}) error {
 	cvoDetails.VnetID = vnet
 	cvoDetails.SubnetID = fmt.Sprintf("%s/subnets/%s", vnet, d.Get("subnet_id").(string))
 
-	res, err := client.createCVOAzure(cvoDetails, clientID)
+	res, err := client.createCVOAzure(ctx, cvoDetails, clientID)
 	if err != nil {
 		log.Print("Error creating instance")
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(res.PublicID)
 	d.Set("svm_name", res.SvmName)
 	log.Printf("Created cvo: %v", res)
 
-	return resourceCVOAzureRead(d, meta)
+	return resourceCVOAzureRead(ctx, d, meta)
 }
 
-func resourceCVOAzureRead(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOAzureRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Reading CVO: %#v", d)
 	client := meta.(*Client)
 
@@ -492,10 +501,13 @@ func resourceCVOAzureRead(d *schema.ResourceData, meta interface{}) error {
 
 	clientID := d.Get("client_id").(string)
 
-	resp, err := client.getCVOProperties(id, clientID)
+	resp, err := client.getCVOProperties(ctx, id, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "CVO")
+	}
 	if err != nil {
 		log.Print("Error reading cvo")
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("svm_name", resp.SvmName)
 	if c, ok := d.GetOk("writing_speed_state"); ok {
@@ -508,7 +520,7 @@ func resourceCVOAzureRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceCVOAzureDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOAzureDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting CVO: %#v", d)
 
 	client := meta.(*Client)
@@ -516,16 +528,16 @@ func resourceCVOAzureDelete(d *schema.ResourceData, meta interface{}) error {
 	id := d.Id()
 	isHA := d.Get("is_ha").(bool)
 
-	deleteErr := client.deleteCVOAzure(id, isHA, clientID)
+	deleteErr := client.deleteCVOAzure(ctx, id, isHA, clientID)
 	if deleteErr != nil {
 		log.Print("Error deleting cvo")
-		return deleteErr
+		return diag.FromErr(deleteErr)
 	}
 
 	return nil
 }
 
-func resourceCVOAzureUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOAzureUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Updating CVO: %#v", d)
 
 	client := meta.(*Client)
@@ -533,9 +545,9 @@ func resourceCVOAzureUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	// check if svm_password is changed
 	if d.HasChange("svm_password") {
-		respErr := updateCVOSVMPassword(d, meta, clientID)
+		respErr := updateCVOSVMPassword(ctx, d, meta, clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 	}
 
@@ -543,9 +555,9 @@ func resourceCVOAzureUpdate(d *schema.ResourceData, meta interface{}) error {
 	if d.HasChange("svm_name") {
 		svmName, svmNewName := d.GetChange("svm_name")
 		if svmNewName.(string) != "" {
-			respErr := client.updateCVOSVMName(d, clientID, svmName.(string), svmNewName.(string))
+			respErr := client.updateCVOSVMName(ctx, d, clientID, svmName.(string), svmNewName.(string))
 			if respErr != nil {
-				return respErr
+				return diag.FromErr(respErr)
 			}
 		} else {
 			d.Set("svm_name", svmName.(string))
@@ -555,27 +567,27 @@ func resourceCVOAzureUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	// check if license_type and instance type are changed
 	if d.HasChange("instance_type") || d.HasChange("license_type") {
-		respErr := updateCVOLicenseInstanceType(d, meta, clientID)
+		respErr := updateCVOLicenseInstanceType(ctx, d, meta, clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 	}
 
 	// check if tier_level is changed
 	if d.HasChange("tier_level") && d.Get("capacity_tier").(string) == "Blob" {
-		respErr := updateCVOTierLevel(d, meta, clientID)
+		respErr := updateCVOTierLevel(ctx, d, meta, clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 	}
 
 	// check if aws_tag has changes
 	if d.HasChange("azure_tag") {
-		respErr := updateCVOUserTags(d, meta, "azure_tag", clientID)
+		respErr := updateCVOUserTags(ctx, d, meta, "azure_tag", clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
-		return resourceCVOAzureRead(d, meta)
+		return resourceCVOAzureRead(ctx, d, meta)
 	}
 
 	// check if writing_speed_state is changed
@@ -586,9 +598,9 @@ func resourceCVOAzureUpdate(d *schema.ResourceData, meta interface{}) error {
 			log.Print("writing_speed_state: default value is NORMAL. No change call is needed.")
 			return nil
 		}
-		respErr := updateCVOWritingSpeedState(d, meta, clientID)
+		respErr := updateCVOWritingSpeedState(ctx, d, meta, clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 
 		return nil
@@ -596,15 +608,15 @@ func resourceCVOAzureUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	// upgrade ontap version
 	// only when the upgrade_ontap_version is true and the ontap_version is not "latest"
-	upgradeErr := client.checkAndDoUpgradeOntapVersion(d, clientID)
+	upgradeErr := client.checkAndDoUpgradeOntapVersion(ctx, d, clientID)
 	if upgradeErr != nil {
-		return upgradeErr
+		return diag.FromErr(upgradeErr)
 	}
 
 	return nil
 }
 
-func resourceCVOAzureCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+func resourceCVOAzureCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
 	respErr := checkUserTagDiff(diff, "azure_tag", "tag_key")
 	if respErr != nil {
 		return respErr
@@ -612,31 +624,7 @@ func resourceCVOAzureCustomizeDiff(diff *schema.ResourceDiff, v interface{}) err
 	return nil
 }
 
-func resourceCVOAzureExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	log.Printf("Checking existence of CVO: %#v", d)
-	client := meta.(*Client)
-
-	id := d.Id()
-	clientID := d.Get("client_id").(string)
-
-	resID, err := client.getCVOAzure(id, clientID)
-	if err != nil {
-		log.Print("Error getting cvo")
-		return false, err
-	}
-
-	log.Print(resID)
-	log.Print(id)
-
-	if resID != id {
-		d.SetId("")
-		return false, nil
-	}
-
-	return true, nil
-}
-
-func resourceCVOAzureImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceCVOAzureImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	return nil, fmt.Errorf("CVO Azure resource's import function is disabled")
 }
 
@@ -1,25 +1,32 @@
 package cloudmanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceCVOGCP() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCVOGCPCreate,
-		Read:   resourceCVOGCPRead,
-		Delete: resourceCVOGCPDelete,
-		Update: resourceCVOGCPUpdate,
-		Exists: resourceCVOGCPExists,
+		CreateWithoutTimeout: resourceCVOGCPCreate,
+		ReadContext:          resourceCVOGCPRead,
+		DeleteContext:        resourceCVOGCPDelete,
+		UpdateContext:        resourceCVOGCPUpdate,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		CustomizeDiff: resourceCVOGCPCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(90 * time.Minute),
+			Update: schema.DefaultTimeout(150 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -323,21 +330,23 @@ func resourceCVOGCP() *schema.Resource {
 				Default:  false,
 			},
 			"retries": {
-				Type:     schema.TypeInt,
-				Optional: true,
-				ForceNew: true,
-				Default:  60,
+				Type:       schema.TypeInt,
+				Optional:   true,
+				ForceNew:   true,
+				Default:    60,
+				Deprecated: "use the create timeout in the timeouts block instead",
 			},
 		},
 	}
 }
 
-func resourceCVOGCPCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOGCPCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating CVO GCP: %#v", d)
 
 	client := meta.(*Client)
+	ctx, cancel := context.WithTimeout(ctx, cvoCreateTimeout(d))
+	defer cancel()
 	clientID := d.Get("client_id").(string)
-	client.Retries = d.Get("retries").(int)
 
 	cvoDetails := createCVOGCPDetails{}
 
@@ -539,13 +548,13 @@ func resourceCVOGCPCreate(d *schema.ResourceData, meta interface{}) error {
 	err := validateCVOGCPParams(cvoDetails)
 	if err != nil {
 		log.Print("Error validating parameters")
-		return err
+		return diag.FromErr(err)
 	}
 
-	res, err := client.createCVOGCP(cvoDetails, clientID)
+	res, err := client.createCVOGCP(ctx, cvoDetails, clientID)
 	if err != nil {
 		log.Print("Error creating instance")
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("createCVOGCP %s result %#v  client_id %s", cvoDetails.Name, res, clientID)
 	d.SetId(res.PublicID)
@@ -554,17 +563,17 @@ func resourceCVOGCPCreate(d *schema.ResourceData, meta interface{}) error {
 
 	// Add SVMs on GCP CVO HA
 	for _, svm := range svmList {
-		err := client.addSVMtoCVO(res.PublicID, clientID, svm.SvmName)
+		err := client.addSVMtoCVO(ctx, res.PublicID, clientID, svm.SvmName)
 		if err != nil {
 			log.Printf("Error adding SVM %v: %v", svm.SvmName, err)
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return resourceCVOGCPRead(d, meta)
+	return resourceCVOGCPRead(ctx, d, meta)
 }
 
-func resourceCVOGCPRead(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOGCPRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Reading CVO: %#v", d)
 	client := meta.(*Client)
 
@@ -572,10 +581,13 @@ func resourceCVOGCPRead(d *schema.ResourceData, meta interface{}) error {
 
 	clientID := d.Get("client_id").(string)
 
-	resp, err := client.getCVOProperties(id, clientID)
+	resp, err := client.getCVOProperties(ctx, id, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "CVO")
+	}
 	if err != nil {
 		log.Print("Error reading cvo")
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("svm_name", resp.SvmName)
 	if c, ok := d.GetOk("writing_speed_state"); ok {
@@ -588,7 +600,7 @@ func resourceCVOGCPRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceCVOGCPDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOGCPDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting CVO: %#v", d)
 
 	client := meta.(*Client)
@@ -597,16 +609,16 @@ func resourceCVOGCPDelete(d *schema.ResourceData, meta interface{}) error {
 	clientID := d.Get("client_id").(string)
 	isHA := d.Get("is_ha").(bool)
 
-	deleteErr := client.deleteCVOGCP(id, isHA, clientID)
+	deleteErr := client.deleteCVOGCP(ctx, id, isHA, clientID)
 	if deleteErr != nil {
 		log.Print("Error deleting cvo")
-		return deleteErr
+		return diag.FromErr(deleteErr)
 	}
 
 	return nil
 }
 
-func resourceCVOGCPUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOGCPUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Updating CVO: %#v", d)
 
 	client := meta.(*Client)
@@ -614,42 +626,42 @@ func resourceCVOGCPUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	// check if svm_password is changed
 	if d.HasChange("svm_password") {
-		respErr := updateCVOSVMPassword(d, meta, clientID)
+		respErr := updateCVOSVMPassword(ctx, d, meta, clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 	}
 
 	//  check if svm_name is changed
 	if d.HasChange("svm_name") {
 		svmName, svmNewName := d.GetChange("svm_name")
-		respErr := client.updateCVOSVMName(d, clientID, svmName.(string), svmNewName.(string))
+		respErr := client.updateCVOSVMName(ctx, d, clientID, svmName.(string), svmNewName.(string))
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 	}
 
 	// check if svm list changes
 	if d.Get("is_ha").(bool) && d.HasChange("svm") {
-		respErr := client.updateCVOSVMs(d, clientID)
+		respErr := client.updateCVOSVMs(ctx, d, clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 	}
 
 	// check if license_type and instance type are changed
 	if d.HasChange("instance_type") || d.HasChange("license_type") {
-		respErr := updateCVOLicenseInstanceType(d, meta, clientID)
+		respErr := updateCVOLicenseInstanceType(ctx, d, meta, clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 	}
 
 	// check if tier_level is changed
 	if d.HasChange("tier_level") {
-		respErr := updateCVOTierLevel(d, meta, clientID)
+		respErr := updateCVOTierLevel(ctx, d, meta, clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 	}
 
@@ -661,9 +673,9 @@ func resourceCVOGCPUpdate(d *schema.ResourceData, meta interface{}) error {
 			log.Print("writing_speed_state: default value is NORMAL. No change call is needed.")
 			return nil
 		}
-		respErr := updateCVOWritingSpeedState(d, meta, clientID)
+		respErr := updateCVOWritingSpeedState(ctx, d, meta, clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
 
 		return nil
@@ -671,16 +683,16 @@ func resourceCVOGCPUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	// check if gcp_label has changes
 	if d.HasChange("gcp_label") {
-		respErr := updateCVOUserTags(d, meta, "gcp_label", clientID)
+		respErr := updateCVOUserTags(ctx, d, meta, "gcp_label", clientID)
 		if respErr != nil {
-			return respErr
+			return diag.FromErr(respErr)
 		}
-		return resourceCVOGCPRead(d, meta)
+		return resourceCVOGCPRead(ctx, d, meta)
 	}
 	// upgrade ontap version
-	upgradeErr := client.checkAndDoUpgradeOntapVersion(d, clientID)
+	upgradeErr := client.checkAndDoUpgradeOntapVersion(ctx, d, clientID)
 	if upgradeErr != nil {
-		return upgradeErr
+		return diag.FromErr(upgradeErr)
 	}
 
 	return nil
@@ -732,32 +744,10 @@ func checkLabelDiff(diff *schema.ResourceDiff) error {
 	return nil
 }
 
-func resourceCVOGCPCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+func resourceCVOGCPCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
 	respErr := checkLabelDiff(diff)
 	if respErr != nil {
 		return respErr
 	}
 	return nil
 }
-
-func resourceCVOGCPExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	log.Printf("Checking existence of CVO: %#v", d)
-	client := meta.(*Client)
-
-	id := d.Id()
-	clientID := d.Get("client_id").(string)
-	name := d.Get("name").(string)
-
-	resID, err := client.findWorkingEnvironmentByName(name, clientID)
-	if err != nil {
-		log.Print("Error getting cvo")
-		return false, err
-	}
-
-	if resID.PublicID != id {
-		d.SetId("")
-		return false, err
-	}
-
-	return true, nil
-}
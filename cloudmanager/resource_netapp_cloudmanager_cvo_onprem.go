@@ -1,18 +1,24 @@
 package cloudmanager
 
 import (
+	"context"
 	"log"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceCVOOnPrem() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCVOOnPremCreate,
-		Read:   resourceCVOOnPremRead,
-		Delete: resourceCVOOnPremDelete,
-		Exists: resourceCVOOnPremExists,
+		CreateContext: resourceCVOOnPremCreate,
+		ReadContext:   resourceCVOOnPremRead,
+		DeleteContext: resourceCVOOnPremDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(60 * time.Minute),
+			Delete: schema.DefaultTimeout(60 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -55,7 +61,7 @@ func resourceCVOOnPrem() *schema.Resource {
 	}
 }
 
-func resourceCVOOnPremCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOOnPremCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating CVO: %#v", d)
 
 	client := meta.(*Client)
@@ -70,10 +76,10 @@ func resourceCVOOnPremCreate(d *schema.ResourceData, meta interface{}) error {
 	cvoDetails.Location = d.Get("location").(string)
 	clientID := d.Get("client_id").(string)
 
-	res, err := client.createCVOOnPrem(cvoDetails, clientID)
+	res, err := client.createCVOOnPrem(ctx, cvoDetails, clientID)
 	if err != nil {
 		log.Print("Error creating instance: ", err)
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(res.PublicID)
@@ -81,25 +87,28 @@ func resourceCVOOnPremCreate(d *schema.ResourceData, meta interface{}) error {
 
 	log.Printf("Created cvo: %v", res)
 
-	return resourceCVOOnPremRead(d, meta)
+	return resourceCVOOnPremRead(ctx, d, meta)
 }
 
-func resourceCVOOnPremRead(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOOnPremRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Reading CVO: %#v", d)
 	client := meta.(*Client)
 
 	id := d.Id()
 	clientID := d.Get("client_id").(string)
 
-	_, err := client.getCVOOnPremByID(id, clientID)
+	_, err := client.getCVOOnPremByID(ctx, id, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "CVO")
+	}
 	if err != nil {
 		log.Print("Error reading cvo onprem: ", err)
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceCVOOnPremDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOOnPremDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting CVO: %#v", d)
 
 	client := meta.(*Client)
@@ -107,32 +116,11 @@ func resourceCVOOnPremDelete(d *schema.ResourceData, meta interface{}) error {
 	id := d.Id()
 	clientID := d.Get("client_id").(string)
 
-	deleteErr := client.deleteCVOOnPrem(id, clientID)
+	deleteErr := client.deleteCVOOnPrem(ctx, id, clientID)
 	if deleteErr != nil {
 		log.Print("Error deleting cvo: ", deleteErr)
-		return deleteErr
+		return diag.FromErr(deleteErr)
 	}
 
 	return nil
 }
-
-func resourceCVOOnPremExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	log.Printf("Checking existence of CVO: %#v", d)
-	client := meta.(*Client)
-
-	id := d.Id()
-	clientID := d.Get("client_id").(string)
-
-	resID, err := client.getCVOOnPrem(id, clientID)
-	if err != nil {
-		log.Print("Error getting cvo: ", err)
-		return false, err
-	}
-
-	if resID != id {
-		d.SetId("")
-		return false, nil
-	}
-
-	return true, nil
-}
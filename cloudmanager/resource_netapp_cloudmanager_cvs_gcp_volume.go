@@ -1,24 +1,30 @@
 package cloudmanager
 
 import (
-	"fmt"
+	"context"
 	"log"
 	"math"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceCVSGCPVolume() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCVSGCPVolumeCreate,
-		Read:   resourceCVSGCPVolumeRead,
-		Delete: resourceCVSGCPVolumeDelete,
+		CreateContext: resourceCVSGCPVolumeCreate,
+		ReadContext:   resourceCVSGCPVolumeRead,
+		DeleteContext: resourceCVSGCPVolumeDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -273,7 +279,7 @@ func resourceCVSGCPVolume() *schema.Resource {
 	}
 }
 
-func resourceCVSGCPVolumeCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceCVSGCPVolumeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 
 	volume := gcpVolumeRequest{}
@@ -316,16 +322,16 @@ func resourceCVSGCPVolumeCreate(d *schema.ResourceData, meta interface{}) error
 	info := cvsInfo{}
 	info.AccountName = d.Get("account").(string)
 
-	res, err := client.createGCPVolume(volume, info, clientID)
+	res, err := client.createGCPVolume(ctx, volume, info, clientID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(res.VolumeID)
 
-	return resourceCVSGCPVolumeRead(d, meta)
+	return resourceCVSGCPVolumeRead(ctx, d, meta)
 }
 
-func resourceCVSGCPVolumeRead(d *schema.ResourceData, meta interface{}) error {
+func resourceCVSGCPVolumeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
 	volume := gcpVolumeRequest{}
@@ -336,20 +342,23 @@ func resourceCVSGCPVolumeRead(d *schema.ResourceData, meta interface{}) error {
 	volume.VolumeID = d.Id()
 	info.AccountName = d.Get("account").(string)
 
-	res, err := client.getGCPVolume(volume, info, clientID)
+	res, err := client.getGCPVolume(ctx, volume, info, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "CVS volume")
+	}
 	if err != nil {
 		log.Print("Error reading volume")
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := d.Set("service_level", res.ServiceLevel); err != nil {
-		return fmt.Errorf("Error reading volume service_level: %s", err)
+		return diag.Errorf("Error reading volume service_level: %s", err)
 	}
 	if err := d.Set("protocol_types", res.ProtocolTypes); err != nil {
-		return fmt.Errorf("Error reading volume protocol_types: %s", err)
+		return diag.Errorf("Error reading volume protocol_types: %s", err)
 	}
 	if err := d.Set("volume_path", res.CreationToken); err != nil {
-		return fmt.Errorf("Error reading volume path or Creation Token: %s", err)
+		return diag.Errorf("Error reading volume path or Creation Token: %s", err)
 	}
 	network := res.Network
 	index := strings.Index(network, "networks/")
@@ -357,30 +366,30 @@ func resourceCVSGCPVolumeRead(d *schema.ResourceData, meta interface{}) error {
 		network = network[index+len("networks/"):]
 	}
 	if err := d.Set("network", network); err != nil {
-		return fmt.Errorf("Error reading volume network: %s", err)
+		return diag.Errorf("Error reading volume network: %s", err)
 	}
 	if err := d.Set("region", res.Region); err != nil {
-		return fmt.Errorf("Error reading volume region: %s", err)
+		return diag.Errorf("Error reading volume region: %s", err)
 	}
 	snapshotPolicy := flattenSnapshotPolicy(res.SnapshotPolicy)
 	exportPolicy := flattenExportPolicy(res.ExportPolicy)
 	if err := d.Set("snapshot_policy", snapshotPolicy); err != nil {
-		return fmt.Errorf("Error reading volume snapshot_policy: %s", err)
+		return diag.Errorf("Error reading volume snapshot_policy: %s", err)
 	}
 	if len(res.ExportPolicy.Rules) > 0 {
 		if err := d.Set("export_policy", exportPolicy); err != nil {
-			return fmt.Errorf("Error reading volume export_policy: %s", err)
+			return diag.Errorf("Error reading volume export_policy: %s", err)
 		}
 	} else {
 		a := schema.NewSet(schema.HashString, []interface{}{})
 		if err := d.Set("export_policy", a); err != nil {
-			return fmt.Errorf("Error reading volume export_policy: %s", err)
+			return diag.Errorf("Error reading volume export_policy: %s", err)
 		}
 	}
 	return nil
 }
 
-func resourceCVSGCPVolumeDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceCVSGCPVolumeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
 	volume := gcpVolumeRequest{}
@@ -390,10 +399,10 @@ func resourceCVSGCPVolumeDelete(d *schema.ResourceData, meta interface{}) error
 	info.AccountName = d.Get("account").(string)
 	volume.VolumeID = d.Id()
 
-	err := client.deleteGCPVolume(volume, info, clientID)
+	err := client.deleteGCPVolume(ctx, volume, info, clientID)
 	if err != nil {
 		log.Print("Error deleting volume")
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
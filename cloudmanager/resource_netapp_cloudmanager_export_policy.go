@@ -0,0 +1,263 @@
+package cloudmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+var exportRuleSecurityFlavors = []string{"any", "none", "never", "krb5", "krb5i", "krb5p", "ntlm", "sys"}
+
+func resourceExportPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceExportPolicyCreate,
+		ReadContext:   resourceExportPolicyRead,
+		UpdateContext: resourceExportPolicyUpdate,
+		DeleteContext: resourceExportPolicyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceExportPolicyImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"file_system_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"rule": {
+				Type:     schema.TypeList,
+				Required: true,
+				MinItems: 1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"client_match": {
+							Type:     schema.TypeList,
+							Required: true,
+							MinItems: 1,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"ro_rule": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(exportRuleSecurityFlavors, false),
+							},
+						},
+						"rw_rule": {
+							Type:     schema.TypeList,
+							Required: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(exportRuleSecurityFlavors, false),
+							},
+						},
+						"superuser": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice(exportRuleSecurityFlavors, false),
+							},
+						},
+						"protocols": {
+							Type:     schema.TypeList,
+							Optional: true,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type:         schema.TypeString,
+								ValidateFunc: validation.StringInSlice([]string{"any", "nfs", "nfs3", "nfs4", "cifs", "flexcache"}, false),
+							},
+						},
+						"anonymous_user": {
+							Type:     schema.TypeString,
+							Optional: true,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getExportPolicyRequest returns the request for the export policy of the resource, with the ID of its working
+// environment or file system
+func getExportPolicyRequest(ctx context.Context, client *Client, d *schema.ResourceData, clientID string) (string, exportPolicyRequest, error) {
+	policy := exportPolicyRequest{}
+	workingEnv, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return "", exportPolicyRequest{}, err
+	}
+	id := workingEnv.PublicID
+	if v, ok := d.GetOk("file_system_id"); ok {
+		id = v.(string)
+	}
+	policy.SvmName = workingEnv.SvmName
+	if v, ok := d.GetOk("svm_name"); ok {
+		policy.SvmName = v.(string)
+	}
+	policy.Name = d.Get("name").(string)
+	policy.Rules = expandExportPolicyRules(d.Get("rule").([]interface{}))
+	return id, policy, nil
+}
+
+func resourceExportPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Creating export policy: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	id, policy, err := getExportPolicyRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.createExportPolicy(ctx, id, policy, clientID)
+	if err != nil {
+		log.Print("Error creating export policy")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(policy.Name)
+	if _, ok := d.GetOk("file_system_id"); !ok {
+		d.Set("working_environment_id", id)
+	}
+	d.Set("svm_name", policy.SvmName)
+
+	log.Printf("Created export policy: %v", policy.Name)
+
+	return resourceExportPolicyRead(ctx, d, meta)
+}
+
+func resourceExportPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Reading export policy: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	id, policy, err := getExportPolicyRequest(ctx, client, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "export policy")
+	}
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	res, err := client.getExportPolicy(ctx, id, policy.SvmName, d.Id(), clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "export policy")
+	}
+	if err != nil {
+		log.Printf("Error getting export policy. id = %v", d.Id())
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("rule", flattenExportPolicyRules(res.Rules)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceExportPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating export policy: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	id, policy, err := getExportPolicyRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.updateExportPolicy(ctx, id, policy, clientID)
+	if err != nil {
+		log.Print("Error updating export policy")
+		return diag.FromErr(err)
+	}
+
+	log.Printf("Updated export policy: %v", policy.Name)
+
+	return resourceExportPolicyRead(ctx, d, meta)
+}
+
+func resourceExportPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Deleting export policy: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	id, policy, err := getExportPolicyRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.deleteExportPolicy(ctx, id, policy.SvmName, d.Id(), clientID)
+	if err != nil {
+		log.Print("Error deleting export policy")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceExportPolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 4 {
+		return []*schema.ResourceData{}, fmt.Errorf("Wrong format of resource: %s. Please follow 'client_id:working_environment_id:svm_name:export_policy_name'", d.Id())
+	}
+
+	d.SetId(parts[3])
+	d.Set("client_id", parts[0])
+	d.Set("working_environment_id", parts[1])
+	d.Set("svm_name", parts[2])
+	d.Set("name", parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
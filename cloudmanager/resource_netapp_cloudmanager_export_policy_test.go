@@ -0,0 +1,120 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestExportPolicyCRUD_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	r := resourceExportPolicy()
+
+	config := map[string]interface{}{
+		"name":                     "app_hosts",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+		"rule": []interface{}{
+			map[string]interface{}{
+				"client_match": []interface{}{"10.0.1.0/24"},
+				"ro_rule":      []interface{}{"sys"},
+				"rw_rule":      []interface{}{"sys"},
+				"superuser":    []interface{}{"sys"},
+				"protocols":    []interface{}{"nfs3", "nfs4"},
+			},
+			map[string]interface{}{
+				"client_match": []interface{}{"10.0.0.0/16"},
+				"ro_rule":      []interface{}{"sys"},
+				"rw_rule":      []interface{}{"never"},
+			},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "app_hosts" || d.Get("svm_name").(string) != "svm_mockvsa" {
+		t.Fatalf("create: unexpected state %v", d.State())
+	}
+	if got := d.Get("rule.0.client_match.0").(string); got != "10.0.1.0/24" {
+		t.Fatalf("create: expected the rules to keep their order, got %q first", got)
+	}
+	if got := d.Get("rule.1.anonymous_user").(string); got != "65534" {
+		t.Fatalf("create: expected the default anonymous user, got %q", got)
+	}
+	if got := d.Get("rule.0.protocols.#").(int); got != 2 {
+		t.Fatalf("create: expected 2 protocols, got %d", got)
+	}
+
+	// a new first rule moves the others down
+	config["rule"] = append([]interface{}{
+		map[string]interface{}{
+			"client_match":   []interface{}{"10.0.2.10"},
+			"ro_rule":        []interface{}{"any"},
+			"rw_rule":        []interface{}{"any"},
+			"superuser":      []interface{}{"any"},
+			"anonymous_user": "0",
+		},
+	}, config["rule"].([]interface{})...)
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if got := d.Get("rule.#").(int); got != 3 {
+		t.Fatalf("update: expected 3 rules, got %d", got)
+	}
+	if got := d.Get("rule.0.client_match.0").(string); got != "10.0.2.10" {
+		t.Fatalf("update: expected the new rule first, got %q", got)
+	}
+	if got := d.Get("rule.2.rw_rule.0").(string); got != "never" {
+		t.Fatalf("update: expected the last rule to be read only, got %q", got)
+	}
+
+	// a volume references the policy by name alone
+	volume := resourceCVOVolume()
+	volumeConfig := map[string]interface{}{
+		"name":                     "vol1",
+		"working_environment_name": "mockvsa",
+		"size":                     10,
+		"unit":                     "GB",
+		"provider_volume_type":     "gp2",
+		"export_policy_name":       "app_hosts",
+		"client_id":                "mock",
+	}
+	if _, err := volume.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(volumeConfig), client); err != nil {
+		t.Fatalf("volume: %v", err)
+	}
+	vd := schema.TestResourceDataRaw(t, volume.Schema, volumeConfig)
+	if diags := volume.CreateContext(context.Background(), vd, client); diags.HasError() {
+		t.Fatalf("volume: %v", diags)
+	}
+	policy := mock.findVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "vol1")["exportPolicyInfo"].(map[string]interface{})
+	if policy["name"] != "app_hosts" || policy["ips"] != nil {
+		t.Fatalf("volume: expected the volume to use the export policy, got %v", policy)
+	}
+
+	// import
+	imported := r.Data(nil)
+	imported.SetId("mock:VsaWorkingEnvironment-mock:svm_mockvsa:app_hosts")
+	states, err := r.Importer.StateContext(context.Background(), imported, client)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), states[0], client); diags.HasError() {
+		t.Fatalf("import read: %v", diags)
+	}
+	if got := states[0].Get("rule.#").(int); got != 3 {
+		t.Fatalf("import: expected 3 rules, got %d", got)
+	}
+
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted export policy to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
@@ -0,0 +1,231 @@
+package cloudmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceIgroup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIgroupCreate,
+		ReadContext:   resourceIgroupRead,
+		UpdateContext: resourceIgroupUpdate,
+		DeleteContext: resourceIgroupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIgroupImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"os_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"linux", "windows", "vmware", "hyper_v", "xen", "aix", "hpux", "solaris", "netware"}, false),
+			},
+			"initiators": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"portset_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"igroup_type": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// getIgroupRequest returns the request addressing the igroup of the resource
+func getIgroupRequest(ctx context.Context, client *Client, d *schema.ResourceData, clientID string) (igroup, error) {
+	request := igroup{}
+	workingEnv, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return igroup{}, err
+	}
+	request.WorkingEnvironmentID = workingEnv.PublicID
+	request.WorkingEnvironmentType = workingEnv.WorkingEnvironmentType
+	request.SvmName = workingEnv.SvmName
+	if v, ok := d.GetOk("svm_name"); ok {
+		request.SvmName = v.(string)
+	}
+	request.IgroupName = d.Get("name").(string)
+	return request, nil
+}
+
+func expandIgroupInitiators(set *schema.Set) []string {
+	initiators := make([]string, 0, set.Len())
+	for _, v := range set.List() {
+		initiators = append(initiators, v.(string))
+	}
+	return initiators
+}
+
+func resourceIgroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Creating igroup: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	ig, err := getIgroupRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	request := igroupRequest{}
+	request.IgroupName = ig.IgroupName
+	request.OsType = d.Get("os_type").(string)
+	request.PortsetName = d.Get("portset_name").(string)
+	request.Initiators = expandIgroupInitiators(d.Get("initiators").(*schema.Set))
+
+	err = client.createIgroup(ctx, ig, request, clientID)
+	if err != nil {
+		log.Print("Error creating igroup")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(ig.IgroupName)
+	d.Set("working_environment_id", ig.WorkingEnvironmentID)
+	d.Set("svm_name", ig.SvmName)
+
+	log.Printf("Created igroup: %v", ig.IgroupName)
+
+	return resourceIgroupRead(ctx, d, meta)
+}
+
+func resourceIgroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Reading igroup: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	ig, err := getIgroupRequest(ctx, client, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "igroup")
+	}
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	res, err := client.getIgroup(ctx, ig, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "igroup")
+	}
+	if err != nil {
+		log.Printf("Error getting igroup. id = %v", d.Id())
+		return diag.FromErr(err)
+	}
+
+	d.Set("os_type", res.OsType)
+	d.Set("initiators", res.Initiators)
+	d.Set("portset_name", res.PortsetName)
+	d.Set("igroup_type", res.IgroupType)
+
+	return nil
+}
+
+func resourceIgroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating igroup: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	ig, err := getIgroupRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	request := igroupRequest{}
+	request.IgroupName = ig.IgroupName
+	request.OsType = d.Get("os_type").(string)
+	request.Initiators = expandIgroupInitiators(d.Get("initiators").(*schema.Set))
+
+	err = client.updateIgroup(ctx, ig, request, clientID)
+	if err != nil {
+		log.Print("Error updating igroup")
+		return diag.FromErr(err)
+	}
+
+	log.Printf("Updated igroup: %v", ig.IgroupName)
+
+	return resourceIgroupRead(ctx, d, meta)
+}
+
+func resourceIgroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Deleting igroup: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	ig, err := getIgroupRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.deleteIgroup(ctx, ig, clientID)
+	if err != nil {
+		log.Print("Error deleting igroup")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceIgroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 4 {
+		return []*schema.ResourceData{}, fmt.Errorf("Wrong format of resource: %s. Please follow 'client_id:working_environment_id:svm_name:igroup_name'", d.Id())
+	}
+
+	d.SetId(parts[3])
+	d.Set("client_id", parts[0])
+	d.Set("working_environment_id", parts[1])
+	d.Set("svm_name", parts[2])
+	d.Set("name", parts[3])
+
+	return []*schema.ResourceData{d}, nil
+}
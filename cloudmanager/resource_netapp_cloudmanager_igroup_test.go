@@ -0,0 +1,127 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestIgroupCRUD_mock(t *testing.T) {
+	_, client := newMockVsa(t)
+	r := resourceIgroup()
+
+	config := map[string]interface{}{
+		"name":                     "hosts",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+		"os_type":                  "linux",
+		"initiators":               []interface{}{"iqn.1994-05.com.redhat:host1"},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "hosts" || d.Get("svm_name").(string) != "svm_mockvsa" || d.Get("working_environment_id").(string) != "VsaWorkingEnvironment-mock" {
+		t.Fatalf("create: unexpected state %v", d.State())
+	}
+	if got := d.Get("igroup_type").(string); got != "iscsi" {
+		t.Fatalf("create: expected igroup_type iscsi, got %q", got)
+	}
+
+	config["os_type"] = "vmware"
+	config["initiators"] = []interface{}{"iqn.1994-05.com.redhat:host1", "iqn.1994-05.com.redhat:host2"}
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if got := d.Get("initiators").(*schema.Set).Len(); got != 2 {
+		t.Fatalf("update: expected 2 initiators, got %d", got)
+	}
+	if got := d.Get("os_type").(string); got != "vmware" {
+		t.Fatalf("update: expected os_type vmware, got %q", got)
+	}
+
+	// a volume referencing the igroup maps to it instead of creating it
+	volume := resourceCVOVolume()
+	vd := schema.TestResourceDataRaw(t, volume.Schema, map[string]interface{}{
+		"name":                   "lunvol",
+		"working_environment_id": "VsaWorkingEnvironment-mock",
+		"client_id":              "mock",
+		"volume_protocol":        "iscsi",
+		"igroups":                []interface{}{"hosts"},
+		"os_name":                "linux",
+	})
+	isNewIgroup, _, err := createIscsiVolumeHelper(context.Background(), vd, client)
+	if err != nil {
+		t.Fatalf("volume: %v", err)
+	}
+	if isNewIgroup {
+		t.Fatal("volume: expected the igroup to exist")
+	}
+
+	// import
+	imported := r.Data(nil)
+	imported.SetId("mock:VsaWorkingEnvironment-mock:svm_mockvsa:hosts")
+	states, err := r.Importer.StateContext(context.Background(), imported, client)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), states[0], client); diags.HasError() {
+		t.Fatalf("import read: %v", diags)
+	}
+	if states[0].Id() != "hosts" || states[0].Get("os_type").(string) != "vmware" {
+		t.Fatalf("import: unexpected state %v", states[0].State())
+	}
+
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted igroup to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
+
+func TestIgroupOnPrem_mock(t *testing.T) {
+	mock := newMockOCCM(t)
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "OnPremWorkingEnvironment-mock", Name: "mockonprem", WorkingEnvironmentType: "ON_PREM"})
+	client := mock.client()
+	r := resourceIgroup()
+
+	config := map[string]interface{}{
+		"name":                     "hosts",
+		"working_environment_name": "mockonprem",
+		"client_id":                "mock",
+		"os_type":                  "linux",
+		"initiators":               []interface{}{"iqn.1994-05.com.redhat:host1"},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	// reads and writes both go to the igroups of the on-premises cluster
+	if count := mock.requestCount("POST", "/occm/api/ontaps/working-environments/OnPremWorkingEnvironment-mock/volumes/svm_mockonprem/igroups"); count != 1 {
+		t.Fatalf("create: expected the igroup to be created on the on-premises cluster, got %d requests", count)
+	}
+	if d.Id() != "hosts" || d.Get("igroup_type").(string) != "iscsi" {
+		t.Fatalf("create: unexpected state %v", d.State())
+	}
+
+	config["initiators"] = []interface{}{"iqn.1994-05.com.redhat:host1", "iqn.1994-05.com.redhat:host2"}
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if got := d.Get("initiators").(*schema.Set).Len(); got != 2 {
+		t.Fatalf("update: expected 2 initiators, got %d", got)
+	}
+
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted igroup to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
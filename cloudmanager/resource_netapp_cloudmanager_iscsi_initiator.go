@@ -0,0 +1,180 @@
+package cloudmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceIscsiInitiator() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceIscsiInitiatorCreate,
+		ReadContext:   resourceIscsiInitiatorRead,
+		UpdateContext: resourceIscsiInitiatorUpdate,
+		DeleteContext: resourceIscsiInitiatorDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceIscsiInitiatorImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"iqn": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"alias": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+		},
+	}
+}
+
+// getInitiatorRequest returns the request addressing the initiator of the resource
+func getInitiatorRequest(ctx context.Context, client *Client, d *schema.ResourceData, clientID string) (initiator, error) {
+	request := initiator{}
+	workingEnv, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return initiator{}, err
+	}
+	request.WorkingEnvironmentID = workingEnv.PublicID
+	request.WorkingEnvironmentType = workingEnv.WorkingEnvironmentType
+	request.Iqn = d.Get("iqn").(string)
+	request.AliasName = d.Get("alias").(string)
+	return request, nil
+}
+
+func resourceIscsiInitiatorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Creating iSCSI initiator: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	request, err := getInitiatorRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.createInitiator(ctx, request, clientID)
+	if err != nil {
+		log.Print("Error creating iSCSI initiator")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(request.Iqn)
+	d.Set("working_environment_id", request.WorkingEnvironmentID)
+
+	log.Printf("Created iSCSI initiator: %v", request.Iqn)
+
+	return resourceIscsiInitiatorRead(ctx, d, meta)
+}
+
+func resourceIscsiInitiatorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Reading iSCSI initiator: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	request, err := getInitiatorRequest(ctx, client, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "iSCSI initiator")
+	}
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	res, err := client.getInitiatorByIqn(ctx, request, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "iSCSI initiator")
+	}
+	if err != nil {
+		log.Printf("Error getting iSCSI initiator. id = %v", d.Id())
+		return diag.FromErr(err)
+	}
+
+	d.Set("alias", res.AliasName)
+
+	return nil
+}
+
+func resourceIscsiInitiatorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating iSCSI initiator: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	request, err := getInitiatorRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.updateInitiator(ctx, request, clientID)
+	if err != nil {
+		log.Print("Error updating iSCSI initiator")
+		return diag.FromErr(err)
+	}
+
+	log.Printf("Updated iSCSI initiator: %v", request.Iqn)
+
+	return resourceIscsiInitiatorRead(ctx, d, meta)
+}
+
+func resourceIscsiInitiatorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Deleting iSCSI initiator: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	request, err := getInitiatorRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.deleteInitiator(ctx, request, clientID)
+	if err != nil {
+		log.Print("Error deleting iSCSI initiator")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceIscsiInitiatorImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	// the IQN itself contains colons, so it is the remainder after the first two parts
+	parts := strings.SplitN(d.Id(), ":", 3)
+	if len(parts) != 3 {
+		return []*schema.ResourceData{}, fmt.Errorf("Wrong format of resource: %s. Please follow 'client_id:working_environment_id:iqn'", d.Id())
+	}
+
+	d.SetId(parts[2])
+	d.Set("client_id", parts[0])
+	d.Set("working_environment_id", parts[1])
+	d.Set("iqn", parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
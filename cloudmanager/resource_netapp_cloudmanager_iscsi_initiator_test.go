@@ -0,0 +1,59 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestIscsiInitiatorCRUD_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	r := resourceIscsiInitiator()
+
+	config := map[string]interface{}{
+		"iqn":                    "iqn.1994-05.com.redhat:host1",
+		"alias":                  "host1",
+		"working_environment_id": "VsaWorkingEnvironment-mock",
+		"client_id":              "mock",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "iqn.1994-05.com.redhat:host1" {
+		t.Fatalf("create: expected ID iqn.1994-05.com.redhat:host1, got %q", d.Id())
+	}
+
+	config["alias"] = "web1"
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if got := mock.initiators[0]["aliasName"]; got != "web1" {
+		t.Fatalf("update: expected alias web1, got %v", got)
+	}
+
+	// the IQN keeps its colons when imported
+	imported := r.Data(nil)
+	imported.SetId("mock:VsaWorkingEnvironment-mock:iqn.1994-05.com.redhat:host1")
+	states, err := r.Importer.StateContext(context.Background(), imported, client)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), states[0], client); diags.HasError() {
+		t.Fatalf("import read: %v", diags)
+	}
+	if states[0].Get("iqn").(string) != "iqn.1994-05.com.redhat:host1" || states[0].Get("alias").(string) != "web1" {
+		t.Fatalf("import: unexpected state %v", states[0].State())
+	}
+
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted initiator to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
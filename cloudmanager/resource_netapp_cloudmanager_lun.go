@@ -0,0 +1,330 @@
+package cloudmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceLun() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceLunCreate,
+		ReadContext:   resourceLunRead,
+		UpdateContext: resourceLunUpdate,
+		DeleteContext: resourceLunDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceLunImport,
+		},
+		CustomizeDiff: resourceLunCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"file_system_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"size": {
+				Type:         schema.TypeFloat,
+				Required:     true,
+				ValidateFunc: validation.FloatAtLeast(0),
+			},
+			"unit": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "GB",
+				ValidateFunc: validation.StringInSlice([]string{"MB", "GB", "TB"}, false),
+			},
+			"os_type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"linux", "windows", "windows_2008", "windows_gpt", "vmware", "hyper_v", "xen", "aix", "hpux", "solaris", "solaris_efi", "netware", "openvms"}, false),
+			},
+			"space_reserve": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  true,
+			},
+			"igroup_mapping": {
+				Type:     schema.TypeSet,
+				Optional: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"igroup_name": {
+							Type:     schema.TypeString,
+							Required: true,
+						},
+						"lun_id": {
+							Type:         schema.TypeInt,
+							Required:     true,
+							ValidateFunc: validation.IntBetween(0, 4095),
+						},
+					},
+				},
+			},
+			"path": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"serial_number": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandLunMappings(set *schema.Set) []lunMapping {
+	mappings := make([]lunMapping, 0, set.Len())
+	for _, v := range set.List() {
+		mapping := v.(map[string]interface{})
+		mappings = append(mappings, lunMapping{
+			IgroupName: mapping["igroup_name"].(string),
+			LunID:      mapping["lun_id"].(int),
+		})
+	}
+	return mappings
+}
+
+func flattenLunMappings(mappings []lunMapping) []interface{} {
+	result := make([]interface{}, 0, len(mappings))
+	for _, mapping := range mappings {
+		result = append(result, map[string]interface{}{
+			"igroup_name": mapping.IgroupName,
+			"lun_id":      mapping.LunID,
+		})
+	}
+	return result
+}
+
+func expandLun(d *schema.ResourceData) lunRequest {
+	lun := lunRequest{}
+	lun.Name = d.Get("name").(string)
+	lun.Size = size{Size: d.Get("size").(float64), Unit: d.Get("unit").(string)}
+	lun.OsType = d.Get("os_type").(string)
+	lun.SpaceReserve = d.Get("space_reserve").(bool)
+	return lun
+}
+
+func resourceLunCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Creating LUN: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	lun := expandLun(d)
+	err = client.createLun(ctx, volume, lun, clientID)
+	if err != nil {
+		log.Print("Error creating LUN")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(lun.Name)
+	d.Set("working_environment_id", volume.WorkingEnvironmentID)
+	d.Set("svm_name", volume.SvmName)
+
+	for _, mapping := range expandLunMappings(d.Get("igroup_mapping").(*schema.Set)) {
+		err = client.mapLun(ctx, volume, lun.Name, mapping, clientID)
+		if err != nil {
+			log.Printf("Error mapping LUN to igroup %s", mapping.IgroupName)
+			return diag.FromErr(err)
+		}
+	}
+
+	log.Printf("Created LUN: %v", lun.Name)
+
+	return resourceLunRead(ctx, d, meta)
+}
+
+func resourceLunRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Reading LUN: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "LUN")
+	}
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	res, err := client.getLun(ctx, volume, d.Id(), clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "LUN")
+	}
+	if err != nil {
+		log.Printf("Error getting LUN. id = %v", d.Id())
+		return diag.FromErr(err)
+	}
+
+	d.Set("size", convertSizeUnit(res.Size.Size, res.Size.Unit, d.Get("unit").(string)))
+	d.Set("os_type", res.OsType)
+	d.Set("space_reserve", res.SpaceReserve)
+	d.Set("igroup_mapping", flattenLunMappings(res.Mappings))
+	d.Set("path", res.Path)
+	d.Set("serial_number", res.SerialNumber)
+
+	return nil
+}
+
+func resourceLunUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating LUN: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	if d.HasChanges("size", "unit", "space_reserve") {
+		lun := expandLun(d)
+		err = client.updateLun(ctx, volume, lun, clientID)
+		if err != nil {
+			log.Print("Error updating LUN")
+			return diag.FromErr(err)
+		}
+	}
+
+	if d.HasChange("igroup_mapping") {
+		// unmap first, so a mapping can move to another LUN ID of the same igroup
+		o, n := d.GetChange("igroup_mapping")
+		oldMappings := o.(*schema.Set)
+		newMappings := n.(*schema.Set)
+		for _, mapping := range expandLunMappings(oldMappings.Difference(newMappings)) {
+			err = client.unmapLun(ctx, volume, d.Id(), mapping.IgroupName, clientID)
+			if err != nil {
+				log.Printf("Error unmapping LUN from igroup %s", mapping.IgroupName)
+				return diag.FromErr(err)
+			}
+		}
+		for _, mapping := range expandLunMappings(newMappings.Difference(oldMappings)) {
+			err = client.mapLun(ctx, volume, d.Id(), mapping, clientID)
+			if err != nil {
+				log.Printf("Error mapping LUN to igroup %s", mapping.IgroupName)
+				return diag.FromErr(err)
+			}
+		}
+	}
+
+	log.Printf("Updated LUN: %v", d.Id())
+
+	return resourceLunRead(ctx, d, meta)
+}
+
+func resourceLunDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Deleting LUN: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	for _, mapping := range expandLunMappings(d.Get("igroup_mapping").(*schema.Set)) {
+		err = client.unmapLun(ctx, volume, d.Id(), mapping.IgroupName, clientID)
+		if err != nil && !isNotFound(err) {
+			log.Printf("Error unmapping LUN from igroup %s", mapping.IgroupName)
+			return diag.FromErr(err)
+		}
+	}
+
+	err = client.deleteLun(ctx, volume, d.Id(), clientID)
+	if err != nil {
+		log.Print("Error deleting LUN")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceLunImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 5 {
+		return []*schema.ResourceData{}, fmt.Errorf("Wrong format of resource: %s. Please follow 'client_id:working_environment_id:svm_name:volume_name:lun_name'", d.Id())
+	}
+
+	d.SetId(parts[4])
+	d.Set("client_id", parts[0])
+	d.Set("working_environment_id", parts[1])
+	d.Set("svm_name", parts[2])
+	d.Set("volume_name", parts[3])
+	d.Set("name", parts[4])
+	d.Set("unit", "GB")
+	d.Set("space_reserve", true)
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// resourceLunCustomizeDiff rejects an igroup mapped more than once, as a LUN has a single LUN ID per igroup
+func resourceLunCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	igroups := map[string]bool{}
+	for _, mapping := range expandLunMappings(diff.Get("igroup_mapping").(*schema.Set)) {
+		if mapping.IgroupName == "" {
+			// not known until apply
+			continue
+		}
+		if igroups[mapping.IgroupName] {
+			return fmt.Errorf("igroup_mapping: igroup %s is mapped more than once", mapping.IgroupName)
+		}
+		igroups[mapping.IgroupName] = true
+	}
+	return nil
+}
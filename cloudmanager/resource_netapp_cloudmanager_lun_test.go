@@ -0,0 +1,108 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestLunCRUD_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	mock.addVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "oradata")
+	r := resourceLun()
+
+	config := map[string]interface{}{
+		"name":                     "lun_data01",
+		"volume_name":              "oradata",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+		"size":                     100,
+		"os_type":                  "linux",
+		"igroup_mapping": []interface{}{
+			map[string]interface{}{"igroup_name": "db_hosts", "lun_id": 0},
+			map[string]interface{}{"igroup_name": "backup_hosts", "lun_id": 1},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "lun_data01" || d.Get("svm_name").(string) != "svm_mockvsa" {
+		t.Fatalf("create: unexpected state %v", d.State())
+	}
+	if d.Get("path").(string) != "/vol/oradata/lun_data01" || d.Get("serial_number").(string) == "" {
+		t.Fatalf("create: expected the LUN details to be read, got %v", d.State())
+	}
+	if got := d.Get("igroup_mapping").(*schema.Set).Len(); got != 2 {
+		t.Fatalf("create: expected 2 mappings, got %d", got)
+	}
+
+	// grow the LUN, move db_hosts to another LUN ID and replace backup_hosts
+	config["size"] = 0.25
+	config["unit"] = "TB"
+	config["space_reserve"] = false
+	config["igroup_mapping"] = []interface{}{
+		map[string]interface{}{"igroup_name": "db_hosts", "lun_id": 5},
+		map[string]interface{}{"igroup_name": "dr_hosts", "lun_id": 1},
+	}
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if d.Get("size").(float64) != 0.25 || d.Get("space_reserve").(bool) {
+		t.Fatalf("update: unexpected state %v", d.State())
+	}
+	mappings := expandLunMappings(d.Get("igroup_mapping").(*schema.Set))
+	if len(mappings) != 2 {
+		t.Fatalf("update: expected 2 mappings, got %v", mappings)
+	}
+	for _, mapping := range mappings {
+		if mapping.IgroupName == "backup_hosts" || (mapping.IgroupName == "db_hosts" && mapping.LunID != 5) {
+			t.Fatalf("update: unexpected mappings %v", mappings)
+		}
+	}
+
+	// import
+	imported := r.Data(nil)
+	imported.SetId("mock:VsaWorkingEnvironment-mock:svm_mockvsa:oradata:lun_data01")
+	states, err := r.Importer.StateContext(context.Background(), imported, client)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), states[0], client); diags.HasError() {
+		t.Fatalf("import read: %v", diags)
+	}
+	if states[0].Get("size").(float64) != 256 || states[0].Get("os_type").(string) != "linux" {
+		t.Fatalf("import: unexpected state %v", states[0].State())
+	}
+
+	// the mapped LUN is unmapped before it is deleted
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted LUN to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
+
+func TestLunCustomizeDiff(t *testing.T) {
+	r := resourceLun()
+	raw := map[string]interface{}{
+		"name":        "lun_data01",
+		"volume_name": "oradata",
+		"client_id":   "mock",
+		"size":        100,
+		"os_type":     "linux",
+		"igroup_mapping": []interface{}{
+			map[string]interface{}{"igroup_name": "db_hosts", "lun_id": 0},
+			map[string]interface{}{"igroup_name": "db_hosts", "lun_id": 1},
+		},
+	}
+	if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil); err == nil {
+		t.Error("expected an error for an igroup mapped twice")
+	}
+}
@@ -1,22 +1,27 @@
 package cloudmanager
 
 import (
-	"fmt"
+	"context"
 	"log"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceCVONssAccount() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCVONssAccountCreate,
-		Read:   resourceCVONssAccountRead,
-		Delete: resourceCVONssAccountDelete,
-		Exists: resourceCVONssAccountExists,
+		CreateContext: resourceCVONssAccountCreate,
+		ReadContext:   resourceCVONssAccountRead,
+		DeleteContext: resourceCVONssAccountDelete,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"username": {
 				Type:     schema.TypeString,
@@ -38,7 +43,7 @@ func resourceCVONssAccount() *schema.Resource {
 	}
 }
 
-func resourceCVONssAccountCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceCVONssAccountCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating nss account: %s", d.Get("username").(string))
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
@@ -47,33 +52,33 @@ func resourceCVONssAccountCreate(d *schema.ResourceData, meta interface{}) error
 	if v, ok := d.GetOk("username"); ok {
 		nssAcc.AccountCredentials.Username = v.(string)
 	} else {
-		return fmt.Errorf("username is required to create nss account")
+		return attributeErrorf("username", "username is required to create nss account")
 	}
 	if v, ok := d.GetOk("password"); ok {
 		nssAcc.AccountCredentials.Password = v.(string)
 	} else {
-		return fmt.Errorf("password is required to create nss account")
+		return attributeErrorf("password", "password is required to create nss account")
 	}
-	res, err := client.createNssAccount(nssAcc, clientID)
+	res, err := client.createNssAccount(ctx, nssAcc, clientID)
 	if err != nil {
 		log.Printf("Error creating nss account: %s", d.Get("username").(string))
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(res["publicId"].(string))
-	return resourceCVONssAccountRead(d, meta)
+	return resourceCVONssAccountRead(ctx, d, meta)
 }
 
-func resourceCVONssAccountRead(d *schema.ResourceData, meta interface{}) error {
+func resourceCVONssAccountRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Getting nss account: %s", d.Get("username").(string))
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
-	res, err := client.getNssAccount(d.Get("username").(string), clientID)
+	res, err := client.getNssAccount(ctx, d.Get("username").(string), clientID)
 	if err != nil {
 		log.Printf("Error getting nss account: %s", d.Get("username").(string))
-		return err
+		return diag.FromErr(err)
 	}
 	if res == nil {
-		return fmt.Errorf("Failed to find account: %s ", d.Get("username"))
+		return removeFromState(d, "nss account")
 	}
 	if _, ok := d.GetOk("username"); ok {
 		d.Set("username", res["nssUserName"])
@@ -81,30 +86,15 @@ func resourceCVONssAccountRead(d *schema.ResourceData, meta interface{}) error {
 	return nil
 }
 
-func resourceCVONssAccountDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceCVONssAccountDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting nss account: %s", d.Get("username").(string))
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
-	err := client.deleteNssAccount(d.Id(), clientID)
+	err := client.deleteNssAccount(ctx, d.Id(), clientID)
 	if err != nil {
 		log.Printf("Error deleting nss account: %s", d.Get("username").(string))
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId("")
 	return nil
 }
-
-func resourceCVONssAccountExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	log.Printf("Checking existence of nss account: %s", d.Get("username").(string))
-	client := meta.(*Client)
-	clientID := d.Get("client_id").(string)
-	res, err := client.getNssAccount(d.Get("username").(string), clientID)
-	if err != nil {
-		log.Printf("Error checking existence of nss account: %s", d.Get("username").(string))
-		return false, err
-	}
-	if res == nil {
-		return false, nil
-	}
-	return true, nil
-}
@@ -0,0 +1,38 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestNssAccountCRUD_mock(t *testing.T) {
+	mock := newMockOCCM(t)
+	client := mock.client()
+	r := resourceCVONssAccount()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"username":  "nssuser",
+		"password":  "nsspassword",
+		"client_id": "mock",
+	})
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	id := d.Id()
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != id {
+		t.Fatalf("read: expected the account to exist, got %q %v", d.Id(), diags)
+	}
+
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted account to be removed from state, got %q %v", d.Id(), diags)
+	}
+	if len(mock.nssAccounts) != 0 {
+		t.Fatalf("delete: expected no account left, got %v", mock.nssAccounts)
+	}
+}
@@ -0,0 +1,205 @@
+package cloudmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceQtree() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceQtreeCreate,
+		ReadContext:   resourceQtreeRead,
+		UpdateContext: resourceQtreeUpdate,
+		DeleteContext: resourceQtreeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceQtreeImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"security_style": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Computed:     true,
+				ValidateFunc: validation.StringInSlice([]string{"unix", "ntfs", "mixed"}, false),
+			},
+			"unix_permissions": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+			"export_policy_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func expandQtree(d *schema.ResourceData) qtreeRequest {
+	qtree := qtreeRequest{}
+	qtree.Name = d.Get("name").(string)
+	qtree.SecurityStyle = d.Get("security_style").(string)
+	qtree.UnixPermissions = d.Get("unix_permissions").(string)
+	qtree.ExportPolicyName = d.Get("export_policy_name").(string)
+	return qtree
+}
+
+func resourceQtreeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Creating qtree: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	qtree := expandQtree(d)
+	err = client.createQtree(ctx, volume, qtree, clientID)
+	if err != nil {
+		log.Print("Error creating qtree")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(qtree.Name)
+	d.Set("working_environment_id", volume.WorkingEnvironmentID)
+	d.Set("svm_name", volume.SvmName)
+
+	log.Printf("Created qtree: %v", qtree.Name)
+
+	return resourceQtreeRead(ctx, d, meta)
+}
+
+func resourceQtreeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Reading qtree: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "qtree")
+	}
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	res, err := client.getQtree(ctx, volume, d.Id(), clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "qtree")
+	}
+	if err != nil {
+		log.Printf("Error getting qtree. id = %v", d.Id())
+		return diag.FromErr(err)
+	}
+
+	d.Set("security_style", res.SecurityStyle)
+	d.Set("unix_permissions", res.UnixPermissions)
+	d.Set("export_policy_name", res.ExportPolicyName)
+
+	return nil
+}
+
+func resourceQtreeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating qtree: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	qtree := expandQtree(d)
+	err = client.updateQtree(ctx, volume, qtree, clientID)
+	if err != nil {
+		log.Print("Error updating qtree")
+		return diag.FromErr(err)
+	}
+
+	log.Printf("Updated qtree: %v", qtree.Name)
+
+	return resourceQtreeRead(ctx, d, meta)
+}
+
+func resourceQtreeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Deleting qtree: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.deleteQtree(ctx, volume, d.Id(), clientID)
+	if err != nil {
+		log.Print("Error deleting qtree")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceQtreeImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 5 {
+		return []*schema.ResourceData{}, fmt.Errorf("Wrong format of resource: %s. Please follow 'client_id:working_environment_id:svm_name:volume_name:qtree_name'", d.Id())
+	}
+
+	d.SetId(parts[4])
+	d.Set("client_id", parts[0])
+	d.Set("working_environment_id", parts[1])
+	d.Set("svm_name", parts[2])
+	d.Set("volume_name", parts[3])
+	d.Set("name", parts[4])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,64 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestQtreeCRUD_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	mock.addVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "home")
+	r := resourceQtree()
+
+	config := map[string]interface{}{
+		"name":                     "team_a",
+		"volume_name":              "home",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "team_a" || d.Get("svm_name").(string) != "svm_mockvsa" {
+		t.Fatalf("create: unexpected state %v", d.State())
+	}
+	if d.Get("security_style").(string) != "unix" || d.Get("unix_permissions").(string) != "0755" {
+		t.Fatalf("create: expected the qtree defaults to be read, got %v", d.State())
+	}
+
+	config["security_style"] = "ntfs"
+	config["export_policy_name"] = "team_a_hosts"
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if d.Get("security_style").(string) != "ntfs" || d.Get("export_policy_name").(string) != "team_a_hosts" {
+		t.Fatalf("update: unexpected state %v", d.State())
+	}
+
+	// import
+	imported := r.Data(nil)
+	imported.SetId("mock:VsaWorkingEnvironment-mock:svm_mockvsa:home:team_a")
+	states, err := r.Importer.StateContext(context.Background(), imported, client)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), states[0], client); diags.HasError() {
+		t.Fatalf("import read: %v", diags)
+	}
+	if states[0].Get("security_style").(string) != "ntfs" || states[0].Get("volume_name").(string) != "home" {
+		t.Fatalf("import: unexpected state %v", states[0].State())
+	}
+
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted qtree to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
@@ -0,0 +1,257 @@
+package cloudmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceQuotaRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceQuotaRuleCreate,
+		ReadContext:   resourceQuotaRuleRead,
+		UpdateContext: resourceQuotaRuleUpdate,
+		DeleteContext: resourceQuotaRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceQuotaRuleImport,
+		},
+		CustomizeDiff: resourceQuotaRuleCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"volume_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"type": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"tree", "user", "group"}, false),
+			},
+			"target": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"qtree_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"disk_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"soft_disk_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"disk_limit_unit": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "GB",
+				ValidateFunc: validation.StringInSlice([]string{"KB", "MB", "GB", "TB"}, false),
+			},
+			"file_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+			"soft_file_limit": {
+				Type:         schema.TypeInt,
+				Optional:     true,
+				ValidateFunc: validation.IntAtLeast(0),
+			},
+		},
+	}
+}
+
+func expandQuotaRule(d *schema.ResourceData) quotaRuleRequest {
+	rule := quotaRuleRequest{}
+	rule.Type = d.Get("type").(string)
+	rule.Target = d.Get("target").(string)
+	rule.QtreeName = d.Get("qtree_name").(string)
+	unit := d.Get("disk_limit_unit").(string)
+	rule.DiskLimit = size{Size: float64(d.Get("disk_limit").(int)), Unit: unit}
+	rule.SoftDiskLimit = size{Size: float64(d.Get("soft_disk_limit").(int)), Unit: unit}
+	rule.FileLimit = d.Get("file_limit").(int)
+	rule.SoftFileLimit = d.Get("soft_file_limit").(int)
+	return rule
+}
+
+func resourceQuotaRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Creating quota rule: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	rule := expandQuotaRule(d)
+	err = client.createQuotaRule(ctx, volume, rule, clientID)
+	if err != nil {
+		log.Print("Error creating quota rule")
+		return diag.FromErr(err)
+	}
+
+	res, err := client.findQuotaRule(ctx, volume, rule, clientID)
+	if err != nil {
+		log.Print("Error finding the created quota rule")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(res.ID)
+	d.Set("working_environment_id", volume.WorkingEnvironmentID)
+	d.Set("svm_name", volume.SvmName)
+
+	log.Printf("Created quota rule: %v", res.ID)
+
+	return resourceQuotaRuleRead(ctx, d, meta)
+}
+
+func resourceQuotaRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Reading quota rule: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "quota rule")
+	}
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	res, err := client.getQuotaRule(ctx, volume, d.Id(), clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "quota rule")
+	}
+	if err != nil {
+		log.Printf("Error getting quota rule. id = %v", d.Id())
+		return diag.FromErr(err)
+	}
+
+	unit := d.Get("disk_limit_unit").(string)
+	d.Set("type", res.Type)
+	d.Set("target", res.Target)
+	d.Set("qtree_name", res.QtreeName)
+	d.Set("disk_limit", int(math.Round(convertSizeUnit(res.DiskLimit.Size, res.DiskLimit.Unit, unit))))
+	d.Set("soft_disk_limit", int(math.Round(convertSizeUnit(res.SoftDiskLimit.Size, res.SoftDiskLimit.Unit, unit))))
+	d.Set("file_limit", res.FileLimit)
+	d.Set("soft_file_limit", res.SoftFileLimit)
+
+	return nil
+}
+
+func resourceQuotaRuleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating quota rule: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.updateQuotaRule(ctx, volume, d.Id(), expandQuotaRule(d), clientID)
+	if err != nil {
+		log.Print("Error updating quota rule")
+		return diag.FromErr(err)
+	}
+
+	log.Printf("Updated quota rule: %v", d.Id())
+
+	return resourceQuotaRuleRead(ctx, d, meta)
+}
+
+func resourceQuotaRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Deleting quota rule: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.deleteQuotaRule(ctx, volume, d.Id(), clientID)
+	if err != nil {
+		log.Print("Error deleting quota rule")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceQuotaRuleCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
+	if diff.Get("type").(string) == "tree" {
+		if _, ok := diff.GetOk("qtree_name"); ok {
+			return fmt.Errorf("qtree_name is only supported for user and group quota rules, the qtree of a tree quota rule is its target")
+		}
+	}
+	if soft, hard := diff.Get("soft_disk_limit").(int), diff.Get("disk_limit").(int); hard > 0 && soft > hard {
+		return fmt.Errorf("soft_disk_limit %d cannot be greater than disk_limit %d", soft, hard)
+	}
+	if soft, hard := diff.Get("soft_file_limit").(int), diff.Get("file_limit").(int); hard > 0 && soft > hard {
+		return fmt.Errorf("soft_file_limit %d cannot be greater than file_limit %d", soft, hard)
+	}
+	return nil
+}
+
+func resourceQuotaRuleImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 5 {
+		return []*schema.ResourceData{}, fmt.Errorf("Wrong format of resource: %s. Please follow 'client_id:working_environment_id:svm_name:volume_name:quota_rule_id'", d.Id())
+	}
+
+	d.SetId(parts[4])
+	d.Set("client_id", parts[0])
+	d.Set("working_environment_id", parts[1])
+	d.Set("svm_name", parts[2])
+	d.Set("volume_name", parts[3])
+	d.Set("disk_limit_unit", "GB")
+
+	return []*schema.ResourceData{d}, nil
+}
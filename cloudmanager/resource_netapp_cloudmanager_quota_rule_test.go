@@ -0,0 +1,126 @@
+package cloudmanager
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestQuotaRuleCRUD_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	mock.addVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "home")
+	r := resourceQuotaRule()
+
+	config := map[string]interface{}{
+		"volume_name":              "home",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+		"type":                     "tree",
+		"target":                   "team_a",
+		"disk_limit":               100,
+		"soft_disk_limit":          80,
+		"file_limit":               100000,
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if !strings.HasPrefix(d.Id(), "quota-rule-") {
+		t.Fatalf("create: expected the ID of the quota rule, got %q", d.Id())
+	}
+	if d.Get("disk_limit").(int) != 100 || d.Get("soft_disk_limit").(int) != 80 || d.Get("file_limit").(int) != 100000 {
+		t.Fatalf("create: unexpected limits %v", d.State())
+	}
+
+	// a user rule in the qtree is distinct from the tree rule
+	user := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"volume_name":              "home",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+		"type":                     "user",
+		"target":                   "alice",
+		"qtree_name":               "team_a",
+		"disk_limit":               5,
+	})
+	if diags := r.CreateContext(context.Background(), user, client); diags.HasError() {
+		t.Fatalf("create user: %v", diags)
+	}
+	if user.Id() == d.Id() {
+		t.Fatalf("create user: expected a new quota rule, got %q", user.Id())
+	}
+
+	// limits are read in the configured unit
+	config["disk_limit"] = 2
+	config["soft_disk_limit"] = 1
+	config["disk_limit_unit"] = "TB"
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if d.Get("disk_limit").(int) != 2 || d.Get("soft_disk_limit").(int) != 1 {
+		t.Fatalf("update: unexpected limits %v", d.State())
+	}
+	config["disk_limit_unit"] = "GB"
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if got := d.Get("disk_limit").(int); got != 2048 {
+		t.Fatalf("read: expected 2 TB to be read as 2048 GB, got %d", got)
+	}
+
+	// import
+	imported := r.Data(nil)
+	imported.SetId("mock:VsaWorkingEnvironment-mock:svm_mockvsa:home:" + user.Id())
+	states, err := r.Importer.StateContext(context.Background(), imported, client)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), states[0], client); diags.HasError() {
+		t.Fatalf("import read: %v", diags)
+	}
+	if states[0].Get("type").(string) != "user" || states[0].Get("target").(string) != "alice" || states[0].Get("qtree_name").(string) != "team_a" {
+		t.Fatalf("import: unexpected state %v", states[0].State())
+	}
+
+	// the limits of an imported rule are read in GB, the default unit
+	imported = r.Data(nil)
+	imported.SetId("mock:VsaWorkingEnvironment-mock:svm_mockvsa:home:" + d.Id())
+	states, err = r.Importer.StateContext(context.Background(), imported, client)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), states[0], client); diags.HasError() {
+		t.Fatalf("import read: %v", diags)
+	}
+	if states[0].Get("disk_limit_unit").(string) != "GB" || states[0].Get("disk_limit").(int) != 2048 {
+		t.Fatalf("import: expected the 2 TB limit to be read as 2048 GB, got %v", states[0].State())
+	}
+
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted quota rule to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
+
+func TestQuotaRuleCustomizeDiff(t *testing.T) {
+	r := resourceQuotaRule()
+	for name, raw := range map[string]map[string]interface{}{
+		"qtree of a tree rule": {"type": "tree", "target": "team_a", "qtree_name": "team_a"},
+		"soft disk limit":      {"type": "tree", "target": "team_a", "disk_limit": 10, "soft_disk_limit": 20},
+		"soft file limit":      {"type": "user", "target": "alice", "file_limit": 10, "soft_file_limit": 20},
+	} {
+		raw["volume_name"] = "home"
+		raw["client_id"] = "mock"
+		if _, err := r.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(raw), nil); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -1,43 +1,55 @@
 package cloudmanager
 
 import (
+	"context"
 	"log"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceCVOSnapMirror() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCVOSnapMirrorCreate,
-		Read:   resourceCVOSnapMirrorRead,
-		Delete: resourceCVOSnapMirrorDelete,
-		Exists: resourceCVOSnapMirrorExists,
-		Update: resourceCVOSnapMirrorUpdate,
+		CreateContext: resourceCVOSnapMirrorCreate,
+		ReadContext:   resourceCVOSnapMirrorRead,
+		DeleteContext: resourceCVOSnapMirrorDelete,
+		UpdateContext: resourceCVOSnapMirrorUpdate,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(15 * time.Minute),
+			Update: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
 		},
 		Schema: map[string]*schema.Schema{
 			"source_working_environment_id": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 			},
 			"destination_working_environment_id": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 			},
 			"source_working_environment_name": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 			},
 			"destination_working_environment_name": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 			},
 			"destination_aggregate_name": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 			},
 			"policy": {
 				Type:     schema.TypeString,
@@ -58,27 +70,33 @@ func resourceCVOSnapMirror() *schema.Resource {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
+				ForceNew: true,
 			},
 			"destination_svm_name": {
 				Type:     schema.TypeString,
 				Optional: true,
 				Computed: true,
+				ForceNew: true,
 			},
 			"source_volume_name": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"destination_volume_name": {
 				Type:     schema.TypeString,
 				Required: true,
+				ForceNew: true,
 			},
 			"provider_volume_type": {
 				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
 			},
 			"capacity_tier": {
 				Type:         schema.TypeString,
 				Optional:     true,
+				ForceNew:     true,
 				ValidateFunc: validation.StringInSlice([]string{"S3", "Blob", "cloudStorage", "none"}, false),
 			},
 			"client_id": {
@@ -100,21 +118,55 @@ func resourceCVOSnapMirror() *schema.Resource {
 				Optional: true,
 				ForceNew: true,
 			},
+			"state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "snapmirrored",
+				ValidateFunc: validation.StringInSlice(snapMirrorStates, false),
+			},
+			"mirror_state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"relationship_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"lag_time": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"last_transfer_size": {
+				Type:     schema.TypeInt,
+				Computed: true,
+			},
+			"last_transfer_end_time": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"healthy": {
+				Type:     schema.TypeBool,
+				Computed: true,
+			},
+			"unhealthy_reason": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
 		},
 	}
 }
 
-func resourceCVOSnapMirrorCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOSnapMirrorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating SnapMirror: %#v", d)
 
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
 	snapMirror := snapMirrorRequest{}
 
-	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID)
+	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(ctx, d, clientID)
 	if err != nil {
 		log.Print("Cannot find working environment")
-		return err
+		return diag.FromErr(err)
 	}
 
 	snapMirror.ReplicationRequest.SourceWorkingEnvironmentID = sourceWEInfo.PublicID
@@ -172,10 +224,10 @@ func resourceCVOSnapMirrorCreate(d *schema.ResourceData, meta interface{}) error
 		snapMirror.ReplicationVolume.DestinationSvmName = destWEInfo.SvmName
 	}
 
-	res, err := client.buildSnapMirrorCreate(snapMirror, sourceWEInfo.WorkingEnvironmentType, destWEInfo.WorkingEnvironmentType, clientID)
+	res, err := client.buildSnapMirrorCreate(ctx, snapMirror, sourceWEInfo.WorkingEnvironmentType, destWEInfo.WorkingEnvironmentType, clientID)
 	if err != nil {
 		log.Print("Error creating SnapMirrorCreate")
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(res.ReplicationVolume.DestinationVolumeName)
@@ -185,10 +237,16 @@ func resourceCVOSnapMirrorCreate(d *schema.ResourceData, meta interface{}) error
 	d.Set("source_volume_name", res.ReplicationVolume.SourceVolumeName)
 	d.Set("destination_volume_name", res.ReplicationVolume.DestinationVolumeName)
 
-	return resourceCVOSnapMirrorRead(d, meta)
+	err = changeSnapMirrorState(ctx, client, d, sourceWEInfo, destWEInfo, "snapmirrored", clientID)
+	if err != nil {
+		log.Print("Error changing SnapMirror state")
+		return diag.FromErr(err)
+	}
+
+	return resourceCVOSnapMirrorRead(ctx, d, meta)
 }
 
-func resourceCVOSnapMirrorRead(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOSnapMirrorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Fetching SnapMirror: %#v", d)
 
 	client := meta.(*Client)
@@ -196,88 +254,191 @@ func resourceCVOSnapMirrorRead(d *schema.ResourceData, meta interface{}) error {
 
 	snapMirror := snapMirrorRequest{}
 
-	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID)
+	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(ctx, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "SnapMirror")
+	}
 	if err != nil {
 		log.Print("Cannot find working environment")
-		return err
+		return diag.FromErr(err)
 	}
 
 	snapMirror.ReplicationRequest.SourceWorkingEnvironmentID = sourceWEInfo.PublicID
 	snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID = destWEInfo.PublicID
 	snapMirror.ReplicationVolume.SourceVolumeName = d.Get("source_volume_name").(string)
 	snapMirror.ReplicationVolume.DestinationVolumeName = d.Get("destination_volume_name").(string)
-	_, err = client.getSnapMirror(snapMirror, d.Id(), clientID)
-	if err != nil {
+	res, err := client.getSnapMirror(ctx, snapMirror, d.Id(), clientID)
+	if err != nil && !isNotFound(err) {
 		log.Print("Error getting SnapMirror")
-		return err
+		return diag.FromErr(err)
+	}
+	reversed := false
+	if isNotFound(err) || snapMirrorState(res) == "broken_off" {
+		// after a reverse resync, the relationship goes from the destination volume back to the source volume
+		reversedSnapMirror := snapMirrorTarget(d, sourceWEInfo, destWEInfo, true)
+		reversedRes, reversedErr := client.getSnapMirror(ctx, reversedSnapMirror, d.Get("source_volume_name").(string), clientID)
+		if reversedErr != nil && !isNotFound(reversedErr) {
+			log.Print("Error getting reversed SnapMirror")
+			return diag.FromErr(reversedErr)
+		}
+		if reversedErr == nil && snapMirrorState(reversedRes) != "broken_off" {
+			reversed = true
+			res, err = reversedRes, nil
+		}
+	}
+	if isNotFound(err) {
+		return removeFromState(d, "SnapMirror")
+	}
+
+	if reversed {
+		d.Set("state", "reversed")
+	} else if state := snapMirrorState(res); state != "" {
+		d.Set("state", state)
+	}
+	setSnapMirrorHealth(d, res)
+	if res.PolicyName != "" {
+		d.Set("policy", res.PolicyName)
+	}
+	if res.ScheduleName != "" {
+		d.Set("schedule", res.ScheduleName)
+	}
+	// the rate is reported with its unit, the argument is in KB/s
+	if res.MaxTransferRate.Unit != "" {
+		d.Set("max_transfer_rate", int(convertSizeUnit(res.MaxTransferRate.Size, res.MaxTransferRate.Unit, "KB")))
 	}
 
 	return nil
 }
 
-func resourceCVOSnapMirrorDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOSnapMirrorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting SnapMirror: %#v", d)
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
-	snapMirror := snapMirrorRequest{}
 
-	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID)
+	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(ctx, d, clientID)
 	if err != nil {
 		log.Print("Cannot find working environment")
-		return err
+		return diag.FromErr(err)
 	}
 
-	snapMirror.ReplicationRequest.SourceWorkingEnvironmentID = sourceWEInfo.PublicID
-	snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID = destWEInfo.PublicID
-	snapMirror.ReplicationVolume.DestinationSvmName = d.Get("destination_svm_name").(string)
-	snapMirror.ReplicationVolume.SourceVolumeName = d.Get("source_volume_name").(string)
-	snapMirror.ReplicationVolume.DestinationVolumeName = d.Get("destination_volume_name").(string)
-	if snapMirror.ReplicationVolume.DestinationVolumeName == "" {
-		snapMirror.ReplicationVolume.DestinationVolumeName = snapMirror.ReplicationVolume.SourceVolumeName + "_copy"
+	// a reversed relationship goes from the destination volume back to the source volume,
+	// the original one is left broken off by the reverse resync and is deleted after it
+	reversed := d.Get("state").(string) == "reversed"
+	if reversed {
+		err = client.deleteSnapMirror(ctx, snapMirrorTarget(d, sourceWEInfo, destWEInfo, true), clientID)
+		if err != nil {
+			log.Print("Error deleting reversed SnapMirror")
+			return diag.FromErr(err)
+		}
 	}
-	if s, ok := d.GetOk("source_svm_name"); ok {
-		snapMirror.ReplicationVolume.SourceSvmName = s.(string)
+
+	snapMirror := snapMirrorTarget(d, sourceWEInfo, destWEInfo, false)
+	if snapMirror.ReplicationVolume.DestinationVolumeName == "" {
+		snapMirror.ReplicationVolume.DestinationVolumeName = d.Get("source_volume_name").(string) + "_copy"
 	}
 
-	err = client.deleteSnapMirror(snapMirror, clientID)
+	err = client.deleteSnapMirror(ctx, snapMirror, clientID)
+	if reversed && isNotFound(err) {
+		return nil
+	}
 	if err != nil {
 		log.Print("Error deleting SnapMirror")
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceCVOSnapMirrorExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	log.Printf("Checking existence of SnapMirror: %#v", d)
+func resourceCVOSnapMirrorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating SnapMirror: %#v", d)
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
-	snapMirror := snapMirrorRequest{}
 
-	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(d, clientID)
+	sourceWEInfo, destWEInfo, err := client.getWorkingEnvironmentDetailForSnapMirror(ctx, d, clientID)
 	if err != nil {
 		log.Print("Cannot find working environment")
-		return false, err
+		return diag.FromErr(err)
 	}
 
-	snapMirror.ReplicationRequest.SourceWorkingEnvironmentID = sourceWEInfo.PublicID
-	snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID = destWEInfo.PublicID
-	snapMirror.ReplicationVolume.SourceVolumeName = d.Get("source_volume_name").(string)
-	snapMirror.ReplicationVolume.DestinationVolumeName = d.Get("destination_volume_name").(string)
-	snapMirror.ReplicationVolume.DestinationSvmName = d.Get("destination_svm_name").(string)
-	res, err := client.getSnapMirror(snapMirror, d.Id(), clientID)
-	if err != nil {
-		log.Print("Error getting SnapMirror")
-		return false, err
+	if d.HasChange("state") {
+		from, _ := d.GetChange("state")
+		if from.(string) == "" {
+			from = "snapmirrored"
+		}
+		err = changeSnapMirrorState(ctx, client, d, sourceWEInfo, destWEInfo, from.(string), clientID)
+		if err != nil {
+			log.Print("Error changing SnapMirror state")
+			return diag.FromErr(err)
+		}
 	}
 
-	if res != d.Id() {
-		d.SetId("")
-		return false, nil
+	// the state is changed first, so the settings go to the relationship in its new direction
+	if d.HasChanges("policy", "schedule", "max_transfer_rate") {
+		snapMirror := snapMirrorTarget(d, sourceWEInfo, destWEInfo, d.Get("state").(string) == "reversed")
+		snapMirror.ReplicationRequest.PolicyName = d.Get("policy").(string)
+		snapMirror.ReplicationRequest.ScheduleName = d.Get("schedule").(string)
+		snapMirror.ReplicationRequest.MaxTransferRate = d.Get("max_transfer_rate").(int)
+		err = client.updateSnapMirror(ctx, snapMirror, clientID)
+		if err != nil {
+			log.Print("Error updating SnapMirror")
+			return diag.FromErr(err)
+		}
 	}
-	return true, nil
+
+	return resourceCVOSnapMirrorRead(ctx, d, meta)
 }
 
-func resourceCVOSnapMirrorUpdate(d *schema.ResourceData, meta interface{}) error {
+// setSnapMirrorHealth sets the computed attributes reporting the replication health of the relationship
+func setSnapMirrorHealth(d *schema.ResourceData, res snapMirrorStatusResponse) {
+	d.Set("mirror_state", res.MirrorState)
+	d.Set("relationship_status", res.RelationshipStatus)
+	d.Set("lag_time", res.LagTime.seconds())
+	d.Set("last_transfer_size", int(convertSizeUnit(res.LastTransferInfo.TransferSize.Size, res.LastTransferInfo.TransferSize.Unit, "B")))
+	if res.LastTransferInfo.TransferEndTime != 0 {
+		d.Set("last_transfer_end_time", time.UnixMilli(res.LastTransferInfo.TransferEndTime).UTC().Format(time.RFC3339))
+	} else {
+		d.Set("last_transfer_end_time", "")
+	}
+	d.Set("healthy", res.Healthy)
+	d.Set("unhealthy_reason", res.UnhealthyReason)
+}
 
+// changeSnapMirrorState moves the relationship from its current state to the configured one
+func changeSnapMirrorState(ctx context.Context, client *Client, d *schema.ResourceData, sourceWEInfo workingEnvironmentInfo, destWEInfo workingEnvironmentInfo, from string, clientID string) error {
+	actions, err := snapMirrorTransition(from, d.Get("state").(string))
+	if err != nil {
+		return err
+	}
+	for _, action := range actions {
+		snapMirror := snapMirrorTarget(d, sourceWEInfo, destWEInfo, action.Reversed)
+		log.Printf("Running SnapMirror %s on %s", action.Name, snapMirror.ReplicationVolume.DestinationVolumeName)
+		err = client.runSnapMirrorAction(ctx, snapMirror, action.Name, clientID)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
+
+// snapMirrorTarget returns the request addressing the relationship by its destination volume. The destination of the
+// reversed relationship is the source volume.
+func snapMirrorTarget(d *schema.ResourceData, sourceWEInfo workingEnvironmentInfo, destWEInfo workingEnvironmentInfo, reversed bool) snapMirrorRequest {
+	snapMirror := snapMirrorRequest{}
+	if reversed {
+		snapMirror.ReplicationRequest.SourceWorkingEnvironmentID = destWEInfo.PublicID
+		snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID = sourceWEInfo.PublicID
+		snapMirror.ReplicationVolume.DestinationSvmName = d.Get("source_svm_name").(string)
+		snapMirror.ReplicationVolume.DestinationVolumeName = d.Get("source_volume_name").(string)
+		if snapMirror.ReplicationVolume.DestinationSvmName == "" {
+			snapMirror.ReplicationVolume.DestinationSvmName = sourceWEInfo.SvmName
+		}
+	} else {
+		snapMirror.ReplicationRequest.SourceWorkingEnvironmentID = sourceWEInfo.PublicID
+		snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID = destWEInfo.PublicID
+		snapMirror.ReplicationVolume.DestinationSvmName = d.Get("destination_svm_name").(string)
+		snapMirror.ReplicationVolume.DestinationVolumeName = d.Get("destination_volume_name").(string)
+		if snapMirror.ReplicationVolume.DestinationSvmName == "" {
+			snapMirror.ReplicationVolume.DestinationSvmName = destWEInfo.SvmName
+		}
+	}
+	return snapMirror
+}
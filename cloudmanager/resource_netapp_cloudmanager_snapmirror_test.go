@@ -0,0 +1,271 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestSnapMirrorUpdate_mock(t *testing.T) {
+	mock := newMockOCCM(t)
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "VsaWorkingEnvironment-source", Name: "source", ProviderName: "Amazon"})
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "VsaWorkingEnvironment-dest", Name: "dest", ProviderName: "Amazon"})
+	mock.addSnapMirror("VsaWorkingEnvironment-source", "svm_source", "vol1", "VsaWorkingEnvironment-dest", "svm_dest", "vol1_copy")
+	client := mock.client()
+	r := resourceCVOSnapMirror()
+
+	config := map[string]interface{}{
+		"source_working_environment_id":      "VsaWorkingEnvironment-source",
+		"destination_working_environment_id": "VsaWorkingEnvironment-dest",
+		"source_volume_name":                 "vol1",
+		"destination_volume_name":            "vol1_copy",
+		"destination_svm_name":               "svm_dest",
+		"client_id":                          "mock",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId("vol1_copy")
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+
+	config["policy"] = "Mirror"
+	config["schedule"] = "5min"
+	config["max_transfer_rate"] = 0
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	sm := mock.findSnapMirror("VsaWorkingEnvironment-dest", "svm_dest", "vol1_copy")
+	if sm["policyName"] != "Mirror" || sm["scheduleName"] != "5min" {
+		t.Fatalf("update: expected policy Mirror and schedule 5min, got %v and %v", sm["policyName"], sm["scheduleName"])
+	}
+	if rate := sm["maxTransferRate"].(map[string]interface{})["size"]; rate != float64(0) {
+		t.Fatalf("update: expected an unlimited transfer rate, got %v", rate)
+	}
+
+	// changes made outside of Terraform show up on refresh
+	sm["scheduleName"] = "1hour"
+	sm["maxTransferRate"] = map[string]interface{}{"size": float64(2), "unit": "MB"}
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if got := d.Get("schedule").(string); got != "1hour" {
+		t.Fatalf("read: expected schedule 1hour, got %q", got)
+	}
+	if got := d.Get("max_transfer_rate").(int); got != 2048 {
+		t.Fatalf("read: expected max_transfer_rate 2048, got %d", got)
+	}
+
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted relationship to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
+
+func TestSnapMirrorIdentityForcesNew(t *testing.T) {
+	r := resourceCVOSnapMirror()
+	config := map[string]interface{}{
+		"source_working_environment_id":      "VsaWorkingEnvironment-source",
+		"destination_working_environment_id": "VsaWorkingEnvironment-dest",
+		"source_volume_name":                 "vol1",
+		"destination_volume_name":            "vol1_copy",
+		"client_id":                          "mock",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId("vol1_copy")
+
+	config["destination_volume_name"] = "vol1_dr"
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), nil)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if !diff.RequiresNew() {
+		t.Fatal("expected a new destination volume to replace the relationship")
+	}
+}
+
+func TestSnapMirrorState_mock(t *testing.T) {
+	mock := newMockOCCM(t)
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "VsaWorkingEnvironment-source", Name: "source", ProviderName: "Amazon"})
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "VsaWorkingEnvironment-dest", Name: "dest", ProviderName: "Amazon"})
+	mock.addSnapMirror("VsaWorkingEnvironment-source", "svm_source", "vol1", "VsaWorkingEnvironment-dest", "svm_dest", "vol1_copy")
+	client := mock.client()
+	r := resourceCVOSnapMirror()
+
+	config := map[string]interface{}{
+		"source_working_environment_id":      "VsaWorkingEnvironment-source",
+		"destination_working_environment_id": "VsaWorkingEnvironment-dest",
+		"source_volume_name":                 "vol1",
+		"source_svm_name":                    "svm_source",
+		"destination_volume_name":            "vol1_copy",
+		"destination_svm_name":               "svm_dest",
+		"client_id":                          "mock",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	d.SetId("vol1_copy")
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if got := d.Get("state").(string); got != "snapmirrored" {
+		t.Fatalf("read: expected state snapmirrored, got %q", got)
+	}
+
+	// fail over, fail back to the source, then return to the original direction
+	for _, state := range []string{"quiesced", "broken_off", "reversed", "snapmirrored", "broken_off", "snapmirrored"} {
+		config["state"] = state
+		d = testResourceDataUpdate(t, r, d, config, client)
+		if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+			t.Fatalf("update to %s: %v", state, diags)
+		}
+		if got := d.Get("state").(string); got != state {
+			t.Fatalf("update to %s: read back state %q", state, got)
+		}
+	}
+	if mock.findSnapMirror("VsaWorkingEnvironment-dest", "svm_dest", "vol1_copy") == nil {
+		t.Fatal("expected the relationship to be back to the original direction")
+	}
+}
+
+func TestSnapMirrorReversed_mock(t *testing.T) {
+	mock := newMockOCCM(t)
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "VsaWorkingEnvironment-source", Name: "source", ProviderName: "Amazon"})
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "VsaWorkingEnvironment-dest", Name: "dest", ProviderName: "Amazon"})
+	mock.addSnapMirror("VsaWorkingEnvironment-source", "svm_source", "vol1", "VsaWorkingEnvironment-dest", "svm_dest", "vol1_copy")
+	client := mock.client()
+	r := resourceCVOSnapMirror()
+
+	config := map[string]interface{}{
+		"source_working_environment_id":      "VsaWorkingEnvironment-source",
+		"destination_working_environment_id": "VsaWorkingEnvironment-dest",
+		"source_volume_name":                 "vol1",
+		"destination_volume_name":            "vol1_copy",
+		"client_id":                          "mock",
+		"state":                              "reversed",
+		"schedule":                           "5min",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"source_working_environment_id":      "VsaWorkingEnvironment-source",
+		"destination_working_environment_id": "VsaWorkingEnvironment-dest",
+		"source_volume_name":                 "vol1",
+		"destination_volume_name":            "vol1_copy",
+		"client_id":                          "mock",
+	})
+	d.SetId("vol1_copy")
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+
+	// the schedule goes to the reverse relationship, replicating back to the source volume
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	sm := mock.findSnapMirror("VsaWorkingEnvironment-source", "svm_source", "vol1")
+	if sm == nil || sm["scheduleName"] != "5min" {
+		t.Fatalf("update: expected the reverse relationship to be updated, got %v", sm)
+	}
+	if got := d.Get("schedule").(string); got != "5min" {
+		t.Fatalf("read: expected the schedule of the reverse relationship, got %q", got)
+	}
+
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if len(mock.snapMirrors) != 0 {
+		t.Fatalf("delete: expected the reverse and the original relationships to be deleted, got %v", mock.snapMirrors)
+	}
+}
+
+func TestSnapMirrorDeleteReversedWithoutOriginal_mock(t *testing.T) {
+	mock := newMockOCCM(t)
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "VsaWorkingEnvironment-source", Name: "source", ProviderName: "Amazon"})
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "VsaWorkingEnvironment-dest", Name: "dest", ProviderName: "Amazon"})
+	// only the reverse relationship is left, the original one was already deleted
+	mock.addSnapMirror("VsaWorkingEnvironment-dest", "svm_dest", "vol1_copy", "VsaWorkingEnvironment-source", "svm_source", "vol1")
+	client := mock.client()
+	r := resourceCVOSnapMirror()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"source_working_environment_id":      "VsaWorkingEnvironment-source",
+		"destination_working_environment_id": "VsaWorkingEnvironment-dest",
+		"source_volume_name":                 "vol1",
+		"destination_volume_name":            "vol1_copy",
+		"client_id":                          "mock",
+	})
+	d.SetId("vol1_copy")
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if got := d.Get("state").(string); got != "reversed" {
+		t.Fatalf("read: expected state reversed, got %q", got)
+	}
+
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if len(mock.snapMirrors) != 0 {
+		t.Fatalf("delete: expected the reverse relationship to be deleted, got %v", mock.snapMirrors)
+	}
+}
+
+func TestSnapMirrorTransition(t *testing.T) {
+	for _, from := range snapMirrorStates {
+		for _, to := range snapMirrorStates {
+			if _, err := snapMirrorTransition(from, to); err != nil {
+				t.Errorf("%s to %s: %v", from, to, err)
+			}
+		}
+	}
+	if _, err := snapMirrorTransition("uninitialized", "snapmirrored"); err == nil {
+		t.Error("expected an error moving from an unknown state")
+	}
+}
+
+func TestSnapMirrorReadHealth_mock(t *testing.T) {
+	mock := newMockOCCM(t)
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "VsaWorkingEnvironment-source", Name: "source", ProviderName: "Amazon"})
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "VsaWorkingEnvironment-dest", Name: "dest", ProviderName: "Amazon"})
+	mock.addSnapMirror("VsaWorkingEnvironment-source", "svm_source", "vol1", "VsaWorkingEnvironment-dest", "svm_dest", "vol1_copy")
+	client := mock.client()
+	r := resourceCVOSnapMirror()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"source_working_environment_id":      "VsaWorkingEnvironment-source",
+		"destination_working_environment_id": "VsaWorkingEnvironment-dest",
+		"source_volume_name":                 "vol1",
+		"destination_volume_name":            "vol1_copy",
+		"client_id":                          "mock",
+	})
+	d.SetId("vol1_copy")
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	expected := map[string]interface{}{
+		"mirror_state":           "snapmirrored",
+		"relationship_status":    "idle",
+		"lag_time":               300,
+		"last_transfer_size":     2097152,
+		"last_transfer_end_time": "2023-11-14T22:13:20Z",
+		"healthy":                true,
+		"unhealthy_reason":       "",
+	}
+	for key, value := range expected {
+		if got := d.Get(key); got != value {
+			t.Errorf("read: expected %s %v, got %v", key, value, got)
+		}
+	}
+
+	sm := mock.findSnapMirror("VsaWorkingEnvironment-dest", "svm_dest", "vol1_copy")
+	sm["healthy"] = false
+	sm["unhealthyReason"] = "Transfer failed"
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("read: %v", diags)
+	}
+	if d.Get("healthy").(bool) || d.Get("unhealthy_reason").(string) != "Transfer failed" {
+		t.Fatalf("read: expected an unhealthy relationship, got %v %q", d.Get("healthy"), d.Get("unhealthy_reason"))
+	}
+}
@@ -0,0 +1,158 @@
+package cloudmanager
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSnapshot() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSnapshotCreate,
+		ReadContext:   resourceSnapshotRead,
+		UpdateContext: resourceSnapshotUpdate,
+		DeleteContext: resourceSnapshotDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"file_system_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"restore_on_destroy": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+			},
+		},
+	}
+}
+
+func resourceSnapshotCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Creating snapshot: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	name := d.Get("name").(string)
+	err = client.createSnapshot(ctx, volume, name, clientID)
+	if err != nil {
+		log.Print("Error creating snapshot")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(name)
+	d.Set("svm_name", volume.SvmName)
+
+	log.Printf("Created snapshot: %v", name)
+
+	return resourceSnapshotRead(ctx, d, meta)
+}
+
+func resourceSnapshotRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Reading snapshot: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "snapshot")
+	}
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	// a volume that no longer exists has no snapshots either
+	_, err = client.getSnapshot(ctx, volume, d.Id(), clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "snapshot")
+	}
+	if err != nil {
+		log.Print("Error getting snapshot")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceSnapshotUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// only restore_on_destroy can change, and it is only used on delete
+	return resourceSnapshotRead(ctx, d, meta)
+}
+
+func resourceSnapshotDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Deleting snapshot: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	if d.Get("restore_on_destroy").(bool) {
+		log.Printf("Restoring volume %s from snapshot %s", volume.Name, d.Id())
+		_, err = client.restoreSnapshot(ctx, volume, d.Id(), snapshotRestoreRequest{}, clientID)
+		if err != nil {
+			log.Print("Error restoring snapshot")
+			return diag.FromErr(err)
+		}
+	}
+
+	err = client.deleteSnapshot(ctx, volume, d.Id(), clientID)
+	if err != nil {
+		log.Print("Error deleting snapshot")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package cloudmanager
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceSnapshotPolicy() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSnapshotPolicyCreate,
+		ReadContext:   resourceSnapshotPolicyRead,
+		UpdateContext: resourceSnapshotPolicyUpdate,
+		DeleteContext: resourceSnapshotPolicyDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"schedule": {
+				Type:     schema.TypeList,
+				Required: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"schedule_type": {
+							Type:         schema.TypeString,
+							ValidateFunc: validation.StringInSlice([]string{"5min", "8hour", "hourly", "daily", "weekly", "monthly"}, true),
+							Required:     true,
+							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
+								return strings.EqualFold(old, new)
+							},
+						},
+						"retention": {
+							Type:     schema.TypeInt,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceSnapshotPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Creating snapshot policy: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	weInfo, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	snapshotPolicy := createSnapshotPolicyRequest{}
+	snapshotPolicy.WorkingEnvironmentID = weInfo.PublicID
+	snapshotPolicy.SnapshotPolicyName = d.Get("name").(string)
+	snapshotPolicy.Schedules = expandSnapshotPolicySchedules(d.Get("schedule").([]interface{}))
+
+	err = client.sendSnapshotPolicy(ctx, "POST", snapshotPolicy, clientID)
+	if err != nil {
+		log.Print("Error creating snapshot policy")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(snapshotPolicy.SnapshotPolicyName)
+
+	log.Printf("Created snapshot policy: %v", snapshotPolicy.SnapshotPolicyName)
+
+	return resourceSnapshotPolicyRead(ctx, d, meta)
+}
+
+func resourceSnapshotPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Reading snapshot policy: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	weInfo, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "snapshot policy")
+	}
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	snapshotPolicy, err := client.getSnapshotPolicy(ctx, weInfo.PublicID, d.Id(), clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "snapshot policy")
+	}
+	if err != nil {
+		log.Print("Error getting snapshot policy")
+		return diag.FromErr(err)
+	}
+
+	d.Set("schedule", flattenSnapshotPolicySchedules(snapshotPolicy.Schedules))
+
+	return nil
+}
+
+func resourceSnapshotPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating snapshot policy: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	weInfo, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	if d.HasChange("schedule") {
+		snapshotPolicy := createSnapshotPolicyRequest{}
+		snapshotPolicy.WorkingEnvironmentID = weInfo.PublicID
+		snapshotPolicy.SnapshotPolicyName = d.Id()
+		snapshotPolicy.Schedules = expandSnapshotPolicySchedules(d.Get("schedule").([]interface{}))
+		err = client.sendSnapshotPolicy(ctx, "PUT", snapshotPolicy, clientID)
+		if err != nil {
+			log.Print("Error updating snapshot policy")
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceSnapshotPolicyRead(ctx, d, meta)
+}
+
+func resourceSnapshotPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Deleting snapshot policy: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	weInfo, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.deleteSnapshotPolicy(ctx, weInfo.PublicID, d.Id(), clientID)
+	if err != nil {
+		log.Print("Error deleting snapshot policy")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestSnapshotPolicyCRUD_mock(t *testing.T) {
+	_, client := newMockVsa(t)
+	r := resourceSnapshotPolicy()
+
+	config := map[string]interface{}{
+		"name":                     "daily_week",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+		"schedule": []interface{}{
+			map[string]interface{}{"schedule_type": "daily", "retention": 7},
+		},
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "daily_week" {
+		t.Fatalf("create: expected ID daily_week, got %q", d.Id())
+	}
+
+	config["schedule"] = []interface{}{
+		map[string]interface{}{"schedule_type": "hourly", "retention": 24},
+		map[string]interface{}{"schedule_type": "daily", "retention": 14},
+	}
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if got := d.Get("schedule.#").(int); got != 2 {
+		t.Fatalf("update: expected 2 schedules, got %d", got)
+	}
+	if got := d.Get("schedule.1.retention").(int); got != 14 {
+		t.Fatalf("update: expected the daily retention to be 14, got %d", got)
+	}
+
+	// the API reports the schedule types in lower case
+	config["schedule"] = []interface{}{
+		map[string]interface{}{"schedule_type": "Hourly", "retention": 24},
+		map[string]interface{}{"schedule_type": "Daily", "retention": 14},
+	}
+	diff, err := r.Diff(context.Background(), d.State(), terraform.NewResourceConfigRaw(config), client)
+	if err != nil {
+		t.Fatalf("diff: %v", err)
+	}
+	if diff != nil && !diff.Empty() {
+		t.Fatalf("expected no diff for schedule types differing in case, got %v", diff)
+	}
+
+	// the policy is visible to volumes referencing it by name
+	if !client.findSnapshotPolicy(context.Background(), "VsaWorkingEnvironment-mock", "daily_week", "mock") {
+		t.Fatal("expected the snapshot policy to be found")
+	}
+
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted snapshot policy to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
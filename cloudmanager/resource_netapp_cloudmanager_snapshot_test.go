@@ -0,0 +1,70 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSnapshotCRUD_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	mock.addVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "vol1")
+	r := resourceSnapshot()
+
+	config := map[string]interface{}{
+		"name":                     "before_upgrade",
+		"volume_name":              "vol1",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "before_upgrade" || d.Get("svm_name").(string) != "svm_mockvsa" {
+		t.Fatalf("create: unexpected ID %q or SVM %q", d.Id(), d.Get("svm_name"))
+	}
+	if len(mock.snapshots["VsaWorkingEnvironment-mock/svm_mockvsa/vol1"]) != 1 {
+		t.Fatalf("create: expected the snapshot to be taken, got %v", mock.snapshots)
+	}
+
+	// deleting without restore_on_destroy leaves the volume as it is
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if count := mock.requestCount("POST", "/occm/api/vsa/volumes/VsaWorkingEnvironment-mock/svm_mockvsa/vol1/snapshots/before_upgrade/restore"); count != 0 {
+		t.Fatalf("delete: expected no restore, got %d", count)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted snapshot to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
+
+func TestSnapshotRestoreOnDestroy_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	mock.addVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "vol1")
+	r := resourceSnapshot()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                   "before_upgrade",
+		"volume_name":            "vol1",
+		"working_environment_id": "VsaWorkingEnvironment-mock",
+		"client_id":              "mock",
+		"restore_on_destroy":     true,
+	})
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if count := mock.requestCount("POST", "/occm/api/vsa/volumes/VsaWorkingEnvironment-mock/svm_mockvsa/vol1/snapshots/before_upgrade/restore"); count != 1 {
+		t.Fatalf("delete: expected the volume to be restored, got %d restores", count)
+	}
+	if len(mock.snapshots["VsaWorkingEnvironment-mock/svm_mockvsa/vol1"]) != 0 {
+		t.Fatalf("delete: expected the snapshot to be deleted, got %v", mock.snapshots)
+	}
+}
@@ -0,0 +1,228 @@
+package cloudmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceSVM() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSVMCreate,
+		ReadContext:   resourceSVMRead,
+		UpdateContext: resourceSVMUpdate,
+		DeleteContext: resourceSVMDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSVMImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"file_system_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"state": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"management_lif_ip": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"data_lif": {
+				Type:     schema.TypeList,
+				Computed: true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"ip": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"node_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"protocols": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// getSVMWorkingEnvironmentID returns the ID of the working environment or file system of the SVM
+func getSVMWorkingEnvironmentID(ctx context.Context, client *Client, d *schema.ResourceData, clientID string) (string, error) {
+	if v, ok := d.GetOk("file_system_id"); ok {
+		return v.(string), nil
+	}
+	workingEnv, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return "", err
+	}
+	return workingEnv.PublicID, nil
+}
+
+func resourceSVMCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Creating SVM: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnvironmentID, err := getSVMWorkingEnvironmentID(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	svm := svmRequest{SvmName: d.Get("name").(string)}
+	err = client.createSVM(ctx, workingEnvironmentID, svm, clientID)
+	if err != nil {
+		log.Print("Error creating SVM")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(svm.SvmName)
+	d.Set("working_environment_id", workingEnvironmentID)
+
+	log.Printf("Created SVM: %v", svm.SvmName)
+
+	return resourceSVMRead(ctx, d, meta)
+}
+
+func resourceSVMRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Reading SVM: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnvironmentID, err := getSVMWorkingEnvironmentID(ctx, client, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "SVM")
+	}
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	res, err := client.getSVM(ctx, workingEnvironmentID, d.Id(), clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "SVM")
+	}
+	if err != nil {
+		log.Printf("Error getting SVM. id = %v", d.Id())
+		return diag.FromErr(err)
+	}
+
+	d.Set("name", res.Name)
+	d.Set("state", res.State)
+	d.Set("management_lif_ip", res.ManagementLif.IP)
+	d.Set("data_lif", flattenSVMDataLifs(res.DataLifs))
+
+	return nil
+}
+
+func resourceSVMUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating SVM: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnvironmentID, err := getSVMWorkingEnvironmentID(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	if d.HasChange("name") {
+		newName := d.Get("name").(string)
+		err = client.renameSVM(ctx, workingEnvironmentID, d.Id(), newName, clientID)
+		if err != nil {
+			log.Print("Error renaming SVM")
+			return diag.FromErr(err)
+		}
+		d.SetId(newName)
+		log.Printf("Renamed SVM to %v", newName)
+	}
+
+	return resourceSVMRead(ctx, d, meta)
+}
+
+func resourceSVMDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Deleting SVM: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	workingEnvironmentID, err := getSVMWorkingEnvironmentID(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.deleteSVM(ctx, workingEnvironmentID, d.Id(), clientID)
+	if err != nil {
+		log.Print("Error deleting SVM")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceSVMImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 3 {
+		return []*schema.ResourceData{}, fmt.Errorf("Wrong format of resource: %s. Please follow 'client_id:working_environment_id:svm_name'", d.Id())
+	}
+
+	d.SetId(parts[2])
+	d.Set("client_id", parts[0])
+	if strings.HasPrefix(parts[1], "fs-") {
+		d.Set("file_system_id", parts[1])
+	} else {
+		d.Set("working_environment_id", parts[1])
+	}
+	d.Set("name", parts[2])
+
+	return []*schema.ResourceData{d}, nil
+}
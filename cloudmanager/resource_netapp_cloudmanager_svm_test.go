@@ -0,0 +1,122 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestSVMCRUD_mock(t *testing.T) {
+	mock := newMockOCCM(t)
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "VsaWorkingEnvironment-mock", Name: "mockazure", ProviderName: "Azure", IsHA: true})
+	client := mock.client()
+	r := resourceSVM()
+
+	config := map[string]interface{}{
+		"name":                     "svm_tenant_a",
+		"working_environment_name": "mockazure",
+		"client_id":                "mock",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "svm_tenant_a" || d.Get("working_environment_id").(string) != "VsaWorkingEnvironment-mock" {
+		t.Fatalf("create: unexpected state %v", d.State())
+	}
+	if d.Get("state").(string) != "running" || d.Get("management_lif_ip").(string) == "" {
+		t.Fatalf("create: expected the SVM details to be read, got %v", d.State())
+	}
+	if got := d.Get("data_lif.#").(int); got != 2 {
+		t.Fatalf("create: expected 2 data LIFs, got %d", got)
+	}
+	if got := d.Get("data_lif.1.protocols.0").(string); got != "iscsi" {
+		t.Fatalf("create: expected an iSCSI LIF, got %q", got)
+	}
+
+	// a second create with the same name is rejected
+	dup := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), dup, client); !diags.HasError() {
+		t.Fatal("create: expected an error for an existing SVM")
+	}
+
+	config["name"] = "svm_tenant_b"
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if d.Id() != "svm_tenant_b" || d.Get("name").(string) != "svm_tenant_b" {
+		t.Fatalf("update: expected the SVM to be renamed, got %v", d.State())
+	}
+
+	// import
+	imported := r.Data(nil)
+	imported.SetId("mock:VsaWorkingEnvironment-mock:svm_tenant_b")
+	states, err := r.Importer.StateContext(context.Background(), imported, client)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), states[0], client); diags.HasError() {
+		t.Fatalf("import read: %v", diags)
+	}
+	if states[0].Get("management_lif_ip").(string) != d.Get("management_lif_ip").(string) {
+		t.Fatalf("import: unexpected state %v", states[0].State())
+	}
+
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted SVM to be removed from state, got %q %v", d.Id(), diags)
+	}
+	if got := len(mock.svms["VsaWorkingEnvironment-mock"]); got != 1 {
+		t.Fatalf("delete: expected the default SVM to remain, got %d SVMs", got)
+	}
+}
+
+func TestSVMFSx_mock(t *testing.T) {
+	mock := newMockOCCM(t)
+	mock.addWorkingEnvironment(mockWorkingEnvironment{PublicID: "fs-mock", Name: "mockfsx", ProviderName: "Amazon", WorkingEnvironmentType: "AWS_FSX", SvmName: "svm_fsx", TenantID: "workspace-mock"})
+	client := mock.client()
+	r := resourceSVM()
+
+	config := map[string]interface{}{
+		"name":           "svm_tenant_a",
+		"file_system_id": "fs-mock",
+		"tenant_id":      "workspace-mock",
+		"client_id":      "mock",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "svm_tenant_a" || d.Get("working_environment_id").(string) != "fs-mock" {
+		t.Fatalf("create: unexpected state %v", d.State())
+	}
+
+	// the SVM created with the file system is still the one used by FSx volumes
+	name, err := client.getFSXSVM(context.Background(), "fs-mock", "mock")
+	if err != nil || name != "svm_fsx" {
+		t.Fatalf("getFSXSVM: expected svm_fsx, got %q %v", name, err)
+	}
+
+	imported := r.Data(nil)
+	imported.SetId("mock:fs-mock:svm_tenant_a")
+	states, err := r.Importer.StateContext(context.Background(), imported, client)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if states[0].Get("file_system_id").(string) != "fs-mock" {
+		t.Fatalf("import: expected the file system ID to be set, got %v", states[0].State())
+	}
+	if diags := r.ReadContext(context.Background(), states[0], client); diags.HasError() || states[0].Id() != "svm_tenant_a" {
+		t.Fatalf("import read: %q %v", states[0].Id(), diags)
+	}
+
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+}
@@ -1,25 +1,32 @@
 package cloudmanager
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
-	"github.com/hashicorp/terraform/helper/schema"
-	"github.com/hashicorp/terraform/helper/validation"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceCVOVolume() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceCVOVolumeCreate,
-		Read:   resourceCVOVolumeRead,
-		Delete: resourceCVOVolumeDelete,
-		Exists: resourceCVOVolumeExists,
-		Update: resourceCVOVolumeUpdate,
+		CreateContext: resourceCVOVolumeCreate,
+		ReadContext:   resourceCVOVolumeRead,
+		DeleteContext: resourceCVOVolumeDelete,
+		UpdateContext: resourceCVOVolumeUpdate,
 		Importer: &schema.ResourceImporter{
-			State: schema.ImportStatePassthrough,
+			StateContext: schema.ImportStatePassthroughContext,
 		},
 		CustomizeDiff: resourceVolumeCustomizeDiff,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(75 * time.Minute),
+			Update: schema.DefaultTimeout(15 * time.Minute),
+			Delete: schema.DefaultTimeout(15 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:     schema.TypeString,
@@ -171,9 +178,27 @@ func resourceCVOVolume() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
-			"snapshot_policy": {
-				Type:     schema.TypeSet,
+			"clone_source_volume": {
+				Type:     schema.TypeString,
 				Optional: true,
+				ForceNew: true,
+			},
+			"clone_source_snapshot": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"clone_source_volume"},
+			},
+			"clone_split": {
+				Type:         schema.TypeBool,
+				Optional:     true,
+				Default:      false,
+				RequiredWith: []string{"clone_source_volume"},
+			},
+			"snapshot_policy": {
+				Type:       schema.TypeSet,
+				Optional:   true,
+				Deprecated: "use the netapp-cloudmanager_snapshot_policy resource and reference it with snapshot_policy_name",
 				Elem: &schema.Resource{
 					Schema: map[string]*schema.Schema{
 						"schedule": {
@@ -202,7 +227,7 @@ func resourceCVOVolume() *schema.Resource {
 	}
 }
 
-func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOVolumeCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Creating volume: %s", d.Get("name").(string))
 
 	client := meta.(*Client)
@@ -249,9 +274,9 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 		svm = v.(string)
 	}
 
-	weInfo, err := client.getWorkingEnvironmentDetail(d, clientID)
+	weInfo, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
 	if err != nil {
-		return fmt.Errorf("cannot find working environment")
+		return diag.Errorf("cannot find working environment")
 	}
 	volume.WorkingEnvironmentID = weInfo.PublicID
 	volume.WorkingEnvironmentType = weInfo.WorkingEnvironmentType
@@ -266,16 +291,20 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 	workingEnvironmentType = weInfo.WorkingEnvironmentType
 	volume.WorkingEnvironmentType = workingEnvironmentType
 
+	if v, ok := d.GetOk("clone_source_volume"); ok {
+		return resourceCVOVolumeClone(ctx, d, meta, volume, weInfo, v.(string))
+	}
+
 	if workingEnvironmentType != "ON_PREM" {
 		// Check if snapshot_nolicy_name exists
-		if !client.findSnapshotPolicy(weInfo.PublicID, quote.SnapshotPolicyName, clientID) {
+		if !client.findSnapshotPolicy(ctx, weInfo.PublicID, quote.SnapshotPolicyName, clientID) {
 			// If snapshot_policy_name does not exist, create the snapshot policy
 			if v, ok := d.GetOk("snapshot_policy"); ok {
 				policy := v.(*schema.Set)
 				if policy.Len() > 0 {
-					err := client.createSnapshotPolicy(weInfo.PublicID, quote.SnapshotPolicyName, policy, clientID)
+					err := client.createSnapshotPolicy(ctx, weInfo.PublicID, quote.SnapshotPolicyName, policy, clientID)
 					if err != nil {
-						return err
+						return diag.FromErr(err)
 					}
 				}
 			}
@@ -306,14 +335,14 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 				volume.CapacityTier = "cloudStorage"
 			}
 		}
-		response, err := client.quoteVolume(quote, clientID)
+		response, err := client.quoteVolume(ctx, quote, clientID)
 		if err != nil {
 			log.Printf("Error quoting volume")
-			return err
+			return diag.FromErr(err)
 		}
-		volume.NewAggregate = response["newAggregate"].(bool)
-		volume.AggregateName = response["aggregateName"].(string)
-		volume.NumOfDisks = response["numOfDisks"].(float64)
+		volume.NewAggregate = response.NewAggregate
+		volume.AggregateName = response.AggregateName
+		volume.NumOfDisks = response.NumOfDisks
 	}
 
 	volume.ProviderVolumeType = d.Get("provider_volume_type").(string)
@@ -359,12 +388,12 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 		volume.VolumeTags = tags
 	}
 	if volumeProtocol == "cifs" {
-		exist, err := client.checkCifsExists(workingEnvironmentType, volume.WorkingEnvironmentID, volume.SvmName, clientID)
+		exist, err := client.checkCifsExists(ctx, workingEnvironmentType, volume.WorkingEnvironmentID, volume.SvmName, clientID)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if !exist {
-			return fmt.Errorf("cifs has not been set up yet")
+			return diag.Errorf("cifs has not been set up yet")
 		}
 		if v, ok := d.GetOk("share_name"); ok {
 			volume.ShareInfo.ShareName = v.(string)
@@ -380,9 +409,9 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 			volume.ShareInfo.AccessControl.Users = users
 		}
 	} else if volumeProtocol == "iscsi" {
-		isNewIgroup, _, err := createIscsiVolumeHelper(d, meta)
+		isNewIgroup, _, err := createIscsiVolumeHelper(ctx, d, meta)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if v, ok := d.GetOk("os_name"); ok {
 			volume.IscsiInfo.OsName = v.(string)
@@ -391,10 +420,10 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 			log.Print("Need to create igroup")
 			igroups := d.Get("igroups").(*schema.Set)
 			if igroups.Len() > 1 {
-				return fmt.Errorf("can not create more than one new igroup")
+				return attributeErrorf("igroups", "can not create more than one new igroup")
 			}
 			if _, ok := d.GetOk("initiator"); !ok {
-				return fmt.Errorf("initiator is required when creating new igroup")
+				return attributeErrorf("initiator", "initiator is required when creating new igroup")
 			}
 			volume.IscsiInfo.IgroupCreationRequest.IgroupName = igroups.List()[0].(string)
 			if v, ok := d.GetOk("initiator"); ok {
@@ -418,15 +447,15 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 	volume.WorkingEnvironmentType = workingEnvironmentType
-	err = client.createVolume(volume, createAggregateifNotExists, clientID)
+	err = client.createVolume(ctx, volume, createAggregateifNotExists, clientID)
 	if err != nil {
 		log.Print("Error creating volume")
-		return err
+		return diag.FromErr(err)
 	}
-	res, err := client.getVolume(volume, clientID)
+	res, err := client.getVolume(ctx, volume, clientID)
 	if err != nil {
 		log.Print("Error reading volume after creation")
-		return err
+		return diag.FromErr(err)
 	}
 	for _, volume := range res {
 		if volume.SvmName == svm && volume.Name == d.Get("name") {
@@ -435,10 +464,82 @@ func resourceCVOVolumeCreate(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	return resourceCVOVolumeRead(d, meta)
+	return resourceCVOVolumeRead(ctx, d, meta)
 }
 
-func resourceCVOVolumeRead(d *schema.ResourceData, meta interface{}) error {
+// resourceCVOVolumeClone creates the volume as a FlexClone of clone_source_volume, applies the configured settings
+// over the ones inherited from its parent, resizing it when the size differs, and splits it from its parent when
+// clone_split is set
+func resourceCVOVolumeClone(ctx context.Context, d *schema.ResourceData, meta interface{}, volume volumeRequest, weInfo workingEnvironmentInfo, parentName string) diag.Diagnostics {
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	parent := volume
+	parent.Name = parentName
+	clone := cloneVolumeRequest{}
+	clone.NewVolumeName = d.Get("name").(string)
+	clone.ParentSnapshot = d.Get("clone_source_snapshot").(string)
+	err := client.cloneVolume(ctx, parent, clone, clientID)
+	if err != nil {
+		log.Print("Error cloning volume")
+		return diag.FromErr(err)
+	}
+
+	volume.Name = clone.NewVolumeName
+	res, err := client.getVolume(ctx, volume, clientID)
+	if err != nil {
+		log.Print("Error reading volume after cloning")
+		return diag.FromErr(err)
+	}
+	var cloned volumeResponse
+	for _, vol := range res {
+		if vol.SvmName == volume.SvmName && vol.Name == volume.Name {
+			cloned = vol
+			break
+		}
+	}
+	if cloned.ID == "" {
+		return diag.Errorf("cannot find clone %s after cloning %s", volume.Name, parentName)
+	}
+	d.SetId(cloned.ID)
+
+	// the clone keeps the settings of its parent until they are updated
+	update := expandVolumeUpdate(d, true)
+	update.WorkingEnvironmentID = volume.WorkingEnvironmentID
+	update.WorkingEnvironmentType = volume.WorkingEnvironmentType
+	update.SvmName = volume.SvmName
+	update.FileSystemID = volume.FileSystemID
+	configuredSize := convertSizeUnit(d.Get("size").(float64), d.Get("unit").(string), "B")
+	clonedSize := convertSizeUnit(cloned.Size.Size, cloned.Size.Unit, "B")
+	if configuredSize != clonedSize {
+		update.Size.Size = d.Get("size").(float64)
+		update.Size.Unit = d.Get("unit").(string)
+		if weInfo.WorkingEnvironmentType != "ON_PREM" && configuredSize > clonedSize {
+			err = client.quoteVolumeResize(ctx, d, &update, weInfo.CloudProviderName, clientID)
+			if err != nil {
+				log.Printf("Error quoting clone resize")
+				return diag.FromErr(err)
+			}
+		}
+	}
+	err = client.updateVolume(ctx, update, clientID)
+	if err != nil {
+		log.Print("Error updating clone")
+		return diag.FromErr(err)
+	}
+
+	if d.Get("clone_split").(bool) {
+		err = client.splitClone(ctx, volume, clientID)
+		if err != nil {
+			log.Print("Error splitting clone")
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceCVOVolumeRead(ctx, d, meta)
+}
+
+func resourceCVOVolumeRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Fetching volume: %s", d.Get("name").(string))
 
 	client := meta.(*Client)
@@ -449,9 +550,12 @@ func resourceCVOVolumeRead(d *schema.ResourceData, meta interface{}) error {
 		svm = v.(string)
 	}
 
-	weInfo, err := client.getWorkingEnvironmentDetail(d, clientID)
+	weInfo, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "volume")
+	}
 	if err != nil {
-		return fmt.Errorf("cannot find working environment")
+		return diag.Errorf("cannot find working environment: %s", err)
 	}
 	volume.WorkingEnvironmentID = weInfo.PublicID
 	volume.WorkingEnvironmentType = weInfo.WorkingEnvironmentType
@@ -464,10 +568,13 @@ func resourceCVOVolumeRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	volume.SvmName = svm
 
-	res, err := client.getVolume(volume, clientID)
+	res, err := client.getVolume(ctx, volume, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "volume")
+	}
 	if err != nil {
 		log.Print("Error reading volume")
-		return err
+		return diag.FromErr(err)
 	}
 	for _, volume := range res {
 		if volume.ID == d.Id() {
@@ -543,10 +650,10 @@ func resourceCVOVolumeRead(d *schema.ResourceData, meta interface{}) error {
 		}
 	}
 
-	return fmt.Errorf("error reading volume: volume doesn't exist")
+	return removeFromState(d, "volume")
 }
 
-func resourceCVOVolumeDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceCVOVolumeDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	log.Printf("Deleting volume: %s", d.Get("name").(string))
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
@@ -556,9 +663,9 @@ func resourceCVOVolumeDelete(d *schema.ResourceData, meta interface{}) error {
 		svm = v.(string)
 	}
 
-	weInfo, err := client.getWorkingEnvironmentDetail(d, clientID)
+	weInfo, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
 	if err != nil {
-		return fmt.Errorf("cannot find working environment")
+		return diag.Errorf("cannot find working environment")
 	}
 	volume.WorkingEnvironmentID = weInfo.PublicID
 	volume.WorkingEnvironmentType = weInfo.WorkingEnvironmentType
@@ -573,48 +680,18 @@ func resourceCVOVolumeDelete(d *schema.ResourceData, meta interface{}) error {
 
 	volume.Name = d.Get("name").(string)
 
-	err = client.deleteVolume(volume, clientID)
+	err = client.deleteVolume(ctx, volume, clientID)
 	if err != nil {
 		log.Print("Error deleting volume")
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceCVOVolumeExists(d *schema.ResourceData, meta interface{}) (bool, error) {
-	log.Printf("Checking existence of volume: %s", d.Get("name").(string))
-	client := meta.(*Client)
-	clientID := d.Get("client_id").(string)
-	volume := volumeRequest{}
-	volume.Name = d.Get("name").(string)
-	volume.ID = d.Id()
-
-	weInfo, err := client.getWorkingEnvironmentDetail(d, clientID)
-	if err != nil {
-		return false, fmt.Errorf("cannot find working environment")
-	}
-	volume.WorkingEnvironmentID = weInfo.PublicID
-	volume.WorkingEnvironmentType = weInfo.WorkingEnvironmentType
-
-	res, err := client.getVolumeByID(volume, clientID)
-	if err != nil {
-		log.Print("Error reading volume")
-		return false, err
-	}
-
-	if res.ID != d.Id() {
-		d.SetId("")
-		return false, nil
-	}
-	return true, nil
-}
-
-func resourceCVOVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
-	log.Printf("Updating volume: %s", d.Get("name").(string))
-	client := meta.(*Client)
-	clientID := d.Get("client_id").(string)
+// expandVolumeUpdate returns the update request of the volume settings that can be changed in place: all of them
+// when all is set, as for a new clone still holding the settings of its parent, otherwise only the changed ones
+func expandVolumeUpdate(d *schema.ResourceData, all bool) volumeRequest {
 	volume := volumeRequest{}
-	var svm string
 	volume.Name = d.Get("name").(string)
 	volume.ExportPolicyInfo.PolicyType = d.Get("export_policy_type").(string)
 	if v, ok := d.GetOk("export_policy_ip"); ok {
@@ -624,19 +701,7 @@ func resourceCVOVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 		volume.ExportPolicyInfo.Ips = ips
 	}
-
-	weInfo, err := client.getWorkingEnvironmentDetail(d, clientID)
-	if err != nil {
-		return fmt.Errorf("cannot find working environment")
-	}
-	volume.WorkingEnvironmentID = weInfo.PublicID
-	volume.WorkingEnvironmentType = weInfo.WorkingEnvironmentType
-	if svm == "" {
-		svm = weInfo.SvmName
-	}
-	volume.SvmName = svm
-
-	if d.HasChange("export_policy_name") {
+	if all || d.HasChange("export_policy_name") {
 		volume.ExportPolicyInfo.Name = d.Get("export_policy_name").(string)
 	}
 	if v, ok := d.GetOk("export_policy_nfs_version"); ok {
@@ -646,7 +711,7 @@ func resourceCVOVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 		volume.ExportPolicyInfo.NfsVersion = nfs
 	}
-	if d.HasChange("permission") || d.HasChange("users") {
+	if (all && d.Get("share_name").(string) != "") || d.HasChange("permission") || d.HasChange("users") {
 		volume.ShareInfoUpdate.ShareName = d.Get("share_name").(string)
 		volume.ShareInfoUpdate.AccessControlList = make([]accessControlList, 1)
 		volume.ShareInfoUpdate.AccessControlList[0].Permission = d.Get("permission").(string)
@@ -656,26 +721,66 @@ func resourceCVOVolumeUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 		volume.ShareInfoUpdate.AccessControlList[0].Users = users
 	}
-	if d.HasChange("snapshot_policy_name") {
+	if all || d.HasChange("snapshot_policy_name") {
 		volume.SnapshotPolicyName = d.Get("snapshot_policy_name").(string)
 	}
-	if d.HasChange("tiering_policy") {
+	if all || d.HasChange("tiering_policy") {
 		volume.TieringPolicy = d.Get("tiering_policy").(string)
 	}
-	err = client.updateVolume(volume, clientID)
+	return volume
+}
+
+func resourceCVOVolumeUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating volume: %s", d.Get("name").(string))
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	volume := expandVolumeUpdate(d, false)
+	var svm string
+
+	weInfo, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment")
+	}
+	volume.WorkingEnvironmentID = weInfo.PublicID
+	volume.WorkingEnvironmentType = weInfo.WorkingEnvironmentType
+	if svm == "" {
+		svm = weInfo.SvmName
+	}
+	volume.SvmName = svm
+
+	if d.HasChange("size") || d.HasChange("unit") {
+		volume.Size.Size = d.Get("size").(float64)
+		volume.Size.Unit = d.Get("unit").(string)
+		// growing the volume may need disks added to its aggregate, or a new aggregate, same as create
+		if weInfo.WorkingEnvironmentType != "ON_PREM" && isVolumeGrowing(d) {
+			err = client.quoteVolumeResize(ctx, d, &volume, weInfo.CloudProviderName, clientID)
+			if err != nil {
+				log.Printf("Error quoting volume resize")
+				return diag.FromErr(err)
+			}
+		}
+	}
+	err = client.updateVolume(ctx, volume, clientID)
 	if err != nil {
 		log.Print("Error updating volume")
-		return err
+		return diag.FromErr(err)
+	}
+	if d.HasChange("clone_split") && d.Get("clone_split").(bool) {
+		err = client.splitClone(ctx, volume, clientID)
+		if err != nil {
+			log.Print("Error splitting clone")
+			return diag.FromErr(err)
+		}
 	}
 
-	return resourceCVOVolumeRead(d, meta)
+	return resourceCVOVolumeRead(ctx, d, meta)
 }
 
-func resourceVolumeCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error {
+func resourceVolumeCustomizeDiff(ctx context.Context, diff *schema.ResourceDiff, v interface{}) error {
 	// Check supported modification: Use volume name as an indication to know if this is a creation or modification
 	if !(diff.HasChange("name")) {
 		changeableParams := []string{"volume_protocol", "export_policy_type", "export_policy_ip", "export_policy_name", "export_policy_nfs_version",
-			"share_name", "permission", "users", "tiering_policy", "snapshot_policy_name"}
+			"share_name", "permission", "users", "tiering_policy", "snapshot_policy_name", "size", "unit", "clone_split"}
 		changedKeys := diff.GetChangedKeysPrefix("")
 		for _, key := range changedKeys {
 			found := false
@@ -689,20 +794,26 @@ func resourceVolumeCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error
 				return fmt.Errorf("change %s is not allowed", key)
 			}
 		}
+		if currentSplit, expectSplit := diff.GetChange("clone_split"); currentSplit.(bool) && !expectSplit.(bool) {
+			return fmt.Errorf("a clone split from its parent volume cannot be joined back")
+		}
 	}
 
 	if diff.HasChange("volume_protocol") {
 		currentVolumeType, expectVolumeType := diff.GetChange("volume_protocol")
 		if currentVolumeType.(string) == "" {
 			if expectVolumeType.(string) == "nfs" {
-				if _, ok := diff.GetOk("export_policy_type"); !ok {
-					return fmt.Errorf("export_policy_type is required when volume type is nfs")
-				}
-				if _, ok := diff.GetOk("export_policy_ip"); !ok {
-					return fmt.Errorf("export_policy_ip is required when volume type is nfs")
-				}
-				if _, ok := diff.GetOk("export_policy_nfs_version"); !ok {
-					return fmt.Errorf("export_policy_nfs_version is required when volume type is nfs")
+				// an existing export policy, such as a netapp-cloudmanager_export_policy, is referenced by its name alone
+				if _, ok := diff.GetOk("export_policy_name"); !ok {
+					if _, ok := diff.GetOk("export_policy_type"); !ok {
+						return fmt.Errorf("export_policy_type is required when volume type is nfs")
+					}
+					if _, ok := diff.GetOk("export_policy_ip"); !ok {
+						return fmt.Errorf("export_policy_ip is required when volume type is nfs")
+					}
+					if _, ok := diff.GetOk("export_policy_nfs_version"); !ok {
+						return fmt.Errorf("export_policy_nfs_version is required when volume type is nfs")
+					}
 				}
 			} else if expectVolumeType.(string) == "cifs" {
 				if _, ok := diff.GetOk("share_name"); !ok {
@@ -740,7 +851,7 @@ func resourceVolumeCustomizeDiff(diff *schema.ResourceDiff, v interface{}) error
 	return nil
 }
 
-func createIscsiVolumeHelper(d *schema.ResourceData, meta interface{}) (bool, bool, error) {
+func createIscsiVolumeHelper(ctx context.Context, d *schema.ResourceData, meta interface{}) (bool, bool, error) {
 	client := meta.(*Client)
 	clientID := d.Get("client_id").(string)
 	igroup := igroup{}
@@ -754,7 +865,7 @@ func createIscsiVolumeHelper(d *schema.ResourceData, meta interface{}) (bool, bo
 		igroup.IgroupName = v.(string)
 	}
 
-	workingEnvDetail, err := client.getWorkingEnvironmentDetail(d, clientID)
+	workingEnvDetail, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
 	if err != nil {
 		return false, false, fmt.Errorf("cannot find working environment")
 	}
@@ -771,7 +882,7 @@ func createIscsiVolumeHelper(d *schema.ResourceData, meta interface{}) (bool, bo
 	igroup.SvmName = svm
 
 	igroup.WorkingEnvironmentType = workingEnvironmentType
-	res, err := client.getIgroups(igroup, clientID)
+	res, err := client.getIgroups(ctx, igroup, clientID)
 	if err != nil {
 		log.Print("Error reading igroups")
 		return false, false, err
@@ -799,7 +910,7 @@ func createIscsiVolumeHelper(d *schema.ResourceData, meta interface{}) (bool, bo
 		getAll := initiator{}
 		getAll.WorkingEnvironmentID = workingEnvironmentID
 		getAll.WorkingEnvironmentType = workingEnvironmentType
-		res, err := client.getInitiator(getAll, clientID)
+		res, err := client.getInitiator(ctx, getAll, clientID)
 		if err != nil {
 			return false, false, err
 		}
@@ -821,7 +932,10 @@ func createIscsiVolumeHelper(d *schema.ResourceData, meta interface{}) (bool, bo
 		}
 		if isNewInitiator {
 			for _, expectIni := range initiators {
-				client.createInitiator(expectIni, clientID)
+				expectIni.WorkingEnvironmentID = workingEnvironmentID
+				if err := client.createInitiator(ctx, expectIni, clientID); err != nil {
+					return false, false, err
+				}
 			}
 		}
 	}
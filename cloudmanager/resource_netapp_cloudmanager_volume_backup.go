@@ -0,0 +1,205 @@
+package cloudmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceVolumeBackup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVolumeBackupCreate,
+		ReadContext:   resourceVolumeBackupRead,
+		UpdateContext: resourceVolumeBackupUpdate,
+		DeleteContext: resourceVolumeBackupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceVolumeBackupImport,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+			Delete: schema.DefaultTimeout(10 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"volume_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"account_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"policy_name": {
+				Type:     schema.TypeString,
+				Required: true,
+			},
+			"backup_status": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+// getVolumeBackupRequest returns the request for the backup of the volume of the resource, with the ID of its
+// working environment
+func getVolumeBackupRequest(ctx context.Context, client *Client, d *schema.ResourceData, clientID string) (string, volumeBackupRequest, error) {
+	request := volumeBackupRequest{}
+	workingEnv, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
+	if err != nil {
+		return "", volumeBackupRequest{}, err
+	}
+	request.SvmName = workingEnv.SvmName
+	if v, ok := d.GetOk("svm_name"); ok {
+		request.SvmName = v.(string)
+	}
+	request.VolumeName = d.Get("volume_name").(string)
+	request.PolicyName = d.Get("policy_name").(string)
+	return workingEnv.PublicID, request, nil
+}
+
+func resourceVolumeBackupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Enabling volume backup: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	accountID := d.Get("account_id").(string)
+
+	workingEnvironmentID, request, err := getVolumeBackupRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.enableVolumeBackup(ctx, accountID, workingEnvironmentID, request, clientID)
+	if err != nil {
+		log.Print("Error enabling volume backup")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(request.VolumeName)
+	d.Set("working_environment_id", workingEnvironmentID)
+	d.Set("svm_name", request.SvmName)
+
+	log.Printf("Enabled backup of volume %v", request.VolumeName)
+
+	return resourceVolumeBackupRead(ctx, d, meta)
+}
+
+func resourceVolumeBackupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Reading volume backup: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	accountID := d.Get("account_id").(string)
+
+	workingEnvironmentID, request, err := getVolumeBackupRequest(ctx, client, d, clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "volume backup")
+	}
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	res, err := client.getVolumeBackup(ctx, accountID, workingEnvironmentID, request.SvmName, d.Id(), clientID)
+	if isNotFound(err) {
+		return removeFromState(d, "volume backup")
+	}
+	if err != nil {
+		log.Printf("Error getting volume backup. id = %v", d.Id())
+		return diag.FromErr(err)
+	}
+
+	d.Set("policy_name", res.PolicyName)
+	d.Set("backup_status", res.BackupStatus)
+
+	return nil
+}
+
+func resourceVolumeBackupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Updating volume backup: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	accountID := d.Get("account_id").(string)
+
+	workingEnvironmentID, request, err := getVolumeBackupRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.updateVolumeBackup(ctx, accountID, workingEnvironmentID, request, clientID)
+	if err != nil {
+		log.Print("Error updating volume backup")
+		return diag.FromErr(err)
+	}
+
+	log.Printf("Updated backup of volume %v", d.Id())
+
+	return resourceVolumeBackupRead(ctx, d, meta)
+}
+
+func resourceVolumeBackupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Disabling volume backup: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+	accountID := d.Get("account_id").(string)
+
+	workingEnvironmentID, request, err := getVolumeBackupRequest(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	err = client.disableVolumeBackup(ctx, accountID, workingEnvironmentID, request.SvmName, d.Id(), clientID)
+	if err != nil {
+		log.Print("Error disabling volume backup")
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceVolumeBackupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), ":")
+	if len(parts) != 5 {
+		return []*schema.ResourceData{}, fmt.Errorf("Wrong format of resource: %s. Please follow 'client_id:account_id:working_environment_id:svm_name:volume_name'", d.Id())
+	}
+
+	d.SetId(parts[4])
+	d.Set("client_id", parts[0])
+	d.Set("account_id", parts[1])
+	d.Set("working_environment_id", parts[2])
+	d.Set("svm_name", parts[3])
+	d.Set("volume_name", parts[4])
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,62 @@
+package cloudmanager
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestVolumeBackupCRUD_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	mock.addVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "vol1")
+	mock.backups["VsaWorkingEnvironment-mock"] = map[string]interface{}{"provider": "AWS", "status": "ACTIVE"}
+	r := resourceVolumeBackup()
+
+	config := map[string]interface{}{
+		"volume_name":              "vol1",
+		"account_id":               "account-mock",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+		"policy_name":              "gold",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() != "vol1" || d.Get("svm_name").(string) != "svm_mockvsa" || d.Get("backup_status").(string) != "Active" {
+		t.Fatalf("create: unexpected state %v", d.State())
+	}
+
+	config["policy_name"] = "silver"
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if d.Get("policy_name").(string) != "silver" {
+		t.Fatalf("update: unexpected state %v", d.State())
+	}
+
+	// import
+	imported := r.Data(nil)
+	imported.SetId("mock:account-mock:VsaWorkingEnvironment-mock:svm_mockvsa:vol1")
+	states, err := r.Importer.StateContext(context.Background(), imported, client)
+	if err != nil {
+		t.Fatalf("import: %v", err)
+	}
+	if diags := r.ReadContext(context.Background(), states[0], client); diags.HasError() {
+		t.Fatalf("import read: %v", diags)
+	}
+	if states[0].Get("policy_name").(string) != "silver" {
+		t.Fatalf("import: unexpected state %v", states[0].State())
+	}
+
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the disabled backup to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
@@ -0,0 +1,135 @@
+package cloudmanager
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// resourceVolumeRestore restores a volume, or a file of it, from a snapshot when it is created.
+// The restore is a one-off operation: reading keeps the recorded result and deleting only removes it from the state.
+func resourceVolumeRestore() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceVolumeRestoreCreate,
+		ReadContext:   resourceVolumeRestoreRead,
+		DeleteContext: resourceVolumeRestoreDelete,
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+		Schema: map[string]*schema.Schema{
+			"snapshot_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"volume_name": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"svm_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Computed: true,
+				ForceNew: true,
+			},
+			"working_environment_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"working_environment_name": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"file_system_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"tenant_id": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"client_id": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+			},
+			"file_path": {
+				Type:     schema.TypeString,
+				Optional: true,
+				ForceNew: true,
+			},
+			"restore_path": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				RequiredWith: []string{"file_path"},
+			},
+			"triggers": {
+				Type:     schema.TypeMap,
+				Optional: true,
+				ForceNew: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"request_id": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+			"restored_at": {
+				Type:     schema.TypeString,
+				Computed: true,
+			},
+		},
+	}
+}
+
+func resourceVolumeRestoreCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Restoring volume: %#v", d)
+
+	client := meta.(*Client)
+	clientID := d.Get("client_id").(string)
+
+	volume, err := getTargetVolume(ctx, client, d, clientID)
+	if err != nil {
+		return diag.Errorf("cannot find working environment: %s", err)
+	}
+
+	request := snapshotRestoreRequest{}
+	request.FilePath = d.Get("file_path").(string)
+	request.RestorePath = d.Get("restore_path").(string)
+	snapshotName := d.Get("snapshot_name").(string)
+
+	requestID, err := client.restoreSnapshot(ctx, volume, snapshotName, request, clientID)
+	if err != nil {
+		log.Print("Error restoring volume")
+		return diag.FromErr(err)
+	}
+
+	d.SetId(requestID)
+	d.Set("svm_name", volume.SvmName)
+	d.Set("request_id", requestID)
+	d.Set("restored_at", time.Now().UTC().Format(time.RFC3339))
+
+	log.Printf("Restored volume %s from snapshot %s", volume.Name, snapshotName)
+
+	return resourceVolumeRestoreRead(ctx, d, meta)
+}
+
+func resourceVolumeRestoreRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// there is nothing to refresh, the restore already happened
+	return nil
+}
+
+func resourceVolumeRestoreDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	log.Printf("Removing volume restore %s from state, the volume is left as it is", d.Id())
+	return nil
+}
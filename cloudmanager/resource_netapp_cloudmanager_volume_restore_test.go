@@ -0,0 +1,97 @@
+package cloudmanager
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestVolumeRestore_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	mock.addVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "vol1")
+	mock.snapshots["VsaWorkingEnvironment-mock/svm_mockvsa/vol1"] = []string{"before_deploy"}
+	r := resourceVolumeRestore()
+
+	// the whole volume is reverted when no file is given
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"snapshot_name":          "before_deploy",
+		"volume_name":            "vol1",
+		"working_environment_id": "VsaWorkingEnvironment-mock",
+		"client_id":              "mock",
+	})
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() == "" || d.Get("request_id").(string) != d.Id() {
+		t.Fatalf("create: expected the restore task to be recorded, got id %q and request_id %q", d.Id(), d.Get("request_id"))
+	}
+	if d.Get("restored_at").(string) == "" || d.Get("svm_name").(string) != "svm_mockvsa" {
+		t.Fatalf("create: unexpected state %v", d.State())
+	}
+	if len(mock.restores) != 1 || len(mock.restores[0]) != 0 {
+		t.Fatalf("create: expected a volume restore, got %v", mock.restores)
+	}
+
+	// a single file can be restored next to the original
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"snapshot_name":          "before_deploy",
+		"volume_name":            "vol1",
+		"working_environment_id": "VsaWorkingEnvironment-mock",
+		"client_id":              "mock",
+		"file_path":              "/app/config.yaml",
+		"restore_path":           "/app/config.yaml.restored",
+	})
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create file: %v", diags)
+	}
+	if len(mock.restores) != 2 || mock.restores[1]["filePath"] != "/app/config.yaml" || mock.restores[1]["restorePath"] != "/app/config.yaml.restored" {
+		t.Fatalf("create file: expected a file restore, got %v", mock.restores)
+	}
+
+	// deleting only forgets the restore
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	if len(mock.restores) != 2 || len(mock.snapshots["VsaWorkingEnvironment-mock/svm_mockvsa/vol1"]) != 1 {
+		t.Fatalf("delete: expected the volume and snapshot to be left alone, got %v and %v", mock.restores, mock.snapshots)
+	}
+
+	// restoring from a missing snapshot fails
+	d = schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"snapshot_name":          "missing",
+		"volume_name":            "vol1",
+		"working_environment_id": "VsaWorkingEnvironment-mock",
+		"client_id":              "mock",
+	})
+	if diags := r.CreateContext(context.Background(), d, client); !diags.HasError() {
+		t.Fatal("create: expected an error for a missing snapshot")
+	}
+	if d.Id() != "" {
+		t.Fatalf("create: expected no id after a failed restore, got %q", d.Id())
+	}
+}
+
+func TestVolumeRestoreWithoutRequestID_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	mock.addVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "vol1")
+	// the restore is answered without an OnCloud-Request-Id
+	mock.override("POST", mockOCCMAPIRoot+"/volumes/([^/]+)/([^/]+)/([^/]+)/snapshots/([^/]+)/restore", func(w http.ResponseWriter, r *http.Request, args []string) {
+		w.WriteHeader(http.StatusOK)
+	})
+	r := resourceVolumeRestore()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"snapshot_name":          "before_deploy",
+		"volume_name":            "vol1",
+		"working_environment_id": "VsaWorkingEnvironment-mock",
+		"client_id":              "mock",
+	})
+	if diags := r.CreateContext(context.Background(), d, client); !diags.HasError() {
+		t.Fatal("create: expected an error for a restore which cannot be waited on")
+	}
+	if d.Id() != "" {
+		t.Fatalf("create: expected no ID for an unconfirmed restore, got %q", d.Id())
+	}
+}
@@ -1,12 +1,17 @@
 package cloudmanager
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"testing"
 	"time"
 
-	"github.com/hashicorp/terraform/helper/resource"
-	"github.com/hashicorp/terraform/terraform"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/netapp/terraform-provider-netapp-cloudmanager/cloudmanager/cloudmanager/restapi"
 )
 
 var clientID = "vAWgtc8ZcLRshb08kybl2Uhh9W0o5ElE"
@@ -18,7 +23,7 @@ func TestAccVolume_basic(t *testing.T) {
 		PreCheck: func() {
 			testAccPreCheck(t)
 		},
-		Providers: testAccProviders,
+		ProviderFactories: testAccProviderFactories,
 		// CheckDestroy: testAccCheckGCPVolumeDestroy,
 		Steps: []resource.TestStep{
 			{
@@ -93,6 +98,7 @@ func TestAccVolume_basic(t *testing.T) {
 
 func testAccCheckGCPVolumeDestroy(state *terraform.State) error {
 	client := testAccProvider.Meta().(*Client)
+	ctx := context.Background()
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "netapp-cloudmanager_volume" {
@@ -103,13 +109,13 @@ func testAccCheckGCPVolumeDestroy(state *terraform.State) error {
 		if v, ok := rs.Primary.Attributes["working_environment_id"]; ok {
 			vol.WorkingEnvironmentID = v
 		} else if v, ok := rs.Primary.Attributes["working_environment_name"]; ok {
-			info, err := client.findWorkingEnvironmentByName(v, rs.Primary.Attributes["client_id"])
+			info, err := client.findWorkingEnvironmentByName(ctx, v, rs.Primary.Attributes["client_id"])
 			if err != nil {
 				return err
 			}
 			vol.WorkingEnvironmentID = info.PublicID
 		}
-		response, err := client.getVolumeByID(vol, rs.Primary.Attributes["client_id"])
+		response, err := client.getVolumeByID(ctx, vol, rs.Primary.Attributes["client_id"])
 		if err == nil {
 			if response.ID != "" {
 				return fmt.Errorf("volume (%s) still exists", response.ID)
@@ -124,6 +130,7 @@ func testAccCheckVolumeExists(name string, volume *volumeResponse) resource.Test
 	return func(s *terraform.State) error {
 		// time.Sleep(20 * time.Second)
 		client := testAccProvider.Meta().(*Client)
+		ctx := context.Background()
 		rs, ok := s.RootModule().Resources[name]
 		if !ok {
 			return fmt.Errorf("Not found: %s", name)
@@ -136,20 +143,20 @@ func testAccCheckVolumeExists(name string, volume *volumeResponse) resource.Test
 		vol.ID = rs.Primary.ID
 		if v, ok := rs.Primary.Attributes["working_environment_id"]; ok {
 			vol.WorkingEnvironmentID = v
-			info, err := client.getWorkingEnvironmentInfo(v, rs.Primary.Attributes["client_id"])
+			info, err := client.getWorkingEnvironmentInfo(ctx, v, rs.Primary.Attributes["client_id"])
 			if err != nil {
 				return err
 			}
 			vol.WorkingEnvironmentType = info.WorkingEnvironmentType
 		} else if v, ok := rs.Primary.Attributes["working_environment_name"]; ok {
-			info, err := client.findWorkingEnvironmentByName(v, rs.Primary.Attributes["client_id"])
+			info, err := client.findWorkingEnvironmentByName(ctx, v, rs.Primary.Attributes["client_id"])
 			if err != nil {
 				return err
 			}
 			vol.WorkingEnvironmentID = info.PublicID
 			vol.WorkingEnvironmentType = info.WorkingEnvironmentType
 		}
-		response, err := client.getVolumeByID(vol, rs.Primary.Attributes["client_id"])
+		response, err := client.getVolumeByID(ctx, vol, rs.Primary.Attributes["client_id"])
 		if err != nil {
 			return err
 		}
@@ -468,3 +475,299 @@ func testAccGCPVolumeConfigCreateIscsi(clientID string, workingEnvironmentName s
 		working_environment_name = "%s"
 	}`, clientID, workingEnvironmentName)
 }
+
+func TestVolumeCRUD_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	r := resourceCVOVolume()
+
+	config := map[string]interface{}{
+		"name":                      "vol1",
+		"working_environment_name":  "mockvsa",
+		"size":                      10,
+		"unit":                      "GB",
+		"provider_volume_type":      "gp2",
+		"export_policy_type":        "custom",
+		"export_policy_ip":          []interface{}{"10.0.0.0/16"},
+		"export_policy_nfs_version": []interface{}{"nfs3"},
+		"client_id":                 "mock",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	if d.Id() == "" {
+		t.Fatal("create: expected the volume ID to be set")
+	}
+	if got := d.Get("aggregate_name").(string); got != "" && got != "aggr1" {
+		t.Fatalf("create: unexpected aggregate %q", got)
+	}
+
+	config["snapshot_policy_name"] = "none"
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("update: %v", diags)
+	}
+	if got := mock.findVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "vol1")["snapshotPolicy"]; got != "none" {
+		t.Fatalf("update: expected snapshot policy none, got %v", got)
+	}
+
+	id := d.Id()
+	if diags := r.DeleteContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("delete: %v", diags)
+	}
+	d.SetId(id)
+	if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+		t.Fatalf("read: expected the deleted volume to be removed from state, got %q %v", d.Id(), diags)
+	}
+}
+
+func TestVolumeResize_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	r := resourceCVOVolume()
+
+	config := map[string]interface{}{
+		"name":                      "vol1",
+		"working_environment_name":  "mockvsa",
+		"size":                      10,
+		"unit":                      "GB",
+		"provider_volume_type":      "gp2",
+		"export_policy_type":        "custom",
+		"export_policy_ip":          []interface{}{"10.0.0.0/16"},
+		"export_policy_nfs_version": []interface{}{"nfs3"},
+		"client_id":                 "mock",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+
+	// growing is quoted first so the aggregate can be extended
+	config["size"] = 1
+	config["unit"] = "TB"
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("grow: %v", diags)
+	}
+	if count := mock.requestCount("POST", "/occm/api/vsa/volumes/quote"); count != 2 {
+		t.Fatalf("grow: expected the resize to be quoted, got %d quotes", count)
+	}
+	if got := d.Get("size").(float64); got != 1 {
+		t.Fatalf("grow: expected size 1 TB, got %v %s", got, d.Get("unit"))
+	}
+
+	// shrinking needs no extra capacity
+	config["size"] = 500
+	config["unit"] = "GB"
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("shrink: %v", diags)
+	}
+	if count := mock.requestCount("POST", "/occm/api/vsa/volumes/quote"); count != 2 {
+		t.Fatalf("shrink: expected no quote, got %d quotes", count)
+	}
+	if got := d.Get("size").(float64); got != 500 {
+		t.Fatalf("shrink: expected size 500 GB, got %v %s", got, d.Get("unit"))
+	}
+}
+
+func TestVolumeResizeMalformedQuote_mock(t *testing.T) {
+	for name, quote := range map[string]map[string]interface{}{
+		"no aggregate": {"newAggregate": false, "numOfDisks": float64(0)},
+		"wrong type":   {"newAggregate": "no", "aggregateName": "aggr1", "numOfDisks": float64(0)},
+	} {
+		quote := quote
+		t.Run(name, func(t *testing.T) {
+			mock, client := newMockVsa(t)
+			r := resourceCVOVolume()
+
+			config := map[string]interface{}{
+				"name":                     "vol1",
+				"working_environment_name": "mockvsa",
+				"size":                     10,
+				"unit":                     "GB",
+				"provider_volume_type":     "gp2",
+				"client_id":                "mock",
+			}
+			d := schema.TestResourceDataRaw(t, r.Schema, config)
+			if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("create: %v", diags)
+			}
+
+			mock.override("POST", mockOCCMAPIRoot+"/volumes/quote", func(w http.ResponseWriter, r *http.Request, args []string) {
+				writeMockJSON(w, http.StatusOK, quote)
+			})
+			config["size"] = 1
+			config["unit"] = "TB"
+			d = testResourceDataUpdate(t, r, d, config, client)
+			if diags := r.UpdateContext(context.Background(), d, client); !diags.HasError() {
+				t.Fatal("grow: expected an error for a malformed quote")
+			}
+			if count := mock.requestCount("PUT", "/occm/api/vsa/volumes/VsaWorkingEnvironment-mock/svm_mockvsa/vol1"); count != 0 {
+				t.Fatalf("grow: expected the volume not to be resized, got %d updates", count)
+			}
+		})
+	}
+}
+
+func TestVolumeClone_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	mock.addVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "prod")
+	mock.snapshots["VsaWorkingEnvironment-mock/svm_mockvsa/prod"] = []string{"nightly"}
+	r := resourceCVOVolume()
+
+	config := map[string]interface{}{
+		"name":                      "prod_clone",
+		"working_environment_name":  "mockvsa",
+		"size":                      20,
+		"unit":                      "GB",
+		"provider_volume_type":      "gp2",
+		"export_policy_type":        "custom",
+		"export_policy_ip":          []interface{}{"10.0.0.0/16"},
+		"export_policy_nfs_version": []interface{}{"nfs3"},
+		"clone_source_volume":       "prod",
+		"clone_source_snapshot":     "nightly",
+		"client_id":                 "mock",
+	}
+	d := schema.TestResourceDataRaw(t, r.Schema, config)
+	if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("create: %v", diags)
+	}
+	clone := mock.findVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "prod_clone")
+	if clone == nil || d.Id() != clone["uuid"] {
+		t.Fatalf("create: expected the clone to be created, got ID %q", d.Id())
+	}
+	if clone["parentVolumeName"] != "prod" {
+		t.Fatalf("create: expected an unsplit clone of prod, got %v", clone["parentVolumeName"])
+	}
+	// the clone inherits the 10 GB of its parent and is grown to the configured size
+	if got := d.Get("size").(float64); got != 20 {
+		t.Fatalf("create: expected the clone to be resized to 20 GB, got %v", got)
+	}
+	if count := mock.requestCount("POST", "/occm/api/vsa/volumes"); count != 0 {
+		t.Fatalf("create: expected no new volume, got %d", count)
+	}
+
+	// a clone of the same size still gets the configured settings instead of the ones of its parent
+	sameSize := map[string]interface{}{
+		"name":                     "prod_dev",
+		"working_environment_name": "mockvsa",
+		"size":                     10,
+		"unit":                     "GB",
+		"export_policy_type":       "custom",
+		"export_policy_ip":         []interface{}{"10.1.0.0/16"},
+		"tiering_policy":           "snapshot_only",
+		"clone_source_volume":      "prod",
+		"client_id":                "mock",
+	}
+	dev := schema.TestResourceDataRaw(t, r.Schema, sameSize)
+	if diags := r.CreateContext(context.Background(), dev, client); diags.HasError() {
+		t.Fatalf("create same size: %v", diags)
+	}
+	devClone := mock.findVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "prod_dev")
+	if devClone["tieringPolicy"] != "snapshot_only" {
+		t.Fatalf("create same size: expected the tiering policy to be applied, got %v", devClone["tieringPolicy"])
+	}
+	if policy, ok := devClone["exportPolicyInfo"].(map[string]interface{}); !ok || policy["policyType"] != "custom" {
+		t.Fatalf("create same size: expected the export policy to be applied, got %v", devClone["exportPolicyInfo"])
+	}
+
+	config["clone_split"] = true
+	d = testResourceDataUpdate(t, r, d, config, client)
+	if diags := r.UpdateContext(context.Background(), d, client); diags.HasError() {
+		t.Fatalf("split: %v", diags)
+	}
+	if _, ok := clone["parentVolumeName"]; ok {
+		t.Fatal("split: expected the clone to be split from its parent")
+	}
+
+	config["clone_split"] = false
+	state := d.State()
+	if _, err := r.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), client); err == nil {
+		t.Fatal("diff: expected joining a split clone back to be rejected")
+	}
+}
+
+func TestVolumeRefreshesExpiredToken_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	ctx := context.Background()
+
+	if _, err := client.getVolume(ctx, volumeRequest{WorkingEnvironmentID: "VsaWorkingEnvironment-mock"}, "mock"); err != nil {
+		t.Fatalf("getVolume: %v", err)
+	}
+	mock.expireTokens()
+	if _, err := client.getVolume(ctx, volumeRequest{WorkingEnvironmentID: "VsaWorkingEnvironment-mock"}, "mock"); err != nil {
+		t.Fatalf("getVolume after token expiry: %v", err)
+	}
+	if count := mock.requestCount("POST", "/auth/oauth/token"); count != 2 {
+		t.Fatalf("expected 2 token requests, got %d", count)
+	}
+}
+
+func TestVolumeReadSurfacesErrors_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	mock.override("GET", mockOCCMAPIRoot+"/volumes", func(w http.ResponseWriter, r *http.Request, args []string) {
+		writeMockJSON(w, http.StatusInternalServerError, map[string]interface{}{"message": "internal error"})
+	})
+	r := resourceCVOVolume()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                     "vol1",
+		"working_environment_name": "mockvsa",
+		"client_id":                "mock",
+	})
+	d.SetId("vol1-id")
+	if diags := r.ReadContext(context.Background(), d, client); !diags.HasError() {
+		t.Fatal("read: expected the API error to be returned")
+	}
+	if d.Id() != "vol1-id" {
+		t.Fatalf("read: expected the volume to be kept in state on error, got %q", d.Id())
+	}
+}
+
+func TestVolumeReadMissingWorkingEnvironment_mock(t *testing.T) {
+	for _, attribute := range []string{"working_environment_id", "working_environment_name"} {
+		t.Run(attribute, func(t *testing.T) {
+			mock, client := newMockVsa(t)
+			r := resourceCVOVolume()
+
+			config := map[string]interface{}{
+				"name":      "vol1",
+				"size":      10,
+				"unit":      "GB",
+				"client_id": "mock",
+			}
+			if attribute == "working_environment_id" {
+				config[attribute] = "VsaWorkingEnvironment-mock"
+			} else {
+				config[attribute] = "mockvsa"
+			}
+			d := schema.TestResourceDataRaw(t, r.Schema, config)
+			if diags := r.CreateContext(context.Background(), d, client); diags.HasError() {
+				t.Fatalf("create: %v", diags)
+			}
+
+			delete(mock.workingEnvironments, "VsaWorkingEnvironment-mock")
+			if diags := r.ReadContext(context.Background(), d, client); diags.HasError() || d.Id() != "" {
+				t.Fatalf("read: expected the volume of a deleted working environment to be removed from state, got %q %v", d.Id(), diags)
+			}
+		})
+	}
+}
+
+func TestVolumeUpdateSurfacesRequestID_mock(t *testing.T) {
+	mock, client := newMockVsa(t)
+	mock.addVolume("VsaWorkingEnvironment-mock", "svm_mockvsa", "vol1")
+	mock.override("PUT", mockOCCMAPIRoot+"/volumes/([^/]+)/([^/]+)/([^/]+)", func(w http.ResponseWriter, r *http.Request, args []string) {
+		w.Header().Set("OnCloud-Request-Id", "request-mock")
+		writeMockJSON(w, http.StatusBadRequest, map[string]interface{}{"message": "invalid tiering policy", "code": "BadRequest"})
+	})
+
+	err := client.updateVolume(context.Background(), volumeRequest{WorkingEnvironmentID: "VsaWorkingEnvironment-mock", SvmName: "svm_mockvsa", Name: "vol1"}, "mock")
+	var apiErr *restapi.APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an APIError, got %v", err)
+	}
+	if apiErr.Method != "PUT" || apiErr.RequestID != "request-mock" || apiErr.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected the method and request ID of the response, got %+v", apiErr)
+	}
+}
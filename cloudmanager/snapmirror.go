@@ -1,6 +1,7 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -48,15 +49,120 @@ type interClusterLifsAddress struct {
 	Address string `json:"address"`
 }
 
+type snapMirrorUpdateRequest struct {
+	PolicyName      string `structs:"policyName"`
+	ScheduleName    string `structs:"scheduleName"`
+	MaxTransferRate int    `structs:"maxTransferRate"`
+}
+
 type snapMirrorStatusResponse struct {
-	Destination destination `structs:"destination"`
+	Destination        destination `json:"destination"`
+	PolicyName         string      `json:"policyName"`
+	ScheduleName       string      `json:"scheduleName"`
+	MaxTransferRate    size        `json:"maxTransferRate"`
+	MirrorState        string      `json:"mirrorState"`
+	RelationshipStatus string      `json:"relationshipStatus"`
+	LagTime            lagTime     `json:"lagTime"`
+	LastTransferInfo   transfer    `json:"lastTransferInfo"`
+	Healthy            bool        `json:"healthy"`
+	UnhealthyReason    string      `json:"unhealthyReason"`
+}
+
+type lagTime struct {
+	Length float64 `json:"length"`
+	Unit   string  `json:"unit"`
+}
+
+type transfer struct {
+	TransferSize size `json:"transferSize"`
+	// TransferEndTime is in milliseconds since the epoch
+	TransferEndTime int64 `json:"transferEndTime"`
+}
+
+// seconds returns the lag time in seconds
+func (l lagTime) seconds() int {
+	switch strings.ToUpper(l.Unit) {
+	case "MINUTES":
+		return int(l.Length * 60)
+	case "HOURS":
+		return int(l.Length * 3600)
+	case "DAYS":
+		return int(l.Length * 86400)
+	}
+	return int(l.Length)
+}
+
+// snapMirrorAction is an operation on a relationship, Reversed being set when it applies to
+// the relationship going back from the destination to the source after a reverse resync
+type snapMirrorAction struct {
+	Name     string
+	Reversed bool
+}
+
+// snapMirrorStates are the states a relationship can be moved between with the state argument
+var snapMirrorStates = []string{"snapmirrored", "quiesced", "broken_off", "reversed"}
+
+// snapMirrorTransition returns the actions moving a relationship from one state to another
+func snapMirrorTransition(from string, to string) ([]snapMirrorAction, error) {
+	if from == to {
+		return nil, nil
+	}
+	switch from {
+	case "snapmirrored":
+		switch to {
+		case "quiesced":
+			return []snapMirrorAction{{Name: "quiesce"}}, nil
+		case "broken_off":
+			return []snapMirrorAction{{Name: "quiesce"}, {Name: "break"}}, nil
+		case "reversed":
+			return []snapMirrorAction{{Name: "quiesce"}, {Name: "break"}, {Name: "reverse-resync"}}, nil
+		}
+	case "quiesced":
+		switch to {
+		case "snapmirrored":
+			return []snapMirrorAction{{Name: "resume"}}, nil
+		case "broken_off":
+			return []snapMirrorAction{{Name: "break"}}, nil
+		case "reversed":
+			return []snapMirrorAction{{Name: "break"}, {Name: "reverse-resync"}}, nil
+		}
+	case "broken_off":
+		switch to {
+		case "snapmirrored":
+			return []snapMirrorAction{{Name: "resync"}}, nil
+		case "quiesced":
+			return []snapMirrorAction{{Name: "resync"}, {Name: "quiesce"}}, nil
+		case "reversed":
+			return []snapMirrorAction{{Name: "reverse-resync"}}, nil
+		}
+	case "reversed":
+		// fail back by reversing the reversed relationship, which restores the original one
+		failback := []snapMirrorAction{{Name: "quiesce", Reversed: true}, {Name: "break", Reversed: true}, {Name: "reverse-resync", Reversed: true}}
+		if to == "snapmirrored" {
+			return failback, nil
+		}
+		next, err := snapMirrorTransition("snapmirrored", to)
+		if err != nil {
+			return nil, err
+		}
+		return append(failback, next...), nil
+	}
+	return nil, fmt.Errorf("cannot move the SnapMirror relationship from %s to %s", from, to)
+}
+
+// snapMirrorState returns the state of the relationship as used by the state argument
+func snapMirrorState(status snapMirrorStatusResponse) string {
+	if strings.EqualFold(status.RelationshipStatus, "quiesced") {
+		return "quiesced"
+	}
+	return strings.ReplaceAll(strings.ToLower(status.MirrorState), "-", "_")
 }
 
 type destination struct {
-	VolumeName string `structs:"volumeName"`
+	VolumeName string `json:"volumeName"`
 }
 
-func (c *Client) getInterclusterlifs(snapMirror snapMirrorRequest, clientID string) (interclusterlif, error) {
+func (c *Client) getInterclusterlifs(ctx context.Context, snapMirror snapMirrorRequest, clientID string) (interclusterlif, error) {
 	var destinationWEID string
 	if snapMirror.ReplicationRequest.DestinationFsxID != "" {
 		destinationWEID = snapMirror.ReplicationRequest.DestinationFsxID
@@ -66,15 +172,11 @@ func (c *Client) getInterclusterlifs(snapMirror snapMirrorRequest, clientID stri
 
 	baseURL := fmt.Sprintf("/occm/api/replication/intercluster-lifs?peerWorkingEnvironmentId=%s&workingEnvironmentId=%s", destinationWEID, snapMirror.ReplicationRequest.SourceWorkingEnvironmentID)
 	hostType := "CloudManagerHost"
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("intercluster-lifs reading failed ", statusCode)
 		return interclusterlif{}, err
 	}
-	responseError := apiResponseChecker(statusCode, response, "intercluster-lifs")
-	if responseError != nil {
-		return interclusterlif{}, responseError
-	}
 	var interclusterlifsResponse interclusterlif
 
 	if err := json.Unmarshal(response, &interclusterlifsResponse); err != nil {
@@ -85,16 +187,15 @@ func (c *Client) getInterclusterlifs(snapMirror snapMirrorRequest, clientID stri
 	return interclusterlifsResponse, nil
 }
 
-func (c *Client) buildSnapMirrorCreate(snapMirror snapMirrorRequest, sourceWorkingEnvironmentType string, destWorkingEnvironmentType string, clientID string) (snapMirrorRequest, error) {
+func (c *Client) buildSnapMirrorCreate(ctx context.Context, snapMirror snapMirrorRequest, sourceWorkingEnvironmentType string, destWorkingEnvironmentType string, clientID string) (snapMirrorRequest, error) {
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in createSnapMirror request, failed to get AccessToken")
 		return snapMirrorRequest{}, err
 	}
-	c.Token = accessTokenResult.Token
 
-	interclusterlifsResponse, err := c.getInterclusterlifs(snapMirror, clientID)
+	interclusterlifsResponse, err := c.getInterclusterlifs(ctx, snapMirror, clientID)
 	if err != nil {
 		log.Print("intercluster-lifs reading failed")
 		return snapMirrorRequest{}, err
@@ -111,13 +212,13 @@ func (c *Client) buildSnapMirrorCreate(snapMirror snapMirrorRequest, sourceWorki
 	volumeS.Name = snapMirror.ReplicationVolume.SourceVolumeName
 
 	if sourceWorkingEnvironmentType != "ON_PREM" {
-		volumeSource, err = c.getVolume(volumeS, clientID)
+		volumeSource, err = c.getVolume(ctx, volumeS, clientID)
 		if err != nil {
 			log.Print("Error reading source volume")
 			return snapMirrorRequest{}, err
 		}
 	} else {
-		volumeSource, err = c.getVolumeForOnPrem(volumeS, clientID)
+		volumeSource, err = c.getVolumeForOnPrem(ctx, volumeS, clientID)
 		if err != nil {
 			log.Print("Error reading source onPrem volume")
 			return snapMirrorRequest{}, err
@@ -148,21 +249,21 @@ func (c *Client) buildSnapMirrorCreate(snapMirror snapMirrorRequest, sourceWorki
 	}
 
 	if destWorkingEnvironmentType != "ON_PREM" && snapMirror.ReplicationRequest.DestinationFsxID == "" {
-		quote := c.buildQuoteRequest(snapMirror, volDestQuote, snapMirror.ReplicationRequest.SourceWorkingEnvironmentID, sourceWorkingEnvironmentType, snapMirror.ReplicationVolume.DestinationVolumeName, snapMirror.ReplicationVolume.DestinationSvmName, snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID, clientID)
+		quote := c.buildQuoteRequest(ctx, snapMirror, volDestQuote, snapMirror.ReplicationRequest.SourceWorkingEnvironmentID, sourceWorkingEnvironmentType, snapMirror.ReplicationVolume.DestinationVolumeName, snapMirror.ReplicationVolume.DestinationSvmName, snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID, clientID)
 		if quote.ProviderVolumeType == "" {
 			return snapMirrorRequest{}, fmt.Errorf("provider_volume_type is required")
 		}
-		quoteResponse, err := c.quoteVolume(quote, clientID)
+		quoteResponse, err := c.quoteVolume(ctx, quote, clientID)
 		if err != nil {
 			log.Printf("Error quoting destination volume")
 			return snapMirrorRequest{}, err
 		}
-		snapMirror.ReplicationVolume.NumOfDisksApprovedToAdd = quoteResponse["numOfDisks"].(float64)
+		snapMirror.ReplicationVolume.NumOfDisksApprovedToAdd = quoteResponse.NumOfDisks
 		if snapMirror.ReplicationVolume.DestinationAggregateName != "" {
 			snapMirror.ReplicationVolume.AdvancedMode = true
 		} else {
 			snapMirror.ReplicationVolume.AdvancedMode = false
-			snapMirror.ReplicationVolume.DestinationAggregateName = quoteResponse["aggregateName"].(string)
+			snapMirror.ReplicationVolume.DestinationAggregateName = quoteResponse.AggregateName
 		}
 		if quote.Iops != 0 {
 			snapMirror.ReplicationVolume.Iops = quote.Iops
@@ -185,7 +286,7 @@ func (c *Client) buildSnapMirrorCreate(snapMirror snapMirrorRequest, sourceWorki
 		snapMirror.ReplicationVolume.DestinationProviderVolumeType = sourceVolume.ProviderVolumeType
 	}
 
-	err = c.createSnapMirror(snapMirror, destWorkingEnvironmentType, clientID)
+	err = c.createSnapMirror(ctx, snapMirror, destWorkingEnvironmentType, clientID)
 	if err != nil {
 		log.Printf("Error creating snapmirror")
 		return snapMirrorRequest{}, err
@@ -194,7 +295,7 @@ func (c *Client) buildSnapMirrorCreate(snapMirror snapMirrorRequest, sourceWorki
 	return snapMirror, nil
 }
 
-func (c *Client) buildQuoteRequest(snapMirror snapMirrorRequest, vol volumeResponse, sourceWorkingEnvironmentID string, sourceWorkingEnvironmentType string, name string, svm string, workingEnvironmentID string, clientID string) quoteRequest {
+func (c *Client) buildQuoteRequest(ctx context.Context, snapMirror snapMirrorRequest, vol volumeResponse, sourceWorkingEnvironmentID string, sourceWorkingEnvironmentType string, name string, svm string, workingEnvironmentID string, clientID string) quoteRequest {
 	var quote quoteRequest
 
 	quote.Name = name
@@ -217,7 +318,7 @@ func (c *Client) buildQuoteRequest(snapMirror snapMirrorRequest, vol volumeRespo
 			quote.Throughput = snapMirror.ReplicationVolume.Throughput
 		}
 	} else {
-		aggregate, err := c.getAggregate(aggregateRequest{WorkingEnvironmentID: sourceWorkingEnvironmentID}, vol.AggregateName, sourceWorkingEnvironmentType, clientID)
+		aggregate, err := c.getAggregate(ctx, aggregateRequest{WorkingEnvironmentID: sourceWorkingEnvironmentID}, vol.AggregateName, sourceWorkingEnvironmentType, clientID)
 		if err != nil {
 			log.Printf("Error getting aggregate. aggregate name = %v", vol.AggregateName)
 		}
@@ -244,7 +345,7 @@ func (c *Client) buildQuoteRequest(snapMirror snapMirrorRequest, vol volumeRespo
 	return quote
 }
 
-func (c *Client) createSnapMirror(sm snapMirrorRequest, destWorkingEnvironmentType string, clientID string) error {
+func (c *Client) createSnapMirror(ctx context.Context, sm snapMirrorRequest, destWorkingEnvironmentType string, clientID string) error {
 	var baseURL string
 	if sm.ReplicationRequest.DestinationFsxID != "" {
 		baseURL = "/occm/api/replication/fsx"
@@ -256,17 +357,12 @@ func (c *Client) createSnapMirror(sm snapMirrorRequest, destWorkingEnvironmentTy
 	hostType := "CloudManagerHost"
 
 	params := structs.Map(sm)
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
 	if err != nil {
 		log.Print("createSnapMirror request failed ", statusCode)
 		return err
 	}
-	responseError := apiResponseChecker(statusCode, response, "createSnapMirror")
-	if responseError != nil {
-		return responseError
-	}
-
-	err = c.waitOnCompletion(onCloudRequestID, "snapmirror", "create", 10, 10, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "snapmirror", "create", 10, clientID)
 	if err != nil {
 		return err
 	}
@@ -274,29 +370,46 @@ func (c *Client) createSnapMirror(sm snapMirrorRequest, destWorkingEnvironmentTy
 	return nil
 }
 
-func (c *Client) deleteSnapMirror(snapMirror snapMirrorRequest, clientID string) error {
+func (c *Client) deleteSnapMirror(ctx context.Context, snapMirror snapMirrorRequest, clientID string) error {
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
 		log.Print("in deleteSnapMirror request, failed to get AccessToken")
 		return err
 	}
-	c.Token = accessTokenResult.Token
 	baseURL := fmt.Sprintf("/occm/api/replication/%s/%s/%s", snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID, snapMirror.ReplicationVolume.DestinationSvmName, snapMirror.ReplicationVolume.DestinationVolumeName)
 	hostType := "CloudManagerHost"
 
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Printf("deleteSnapMirror request failed with statusCode:%v, Error:%v", statusCode, err)
 		return err
 	}
 
-	responseError := apiResponseChecker(statusCode, response, "deleteSnapMirror")
-	if responseError != nil {
-		return responseError
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "snapmirror", "delete", 10, clientID)
+	if err != nil {
+		return err
 	}
 
-	err = c.waitOnCompletion(onCloudRequestID, "snapmirror", "delete", 10, 10, clientID)
+	return nil
+}
+
+func (c *Client) updateSnapMirror(ctx context.Context, snapMirror snapMirrorRequest, clientID string) error {
+	baseURL := fmt.Sprintf("/occm/api/replication/%s/%s/%s", snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID, snapMirror.ReplicationVolume.DestinationSvmName, snapMirror.ReplicationVolume.DestinationVolumeName)
+	hostType := "CloudManagerHost"
+
+	request := snapMirrorUpdateRequest{
+		PolicyName:      snapMirror.ReplicationRequest.PolicyName,
+		ScheduleName:    snapMirror.ReplicationRequest.ScheduleName,
+		MaxTransferRate: snapMirror.ReplicationRequest.MaxTransferRate,
+	}
+	params := structs.Map(request)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "PUT", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("updateSnapMirror request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "snapmirror", "update", 10, clientID)
 	if err != nil {
 		return err
 	}
@@ -304,38 +417,52 @@ func (c *Client) deleteSnapMirror(snapMirror snapMirrorRequest, clientID string)
 	return nil
 }
 
-func (c *Client) getSnapMirror(snapMirror snapMirrorRequest, vol string, clientID string) (string, error) {
+// runSnapMirrorAction runs the action (quiesce, resume, break, resync or reverse-resync) on the relationship to the destination volume
+func (c *Client) runSnapMirrorAction(ctx context.Context, snapMirror snapMirrorRequest, action string, clientID string) error {
+	baseURL := fmt.Sprintf("/occm/api/replication/%s/%s/%s/%s", action, snapMirror.ReplicationRequest.DestinationWorkingEnvironmentID, snapMirror.ReplicationVolume.DestinationSvmName, snapMirror.ReplicationVolume.DestinationVolumeName)
+	hostType := "CloudManagerHost"
+
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Printf("%s SnapMirror request failed %v", action, statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "snapmirror", action, 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getSnapMirror returns the status of the relationship of the source working environment to the destination volume vol
+func (c *Client) getSnapMirror(ctx context.Context, snapMirror snapMirrorRequest, vol string, clientID string) (snapMirrorStatusResponse, error) {
 
 	var result []snapMirrorStatusResponse
 
-	accessTokenResult, err := c.getAccessToken()
+	_, err := c.getAccessToken(ctx)
 	if err != nil {
-		log.Print("in createSnapMirror request, failed to get AccessToken")
-		return "", err
+		log.Print("in getSnapMirror request, failed to get AccessToken")
+		return snapMirrorStatusResponse{}, err
 	}
-	c.Token = accessTokenResult.Token
 
 	hostType := "CloudManagerHost"
 	baseURL := fmt.Sprintf("/occm/api/replication/status/%s", snapMirror.ReplicationRequest.SourceWorkingEnvironmentID)
 
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getSnapMirror request failed ", statusCode)
-		return "", err
-	}
-	responseError := apiResponseChecker(statusCode, response, "getSnapMirror")
-	if responseError != nil {
-		return "", responseError
+		return snapMirrorStatusResponse{}, err
 	}
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getSnapMirror ", err)
-		return "", err
+		return snapMirrorStatusResponse{}, err
 	}
 	for _, sm := range result {
 		if sm.Destination.VolumeName == vol {
-			return vol, nil
+			return sm, nil
 		}
 	}
 
-	return "", nil
+	return snapMirrorStatusResponse{}, fmt.Errorf("snapmirror to volume %s %w", vol, errNotFound)
 }
@@ -0,0 +1,127 @@
+package cloudmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+type snapshotRequest struct {
+	SnapshotName string `structs:"snapshotName"`
+}
+
+// snapshotRestoreRequest restores a single file or directory of the snapshot when FilePath is set,
+// to RestorePath when set instead of over the original path
+type snapshotRestoreRequest struct {
+	FilePath    string `structs:"filePath,omitempty"`
+	RestorePath string `structs:"restorePath,omitempty"`
+}
+
+type snapshotResponse struct {
+	Name string `json:"name"`
+}
+
+// snapshotsURL returns the URL of the snapshots of the volume, in the working environment or FSx file system
+func (c *Client) snapshotsURL(ctx context.Context, vol volumeRequest, clientID string) (string, error) {
+	baseURL, err := c.volumeURL(ctx, vol, clientID)
+	if err != nil {
+		return "", err
+	}
+	return baseURL + "/snapshots", nil
+}
+
+func (c *Client) createSnapshot(ctx context.Context, vol volumeRequest, name string, clientID string) error {
+	baseURL, err := c.snapshotsURL(ctx, vol, clientID)
+	if err != nil {
+		return err
+	}
+	hostType := "CloudManagerHost"
+	params := structs.Map(snapshotRequest{SnapshotName: name})
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("createSnapshot request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "snapshot", "create", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getSnapshot returns the snapshot of the volume with the given name
+func (c *Client) getSnapshot(ctx context.Context, vol volumeRequest, name string, clientID string) (snapshotResponse, error) {
+	baseURL, err := c.snapshotsURL(ctx, vol, clientID)
+	if err != nil {
+		return snapshotResponse{}, err
+	}
+	hostType := "CloudManagerHost"
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("getSnapshot request failed ", statusCode)
+		return snapshotResponse{}, err
+	}
+	var result []snapshotResponse
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getSnapshot ", err)
+		return snapshotResponse{}, err
+	}
+	for _, snapshot := range result {
+		if snapshot.Name == name {
+			return snapshot, nil
+		}
+	}
+
+	return snapshotResponse{}, fmt.Errorf("snapshot %s %w", name, errNotFound)
+}
+
+func (c *Client) deleteSnapshot(ctx context.Context, vol volumeRequest, name string, clientID string) error {
+	baseURL, err := c.snapshotsURL(ctx, vol, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/%s", baseURL, name)
+	hostType := "CloudManagerHost"
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("deleteSnapshot request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "snapshot", "delete", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// restoreSnapshot reverts the volume, or the file of the request, to the snapshot, discarding the changes made
+// since it was taken. It returns the ID of the restore task.
+func (c *Client) restoreSnapshot(ctx context.Context, vol volumeRequest, name string, request snapshotRestoreRequest, clientID string) (string, error) {
+	baseURL, err := c.snapshotsURL(ctx, vol, clientID)
+	if err != nil {
+		return "", err
+	}
+	baseURL = fmt.Sprintf("%s/%s/restore", baseURL, name)
+	hostType := "CloudManagerHost"
+	params := structs.Map(request)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("restoreSnapshot request failed ", statusCode)
+		return "", err
+	}
+	// without a task to wait on, the restore cannot be known to have completed
+	if onCloudRequestID == "" {
+		return "", fmt.Errorf("restoreSnapshot: no OnCloud-Request-Id returned for the restore of snapshot %s, cannot wait on its completion", name)
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "snapshot", "restore", 10, clientID)
+	if err != nil {
+		return "", err
+	}
+
+	return onCloudRequestID, nil
+}
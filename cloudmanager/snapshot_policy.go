@@ -0,0 +1,99 @@
+package cloudmanager
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+// expandSnapshotPolicySchedules converts the schedule blocks of a snapshot policy to the API schedules
+func expandSnapshotPolicySchedules(scheduleSet []interface{}) []scheduleReq {
+	scheduleConfigs := make([]scheduleReq, 0, len(scheduleSet))
+	for _, x := range scheduleSet {
+		snapshotPolicySchedule := scheduleReq{}
+		scheduleConfig := x.(map[string]interface{})
+		snapshotPolicySchedule.ScheduleType = scheduleConfig["schedule_type"].(string)
+		snapshotPolicySchedule.Retention = scheduleConfig["retention"].(int)
+
+		scheduleConfigs = append(scheduleConfigs, snapshotPolicySchedule)
+	}
+	return scheduleConfigs
+}
+
+// flattenSnapshotPolicySchedules converts the schedules of a snapshot policy read from the API to schedule blocks
+func flattenSnapshotPolicySchedules(schedules []policySchedule) []interface{} {
+	result := make([]interface{}, 0, len(schedules))
+	for _, schedule := range schedules {
+		result = append(result, map[string]interface{}{
+			"schedule_type": schedule.Frequency,
+			"retention":     schedule.Retention,
+		})
+	}
+	return result
+}
+
+// sendSnapshotPolicy creates (POST) or modifies (PUT) the snapshot policy and waits until it is found on the working environment
+func (c *Client) sendSnapshotPolicy(ctx context.Context, method string, snapshotPolicy createSnapshotPolicyRequest, clientID string) error {
+	baseURL, _, err := c.getAPIRoot(ctx, snapshotPolicy.WorkingEnvironmentID, clientID)
+	hostType := "CloudManagerHost"
+	if err != nil {
+		return err
+	}
+	action := "create"
+	if method == "PUT" {
+		action = "update"
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/snapshot-policy", baseURL, snapshotPolicy.WorkingEnvironmentID)
+	param := structs.Map(snapshotPolicy)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, method, baseURL, param, true, hostType, clientID)
+	if err != nil {
+		log.Printf("%s snapshotPolicy request failed %v", action, statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "snapshotPolicy", action, 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	if c.findSnapshotPolicy(ctx, snapshotPolicy.WorkingEnvironmentID, snapshotPolicy.SnapshotPolicyName, clientID) {
+		return nil
+	}
+	return fmt.Errorf("%s snapshot policy failed", action)
+}
+
+// getSnapshotPolicy returns the snapshot policy of the working environment with the given name
+func (c *Client) getSnapshotPolicy(ctx context.Context, workingEnvironmentID string, snapshotPolicyName string, clientID string) (cvoSnapshotPolicy, error) {
+	resp, err := c.getCVOProperties(ctx, workingEnvironmentID, clientID)
+	if err != nil {
+		log.Print("cannot find working environment ", workingEnvironmentID)
+		return cvoSnapshotPolicy{}, err
+	}
+	for _, snapshotPolicy := range resp.SnapshotPolicies {
+		if snapshotPolicy.Name == snapshotPolicyName {
+			return snapshotPolicy, nil
+		}
+	}
+	return cvoSnapshotPolicy{}, fmt.Errorf("snapshot policy %s %w", snapshotPolicyName, errNotFound)
+}
+
+func (c *Client) deleteSnapshotPolicy(ctx context.Context, workingEnvironmentID string, snapshotPolicyName string, clientID string) error {
+	baseURL, _, err := c.getAPIRoot(ctx, workingEnvironmentID, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/snapshot-policy/%s", baseURL, workingEnvironmentID, snapshotPolicyName)
+	hostType := "CloudManagerHost"
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("deleteSnapshotPolicy request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "snapshotPolicy", "delete", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package cloudmanager
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/fatih/structs"
+)
+
+type svmRequest struct {
+	SvmName string `structs:"svmName"`
+}
+
+// svmResponse is an SVM of a CVO or FSx working environment, with its network interfaces
+type svmResponse struct {
+	Name          string   `json:"name"`
+	State         string   `json:"state"`
+	ManagementLif svmLif   `json:"managementLif"`
+	DataLifs      []svmLif `json:"dataLifs"`
+}
+
+type svmLif struct {
+	Name          string   `json:"name"`
+	IP            string   `json:"ip"`
+	NodeName      string   `json:"nodeName"`
+	DataProtocols []string `json:"dataProtocols"`
+}
+
+func flattenSVMDataLifs(lifs []svmLif) []interface{} {
+	result := make([]interface{}, 0, len(lifs))
+	for _, lif := range lifs {
+		result = append(result, map[string]interface{}{
+			"name":      lif.Name,
+			"ip":        lif.IP,
+			"node_name": lif.NodeName,
+			"protocols": lif.DataProtocols,
+		})
+	}
+	return result
+}
+
+// createSVM adds an SVM to the working environment, which can be a CVO of any cloud provider or a FSx file system
+func (c *Client) createSVM(ctx context.Context, id string, svm svmRequest, clientID string) error {
+	baseURL, _, err := c.getAPIRoot(ctx, id, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/svm", baseURL, id)
+	hostType := "CloudManagerHost"
+	params := structs.Map(svm)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
+	if err != nil {
+		log.Print("createSVM request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "SVM", "create", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getSVM returns the SVM of the working environment, or errNotFound
+func (c *Client) getSVM(ctx context.Context, id string, name string, clientID string) (svmResponse, error) {
+	baseURL, _, err := c.getAPIRoot(ctx, id, clientID)
+	if err != nil {
+		return svmResponse{}, err
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/svms", baseURL, id)
+	hostType := "CloudManagerHost"
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("getSVM request failed ", statusCode)
+		return svmResponse{}, err
+	}
+	var result []svmResponse
+	if err := json.Unmarshal(response, &result); err != nil {
+		log.Print("Failed to unmarshall response from getSVM ", err)
+		return svmResponse{}, err
+	}
+	for _, svm := range result {
+		if svm.Name == name {
+			return svm, nil
+		}
+	}
+	return svmResponse{}, fmt.Errorf("SVM %s: %w", name, errNotFound)
+}
+
+func (c *Client) renameSVM(ctx context.Context, id string, name string, newName string, clientID string) error {
+	request := svmNameModificationRequest{SvmName: name, SvmNewName: newName}
+	baseURL := fmt.Sprintf("/working-environments/%s/svm", id)
+	return c.callCMUpdateAPI(ctx, "PUT", request, baseURL, id, "renameSVM", clientID)
+}
+
+func (c *Client) deleteSVM(ctx context.Context, id string, name string, clientID string) error {
+	baseURL, _, err := c.getAPIRoot(ctx, id, clientID)
+	if err != nil {
+		return err
+	}
+	baseURL = fmt.Sprintf("%s/working-environments/%s/svm/%s", baseURL, id, name)
+	hostType := "CloudManagerHost"
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
+	if err != nil {
+		log.Print("deleteSVM request failed ", statusCode)
+		return err
+	}
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "SVM", "delete", 10, clientID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package cloudmanager
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -8,7 +9,7 @@ import (
 	"strings"
 
 	"github.com/fatih/structs"
-	"github.com/hashicorp/terraform/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 type volumeRequest struct {
@@ -94,6 +95,13 @@ type quoteRequest struct {
 	WorkingEnvironmentType string           `structs:"workingEnvironmentType"`
 }
 
+// volumeQuoteResponse is the aggregate a new or resized volume is placed on
+type volumeQuoteResponse struct {
+	NewAggregate  bool    `json:"newAggregate"`
+	AggregateName string  `json:"aggregateName"`
+	NumOfDisks    float64 `json:"numOfDisks"`
+}
+
 type shareInfoRequest struct {
 	ShareName     string        `structs:"shareName,omitempty"`
 	AccessControl accessControl `structs:"accessControl,omitempty"`
@@ -134,14 +142,6 @@ type iscsiInfo struct {
 	Igroups []string `structs:"igroups,omitempty"`
 }
 
-type initiator struct {
-	AliasName              string `structs:"aliasName,omitempty"`
-	Iqn                    string `structs:"iqn,omitempty"`
-	WorkingEnvironmentID   string `structs:"workingEnvironmentId,omitempty"`
-	SvmName                string `structs:"svmName,omitempty"`
-	WorkingEnvironmentType string `structs:"workingEnvironmentType,omitempty"`
-}
-
 type volumeTag struct {
 	TagKey   string `structs:"tagKey"`
 	TagValue string `structs:"tagValue"`
@@ -152,19 +152,24 @@ type createSnapshotPolicyRequest struct {
 	WorkingEnvironmentID string        `structs:"workingEnvironmentId"`
 }
 
+type cloneVolumeRequest struct {
+	NewVolumeName  string `structs:"newVolumeName"`
+	ParentSnapshot string `structs:"parentSnapshot,omitempty"`
+}
+
 type scheduleReq struct {
 	ScheduleType string `structs:"scheduleType"`
 	Retention    int    `structs:"retention"`
 }
 
-func (c *Client) createVolume(vol volumeRequest, createAggregateIfNotFound bool, clientID string) error {
+func (c *Client) createVolume(ctx context.Context, vol volumeRequest, createAggregateIfNotFound bool, clientID string) error {
 	var id string
 	if vol.FileSystemID != "" {
 		id = vol.FileSystemID
 	} else {
 		id = vol.WorkingEnvironmentID
 	}
-	baseURL, _, err := c.getAPIRoot(id, clientID)
+	baseURL, _, err := c.getAPIRoot(ctx, id, clientID)
 	if err != nil {
 		return err
 	}
@@ -175,16 +180,12 @@ func (c *Client) createVolume(vol volumeRequest, createAggregateIfNotFound bool,
 	}
 	hostType := "CloudManagerHost"
 	param := structs.Map(vol)
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, param, c.Token, hostType, clientID)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, param, true, hostType, clientID)
 	if err != nil {
 		log.Print("createVolume request failed ", statusCode)
 		return err
 	}
-	responseError := apiResponseChecker(statusCode, response, "createVolume")
-	if responseError != nil {
-		return responseError
-	}
-	err = c.waitOnCompletion(onCloudRequestID, "volume", "create", 40, 10, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "volume", "create", 10, clientID)
 	if err != nil {
 		return err
 	}
@@ -192,32 +193,27 @@ func (c *Client) createVolume(vol volumeRequest, createAggregateIfNotFound bool,
 	return nil
 }
 
-func (c *Client) deleteVolume(vol volumeRequest, clientID string) error {
+func (c *Client) deleteVolume(ctx context.Context, vol volumeRequest, clientID string) error {
 	var id string
 	if vol.FileSystemID != "" {
 		id = vol.FileSystemID
 	} else {
 		id = vol.WorkingEnvironmentID
 	}
-	baseURL, _, err := c.getAPIRoot(id, clientID)
+	baseURL, _, err := c.getAPIRoot(ctx, id, clientID)
 	if err != nil {
 		return err
 	}
 	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s", baseURL, id, vol.SvmName, vol.Name)
 	hostType := "CloudManagerHost"
 
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("DELETE", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "DELETE", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("deleteVolume request failed ", statusCode)
 		return err
 	}
-	responseError := apiResponseChecker(statusCode, response, "deleteVolume")
-	if responseError != nil {
-		return responseError
-	}
-
 	log.Print("Wait for volume deletion.")
-	err = c.waitOnCompletion(onCloudRequestID, "volume", "delete", 10, 60, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "volume", "delete", 60, clientID)
 	if err != nil {
 		log.Print("deleteVolume request failed ", statusCode)
 		return err
@@ -225,7 +221,7 @@ func (c *Client) deleteVolume(vol volumeRequest, clientID string) error {
 	return nil
 }
 
-func (c *Client) getVolume(vol volumeRequest, clientID string) ([]volumeResponse, error) {
+func (c *Client) getVolume(ctx context.Context, vol volumeRequest, clientID string) ([]volumeResponse, error) {
 	var result []volumeResponse
 	hostType := "CloudManagerHost"
 	var id string
@@ -234,7 +230,7 @@ func (c *Client) getVolume(vol volumeRequest, clientID string) ([]volumeResponse
 	} else {
 		id = vol.WorkingEnvironmentID
 	}
-	baseURL, _, err := c.getAPIRoot(id, clientID)
+	baseURL, _, err := c.getAPIRoot(ctx, id, clientID)
 	if err != nil {
 		return result, err
 	}
@@ -244,15 +240,11 @@ func (c *Client) getVolume(vol volumeRequest, clientID string) ([]volumeResponse
 		baseURL = fmt.Sprintf("%s/volumes?workingEnvironmentId=%s", baseURL, id)
 	}
 
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getVolume request failed ", statusCode)
 		return result, err
 	}
-	responseError := apiResponseChecker(statusCode, response, "getVolume")
-	if responseError != nil {
-		return result, responseError
-	}
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getVolume ", err)
 		return result, err
@@ -261,20 +253,16 @@ func (c *Client) getVolume(vol volumeRequest, clientID string) ([]volumeResponse
 	return result, nil
 }
 
-func (c *Client) getVolumeForOnPrem(vol volumeRequest, clientID string) ([]volumeResponse, error) {
+func (c *Client) getVolumeForOnPrem(ctx context.Context, vol volumeRequest, clientID string) ([]volumeResponse, error) {
 	var result []volumeResponse
 	hostType := "CloudManagerHost"
 	baseURL := fmt.Sprintf("/occm/api/onprem/volumes?workingEnvironmentId=%s", vol.WorkingEnvironmentID)
 
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("getVolumeForOnPrem request failed ", statusCode)
 		return result, err
 	}
-	responseError := apiResponseChecker(statusCode, response, "getVolumeForOnPrem")
-	if responseError != nil {
-		return result, responseError
-	}
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from getVolumeForOnPrem ", err)
 		return result, err
@@ -283,8 +271,8 @@ func (c *Client) getVolumeForOnPrem(vol volumeRequest, clientID string) ([]volum
 	return result, nil
 }
 
-func (c *Client) getVolumeByID(request volumeRequest, clientID string) (volumeResponse, error) {
-	res, err := c.getVolume(request, clientID)
+func (c *Client) getVolumeByID(ctx context.Context, request volumeRequest, clientID string) (volumeResponse, error) {
+	res, err := c.getVolume(ctx, request, clientID)
 	if err != nil {
 		return volumeResponse{}, err
 	}
@@ -293,10 +281,10 @@ func (c *Client) getVolumeByID(request volumeRequest, clientID string) (volumeRe
 			return vol, nil
 		}
 	}
-	return volumeResponse{}, fmt.Errorf("error fetching volume: volume doesn't exist")
+	return volumeResponse{}, fmt.Errorf("error fetching volume: volume %w", errNotFound)
 }
 
-func (c *Client) updateVolume(request volumeRequest, clientID string) error {
+func (c *Client) updateVolume(ctx context.Context, request volumeRequest, clientID string) error {
 	hostType := "CloudManagerHost"
 	var id string
 	if request.FileSystemID != "" {
@@ -304,137 +292,92 @@ func (c *Client) updateVolume(request volumeRequest, clientID string) error {
 	} else {
 		id = request.WorkingEnvironmentID
 	}
-	baseURL, _, err := c.getAPIRoot(id, clientID)
+	baseURL, _, err := c.getAPIRoot(ctx, id, clientID)
 	if err != nil {
 		return err
 	}
 	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s", baseURL, id, request.SvmName, request.Name)
 	params := structs.Map(request)
-	statusCode, response, _, err := c.CallAPIMethod("PUT", baseURL, params, c.Token, hostType, clientID)
-
-	responseError := apiResponseChecker(statusCode, response, "updateVolume")
-	if responseError != nil {
-		return responseError
-	}
-
+	statusCode, _, _, err := c.CallAPIMethod(ctx, "PUT", baseURL, params, true, hostType, clientID)
 	if err != nil {
 		log.Print("updateVolume request failed ", statusCode)
 		return err
 	}
+
 	return nil
 }
 
-func (c *Client) quoteVolume(request quoteRequest, clientID string) (map[string]interface{}, error) {
-	hostType := "CloudManagerHost"
-	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID)
-	if err != nil {
-		return nil, err
-	}
-	baseURL = fmt.Sprintf("%s/volumes/quote", baseURL)
-	params := structs.Map(request)
-
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+// cloneVolume creates a FlexClone of the parent volume, from its parent snapshot when given or from a new snapshot
+func (c *Client) cloneVolume(ctx context.Context, parent volumeRequest, request cloneVolumeRequest, clientID string) error {
+	baseURL, _, err := c.getAPIRoot(ctx, parent.WorkingEnvironmentID, clientID)
 	if err != nil {
-		log.Print("quoteVolume request failed ", statusCode)
-		return nil, err
-	}
-	responseError := apiResponseChecker(statusCode, response, "quoteVolume")
-	if responseError != nil {
-		return nil, responseError
+		return err
 	}
-	var result map[string]interface{}
-	json.Unmarshal(response, &result)
-	return result, nil
-
-}
-
-func (c *Client) createInitiator(request initiator, clientID string) error {
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/clone", baseURL, parent.WorkingEnvironmentID, parent.SvmName, parent.Name)
 	hostType := "CloudManagerHost"
-	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID)
+	params := structs.Map(request)
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
 	if err != nil {
+		log.Print("cloneVolume request failed ", statusCode)
 		return err
 	}
-	baseURL = fmt.Sprintf("%s/volumes/initiator", baseURL)
-	params := structs.Map(request)
-	statusCode, response, _, err := c.CallAPIMethod("POST", baseURL, params, c.Token, hostType, clientID)
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "volume", "clone", 10, clientID)
 	if err != nil {
-		log.Print("createInitiator request failed ", statusCode)
 		return err
 	}
-	responseError := apiResponseChecker(statusCode, response, "createInitiator")
-	if responseError != nil {
-		return responseError
-	}
+
 	return nil
 }
 
-func (c *Client) getInitiator(request initiator, clientID string) ([]initiator, error) {
-	hostType := "CloudManagerHost"
-	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID)
-	var result []initiator
+// splitClone splits the clone from its parent volume, copying the shared blocks so the parent can be deleted
+func (c *Client) splitClone(ctx context.Context, vol volumeRequest, clientID string) error {
+	baseURL, _, err := c.getAPIRoot(ctx, vol.WorkingEnvironmentID, clientID)
 	if err != nil {
-		return result, err
+		return err
 	}
-	baseURL = fmt.Sprintf("%s/volumes/initiator", baseURL)
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	baseURL = fmt.Sprintf("%s/volumes/%s/%s/%s/split", baseURL, vol.WorkingEnvironmentID, vol.SvmName, vol.Name)
+	hostType := "CloudManagerHost"
+	statusCode, _, onCloudRequestID, err := c.CallAPIMethod(ctx, "POST", baseURL, nil, true, hostType, clientID)
 	if err != nil {
-		log.Print("createInitiator request failed ", statusCode)
-		return result, err
-	}
-	responseError := apiResponseChecker(statusCode, response, "createInitiator")
-	if responseError != nil {
-		return result, responseError
+		log.Print("splitClone request failed ", statusCode)
+		return err
 	}
-	if err := json.Unmarshal(response, &result); err != nil {
-		log.Print("Failed to unmarshall response from getInitiator ", err)
-		return result, err
+	err = c.waitOnCompletion(ctx, onCloudRequestID, "volume", "split", 10, clientID)
+	if err != nil {
+		return err
 	}
-	return result, nil
-}
 
-type igroup struct {
-	IgroupName             string   `json:"igroupName"`
-	OsType                 string   `json:"osType"`
-	PortsetName            string   `json:"portsetName"`
-	IgroupType             string   `json:"igroupType"`
-	Initiators             []string `json:"initiators"`
-	WorkingEnvironmentID   string   `structs:"workingEnvironmentId"`
-	SvmName                string   `structs:"svmName"`
-	WorkingEnvironmentType string   `structs:"workingEnvironmentType,omitempty"`
+	return nil
 }
 
-func (c *Client) getIgroups(request igroup, clientID string) ([]igroup, error) {
+func (c *Client) quoteVolume(ctx context.Context, request quoteRequest, clientID string) (volumeQuoteResponse, error) {
 	hostType := "CloudManagerHost"
-	baseURL, _, err := c.getAPIRoot(request.WorkingEnvironmentID, clientID)
-	var result []igroup
+	baseURL, _, err := c.getAPIRoot(ctx, request.WorkingEnvironmentID, clientID)
 	if err != nil {
-		return result, err
+		return volumeQuoteResponse{}, err
 	}
-	if request.WorkingEnvironmentType == "ON_PREM" {
-		log.Print("get igroup onPrem")
-		baseURL = fmt.Sprintf("/occm/api/ontaps/working-environments/%s/volumes/%s/igroups", request.WorkingEnvironmentID, request.SvmName)
-	} else {
-		baseURL = fmt.Sprintf("%s/volumes/igroups/%s/%s", baseURL, request.WorkingEnvironmentID, request.SvmName)
-	}
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	baseURL = fmt.Sprintf("%s/volumes/quote", baseURL)
+	params := structs.Map(request)
+
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "POST", baseURL, params, true, hostType, clientID)
 	if err != nil {
-		log.Print("getIgroups request failed ", statusCode)
-		return result, err
-	}
-	responseError := apiResponseChecker(statusCode, response, "getIgroups")
-	if responseError != nil {
-		return result, responseError
+		log.Print("quoteVolume request failed ", statusCode)
+		return volumeQuoteResponse{}, err
 	}
+	var result volumeQuoteResponse
 	if err := json.Unmarshal(response, &result); err != nil {
-		log.Print("Failed to unmarshall response from getIgroups ", err)
-		return result, err
+		log.Print("Failed to unmarshall response from quoteVolume ", err)
+		return volumeQuoteResponse{}, err
+	}
+	if result.AggregateName == "" {
+		return volumeQuoteResponse{}, fmt.Errorf("quoteVolume: response for volume %s has no aggregate: %s", request.Name, string(response))
 	}
 	return result, nil
 }
 
-func (c *Client) checkCifsExists(workingEnvironmentType string, id string, svm string, clientID string) (bool, error) {
+func (c *Client) checkCifsExists(ctx context.Context, workingEnvironmentType string, id string, svm string, clientID string) (bool, error) {
 	hostType := "CloudManagerHost"
-	baseURL, _, err := c.getAPIRoot(id, clientID)
+	baseURL, _, err := c.getAPIRoot(ctx, id, clientID)
 	var result []map[string]interface{}
 	if err != nil {
 		return false, err
@@ -444,15 +387,11 @@ func (c *Client) checkCifsExists(workingEnvironmentType string, id string, svm s
 	} else {
 		baseURL = fmt.Sprintf("%s/working-environments/%s/cifs?svm=%s", baseURL, id, svm)
 	}
-	statusCode, response, _, err := c.CallAPIMethod("GET", baseURL, nil, c.Token, hostType, clientID)
+	statusCode, response, _, err := c.CallAPIMethod(ctx, "GET", baseURL, nil, true, hostType, clientID)
 	if err != nil {
 		log.Print("chkeckCifsExists request failed ", statusCode)
 		return false, err
 	}
-	responseError := apiResponseChecker(statusCode, response, "checkCifsExists")
-	if responseError != nil {
-		return false, responseError
-	}
 	if err := json.Unmarshal(response, &result); err != nil {
 		log.Print("Failed to unmarshall response from chkeckCifsExists ", err)
 		return false, err
@@ -463,23 +402,89 @@ func (c *Client) checkCifsExists(workingEnvironmentType string, id string, svm s
 	return false, nil
 }
 
-func convertSizeUnit(size float64, from string, to string) float64 {
-	if strings.ToUpper(from) == "GB" && strings.ToUpper(to) == "GB" {
-		return size
+// quoteVolumeResize asks the quote API whether the aggregate of an existing volume can hold its new size,
+// and sets the disks to add or the new aggregate to create on the update request
+func (c *Client) quoteVolumeResize(ctx context.Context, d *schema.ResourceData, volume *volumeRequest, cloudProviderName string, clientID string) error {
+	current, err := c.getVolumeByID(ctx, volumeRequest{ID: d.Id(), WorkingEnvironmentID: volume.WorkingEnvironmentID}, clientID)
+	if err != nil {
+		return err
+	}
+	quote := quoteRequest{}
+	quote.Name = volume.Name
+	quote.Size = volume.Size
+	quote.WorkingEnvironmentID = volume.WorkingEnvironmentID
+	quote.WorkingEnvironmentType = volume.WorkingEnvironmentType
+	quote.SvmName = volume.SvmName
+	quote.AggregateName = current.AggregateName
+	quote.SnapshotPolicyName = d.Get("snapshot_policy_name").(string)
+	quote.ProviderVolumeType = d.Get("provider_volume_type").(string)
+	quote.EnableDeduplication = d.Get("enable_deduplication").(bool)
+	quote.EnableThinProvisioning = d.Get("enable_thin_provisioning").(bool)
+	quote.EnableCompression = d.Get("enable_compression").(bool)
+	// the volume already exists, so its name is expected to be taken
+	quote.VerifyNameUniqueness = false
+	if v, ok := d.GetOk("iops"); ok {
+		quote.Iops = v.(int)
+	}
+	if v, ok := d.GetOk("throughput"); ok {
+		quote.Throughput = v.(int)
+	}
+	if v, ok := d.GetOk("capacity_tier"); ok {
+		if v.(string) != "none" {
+			quote.CapacityTier = v.(string)
+			if v, ok = d.GetOk("tiering_policy"); ok && v.(string) != "none" {
+				quote.TieringPolicy = v.(string)
+			}
+		}
+	} else {
+		switch strings.ToLower(cloudProviderName) {
+		case "aws":
+			quote.CapacityTier = "S3"
+		case "azure":
+			quote.CapacityTier = "Blob"
+		case "gcp":
+			quote.CapacityTier = "cloudStorage"
+		}
 	}
-	if strings.ToUpper(from) == "GB" && strings.ToUpper(to) == "TB" {
-		size = size / 1024
+	response, err := c.quoteVolume(ctx, quote, clientID)
+	if err != nil {
+		return err
 	}
-	if strings.ToUpper(from) == "GB" && strings.ToUpper(to) == "B" {
-		size = size * 1073741824
+	volume.NewAggregate = response.NewAggregate
+	volume.AggregateName = response.AggregateName
+	volume.NumOfDisks = response.NumOfDisks
+	return nil
+}
+
+// isVolumeGrowing reports whether the planned size of the volume is larger than its current size
+func isVolumeGrowing(d *schema.ResourceData) bool {
+	oldSize, newSize := d.GetChange("size")
+	oldUnit, newUnit := d.GetChange("unit")
+	return convertSizeUnit(newSize.(float64), newUnit.(string), "B") > convertSizeUnit(oldSize.(float64), oldUnit.(string), "B")
+}
+
+// sizeUnitBytes is the number of bytes in each size unit accepted by the API
+var sizeUnitBytes = map[string]float64{
+	"B":  1,
+	"KB": 1024,
+	"MB": 1048576,
+	"GB": 1073741824,
+	"TB": 1099511627776,
+}
+
+func convertSizeUnit(size float64, from string, to string) float64 {
+	fromBytes, ok := sizeUnitBytes[strings.ToUpper(from)]
+	if !ok {
+		return size
 	}
-	if strings.ToUpper(from) == "B" && strings.ToUpper(to) == "GB" {
-		size = size / 1073741824
+	toBytes, ok := sizeUnitBytes[strings.ToUpper(to)]
+	if !ok {
+		return size
 	}
-	return size
+	return size * fromBytes / toBytes
 }
 
-func (c *Client) setCommonAttributes(WorkingEnvironmentType string, d *schema.ResourceData, volume *volumeRequest, clientID string) error {
+func (c *Client) setCommonAttributes(ctx context.Context, WorkingEnvironmentType string, d *schema.ResourceData, volume *volumeRequest, clientID string) error {
 	volume.Name = d.Get("name").(string)
 	volume.Size.Size = d.Get("size").(float64)
 	volume.Size.Unit = d.Get("unit").(string)
@@ -491,6 +496,9 @@ func (c *Client) setCommonAttributes(WorkingEnvironmentType string, d *schema.Re
 		weid = volume.WorkingEnvironmentID
 	}
 
+	if v, ok := d.GetOk("export_policy_name"); ok {
+		volume.ExportPolicyInfo.Name = v.(string)
+	}
 	if v, ok := d.GetOk("export_policy_type"); ok {
 		volume.ExportPolicyInfo.PolicyType = v.(string)
 	}
@@ -512,7 +520,7 @@ func (c *Client) setCommonAttributes(WorkingEnvironmentType string, d *schema.Re
 	volumeProtocol := d.Get("volume_protocol").(string)
 	if volumeProtocol == "cifs" {
 
-		exist, err := c.checkCifsExists(WorkingEnvironmentType, weid, volume.SvmName, clientID)
+		exist, err := c.checkCifsExists(ctx, WorkingEnvironmentType, weid, volume.SvmName, clientID)
 		if err != nil {
 			return err
 		}
@@ -537,66 +545,61 @@ func (c *Client) setCommonAttributes(WorkingEnvironmentType string, d *schema.Re
 }
 
 // createSnapshotPolicy
-func (c *Client) createSnapshotPolicy(workingEnviromentID string, snapshotPolicyName string, set *schema.Set, clientID string) error {
+func (c *Client) createSnapshotPolicy(ctx context.Context, workingEnviromentID string, snapshotPolicyName string, set *schema.Set, clientID string) error {
 	log.Print("createSnapshotPolicy: ", snapshotPolicyName)
 	snapshotPolicy := createSnapshotPolicyRequest{}
 	snapshotPolicy.SnapshotPolicyName = snapshotPolicyName
 	snapshotPolicy.WorkingEnvironmentID = workingEnviromentID
 	for _, v := range set.List() {
 		schedules := v.(map[string]interface{})
-		scheduleSet := schedules["schedule"].([]interface{})
-		scheduleConfigs := make([]scheduleReq, 0, len(scheduleSet))
-		for _, x := range scheduleSet {
-			snapshotPolicySchedule := scheduleReq{}
-			scheduleConfig := x.(map[string]interface{})
-			snapshotPolicySchedule.ScheduleType = scheduleConfig["schedule_type"].(string)
-			snapshotPolicySchedule.Retention = scheduleConfig["retention"].(int)
-
-			scheduleConfigs = append(scheduleConfigs, snapshotPolicySchedule)
-		}
-		snapshotPolicy.Schedules = scheduleConfigs
+		snapshotPolicy.Schedules = expandSnapshotPolicySchedules(schedules["schedule"].([]interface{}))
 	}
-	baseURL, _, err := c.getAPIRoot(snapshotPolicy.WorkingEnvironmentID, clientID)
-	hostType := "CloudManagerHost"
+	return c.sendSnapshotPolicy(ctx, "POST", snapshotPolicy, clientID)
+}
+
+// findSnapshotPolicy
+func (c *Client) findSnapshotPolicy(ctx context.Context, workingEnviromentID string, snapshotPolicyName string, clientID string) bool {
+	_, err := c.getSnapshotPolicy(ctx, workingEnviromentID, snapshotPolicyName, clientID)
 	if err != nil {
-		return err
+		log.Print("cannot find snapshot policy ", snapshotPolicyName)
+		return false
 	}
-	baseURL = fmt.Sprintf("%s/working-environments/%s/snapshot-policy", baseURL, snapshotPolicy.WorkingEnvironmentID)
-	param := structs.Map(snapshotPolicy)
-	statusCode, response, onCloudRequestID, err := c.CallAPIMethod("POST", baseURL, param, c.Token, hostType, clientID)
+	log.Print("found snapshot policy: ", snapshotPolicyName)
+	return true
+}
+
+// getTargetVolume returns the request addressing the volume of the resource, from its volume_name, svm_name and
+// working environment or file system
+func getTargetVolume(ctx context.Context, client *Client, d *schema.ResourceData, clientID string) (volumeRequest, error) {
+	volume := volumeRequest{}
+	weInfo, err := client.getWorkingEnvironmentDetail(ctx, d, clientID)
 	if err != nil {
-		log.Print("createSnapshotPolicy request failed ", statusCode)
-		return err
-	}
-	responseError := apiResponseChecker(statusCode, response, "createSnapshotPolicy")
-	if responseError != nil {
-		return responseError
+		return volumeRequest{}, err
 	}
-	err = c.waitOnCompletion(onCloudRequestID, "snapshotPolicy", "create", 10, 10, clientID)
-	if err != nil {
-		return err
+	if v, ok := d.GetOk("file_system_id"); ok {
+		volume.FileSystemID = v.(string)
+	} else {
+		volume.WorkingEnvironmentID = weInfo.PublicID
 	}
-
-	if c.findSnapshotPolicy(workingEnviromentID, snapshotPolicyName, clientID) {
-		return nil
+	volume.SvmName = weInfo.SvmName
+	if v, ok := d.GetOk("svm_name"); ok {
+		volume.SvmName = v.(string)
 	}
-	return fmt.Errorf("create snapshot policy failed")
+	volume.Name = d.Get("volume_name").(string)
+	return volume, nil
 }
 
-// findSnapshotPolicy
-func (c *Client) findSnapshotPolicy(workingEnviromentID string, snapshotPolicyName string, clientID string) bool {
-	resp, err := c.getCVOProperties(workingEnviromentID, clientID)
-	if err != nil {
-		log.Print("cannot find working environment ", workingEnviromentID)
-		return false
+// volumeURL returns the URL of the volume, in the working environment or FSx file system
+func (c *Client) volumeURL(ctx context.Context, vol volumeRequest, clientID string) (string, error) {
+	var id string
+	if vol.FileSystemID != "" {
+		id = vol.FileSystemID
+	} else {
+		id = vol.WorkingEnvironmentID
 	}
-	snapshotPolicies := resp.SnapshotPolicies
-	for i := range snapshotPolicies {
-		if snapshotPolicies[i].Name == snapshotPolicyName {
-			log.Print("found snapshot policy: ", snapshotPolicyName)
-			return true
-		}
+	baseURL, _, err := c.getAPIRoot(ctx, id, clientID)
+	if err != nil {
+		return "", err
 	}
-	log.Print("cannot find snapshot policy ", snapshotPolicyName)
-	return false
+	return fmt.Sprintf("%s/volumes/%s/%s/%s", baseURL, id, vol.SvmName, vol.Name), nil
 }
@@ -1,12 +1,21 @@
 package main
 
 import (
-	"github.com/hashicorp/terraform/plugin"
+	"flag"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/netapp/terraform-provider-netapp-cloudmanager/cloudmanager"
 )
 
 func main() {
+	var debug bool
+	flag.BoolVar(&debug, "debug", false, "start the provider in debug mode, for use with debuggers such as delve")
+	flag.Parse()
+
+	// plugin.Serve serves the provider over the plugin protocol version 5
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: cloudmanager.Provider,
+		ProviderAddr: "registry.terraform.io/NetApp/netapp-cloudmanager",
+		Debug:        debug,
 	})
 }